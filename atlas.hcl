@@ -0,0 +1,26 @@
+// core's migrations build on the schema arnobot-shared migrates, so they are
+// applied after shared's on the same database. They are versioned by hand
+// with `atlas migrate new` and `atlas migrate hash`, as the shared tables are
+// not part of this repo; revisions are kept apart from shared's. Shared
+// creates core.user_commands, but only these migrations change it since.
+env "local" {
+  url = getenv("DB_DSN")
+
+  dev = getenv("DB_DSN_DEV")
+
+  migration {
+    dir              = "file://internal/db/migrations"
+    revisions_schema = "core"
+  }
+}
+
+env "staging" {
+  url = getenv("DB_DSN_STAGING")
+
+  dev = getenv("DB_DSN_DEV")
+
+  migration {
+    dir              = "file://internal/db/migrations"
+    revisions_schema = "core"
+  }
+}
@@ -8,10 +8,8 @@ import (
 
 	"github.com/arnokay/arnobot-shared/applog"
 	mbControllers "github.com/arnokay/arnobot-shared/controllers/mb"
-	"github.com/arnokay/arnobot-shared/db"
 	"github.com/arnokay/arnobot-shared/pkg/assert"
 	sharedService "github.com/arnokay/arnobot-shared/service"
-	"github.com/arnokay/arnobot-shared/storage"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
@@ -19,7 +17,9 @@ import (
 	"github.com/arnokay/arnobot-core/internal/app/config"
 	"github.com/arnokay/arnobot-core/internal/app/service"
 	"github.com/arnokay/arnobot-core/internal/commands"
+	"github.com/arnokay/arnobot-core/internal/db"
 	"github.com/arnokay/arnobot-core/internal/mb/controller"
+	"github.com/arnokay/arnobot-core/internal/storage"
 )
 
 const APP_NAME = "core"
@@ -69,17 +69,121 @@ func main() {
 	services := &service.Services{}
 	services.PlatformModuleService = sharedService.NewPlatformModuleIn(app.pubSub)
 	services.CmdManagerService = service.NewCmdManagerService(app.cache)
-	services.UserCommandService = service.NewUserCommandService(app.cache, app.storage, services.CmdManagerService)
+	services.TransactionService = sharedService.NewPgxTransactionService(app.db)
+	services.UserCommandService = service.NewUserCommandService(
+		app.cache,
+		app.storage,
+		services.TransactionService,
+		services.CmdManagerService,
+	)
+	services.UserCommandTransferService = service.NewUserCommandTransferService(
+		services.UserCommandService,
+		services.CmdManagerService,
+	)
+	services.AuthorizationService = service.NewAuthorizationService(
+		sharedService.NewAuthModule(app.pubSub),
+	)
 	services.UserCmdManagerService = service.NewUserCmdManagerService(
 		app.cache,
 		services.CmdManagerService,
 		services.UserCommandService,
 	)
+	services.ChannelService = service.NewChannelService(
+		app.cache,
+		services.PlatformModuleService,
+	)
+	services.TimerService = service.NewTimerService(
+		services.ChannelService,
+		app.storage,
+		services.TransactionService,
+		services.PlatformModuleService,
+	)
+	services.TriggerService = service.NewTriggerService(
+		app.cache,
+		app.storage,
+		services.TransactionService,
+	)
+	services.WhisperService = service.NewWhisperService(
+		app.pubSub,
+		app.storage,
+		services.CmdManagerService,
+	)
+	services.ModerationService = service.NewModerationService(
+		app.pubSub,
+		app.cache,
+		app.storage,
+		services.PlatformModuleService,
+	)
+	services.BannedPhraseService = service.NewBannedPhraseService(
+		app.storage,
+		services.ModerationService,
+	)
+	services.PointsService = service.NewPointsService(
+		app.cache,
+		app.storage,
+		services.TransactionService,
+	)
+	services.FairService = service.NewFairService(
+		app.storage,
+		services.TransactionService,
+	)
+	services.SlotService = service.NewSlotService(
+		app.storage,
+		services.TransactionService,
+		services.PointsService,
+		services.FairService,
+	)
+	services.GiveawayService = service.NewGiveawayService(
+		app.cache,
+		app.storage,
+		services.TransactionService,
+		services.PointsService,
+	)
+	services.PollService = service.NewPollService(
+		app.cache,
+		app.storage,
+		services.TransactionService,
+		services.ChannelService,
+	)
+	services.QuoteService = service.NewQuoteService(
+		services.ChannelService,
+		app.storage,
+	)
+	services.InteractionService = service.NewInteractionService(
+		app.cache,
+	)
+	services.DuelService = service.NewDuelService(
+		services.TransactionService,
+		services.InteractionService,
+		services.PointsService,
+		services.FairService,
+	)
+	services.TriviaService = service.NewTriviaService(
+		app.cache,
+		app.storage,
+		services.TransactionService,
+		services.PointsService,
+		services.ChannelService,
+	)
 
 	// load services
 	services.MessageService = service.NewMessageService(
-		services.CmdManagerService,
-		services.UserCmdManagerService,
+		[]service.MessageFilter{
+			services.ModerationService,
+			services.BannedPhraseService,
+		},
+		[]service.MessageObserver{
+			services.ChannelService,
+			services.PointsService,
+			services.GiveawayService,
+			services.PollService,
+			services.TriviaService,
+		},
+		[]service.MessageResolver{
+			services.CmdManagerService,
+			services.UserCmdManagerService,
+			services.TriggerService,
+		},
 		services.PlatformModuleService,
 	)
 	app.services = services
@@ -89,18 +193,110 @@ func main() {
 	app.services.CmdManagerService.Add(ctx, cmdPing)
 	eightBall := commands.NewEightBall()
 	app.services.CmdManagerService.Add(ctx, eightBall)
-	dice := commands.NewDiceCommand()
+	dice := commands.NewDiceCommand(app.services.FairService)
 	app.services.CmdManagerService.Add(ctx, dice)
-	coin := commands.NewCoinCommand()
+	coin := commands.NewCoinCommand(app.services.FairService)
 	app.services.CmdManagerService.Add(ctx, coin)
-	gamba := commands.NewGambaCommand()
+	gamba := commands.NewGambaCommand(app.services.SlotService, app.services.PointsService)
 	app.services.CmdManagerService.Add(ctx, gamba)
 	cmd := commands.NewCmdCommand(app.services.UserCommandService)
 	app.services.CmdManagerService.Add(ctx, cmd)
+	timer := commands.NewTimerCommand(app.services.TimerService)
+	app.services.CmdManagerService.Add(ctx, timer)
+	trigger := commands.NewTriggerCommand(app.services.TriggerService)
+	app.services.CmdManagerService.Add(ctx, trigger)
+	help := commands.NewHelpCommand(app.services.CmdManagerService)
+	app.services.CmdManagerService.Add(ctx, help)
+	filter := commands.NewFilterCommand(app.services.ModerationService)
+	app.services.CmdManagerService.Add(ctx, filter)
+	banword := commands.NewBanwordCommand(app.services.BannedPhraseService)
+	app.services.CmdManagerService.Add(ctx, banword)
+	permit := commands.NewPermitCommand(app.services.ModerationService)
+	app.services.CmdManagerService.Add(ctx, permit)
+	strikes := commands.NewStrikesCommand(app.services.ModerationService)
+	app.services.CmdManagerService.Add(ctx, strikes)
+	pardon := commands.NewPardonCommand(app.services.ModerationService)
+	app.services.CmdManagerService.Add(ctx, pardon)
+	points := commands.NewPointsCommand(app.services.PointsService)
+	app.services.CmdManagerService.Add(ctx, points)
+	give := commands.NewGiveCommand(app.services.PointsService)
+	app.services.CmdManagerService.Add(ctx, give)
+	addPoints := commands.NewAddPointsCommand(app.services.PointsService)
+	app.services.CmdManagerService.Add(ctx, addPoints)
+	fair := commands.NewFairCommand(app.services.FairService)
+	app.services.CmdManagerService.Add(ctx, fair)
+	verify := commands.NewVerifyCommand(app.services.FairService)
+	app.services.CmdManagerService.Add(ctx, verify)
+	giveaway := commands.NewGiveawayCommand(app.services.GiveawayService)
+	app.services.CmdManagerService.Add(ctx, giveaway)
+	poll := commands.NewPollCommand(app.services.PollService)
+	app.services.CmdManagerService.Add(ctx, poll)
+	quote := commands.NewQuoteCommand(app.services.QuoteService)
+	app.services.CmdManagerService.Add(ctx, quote)
+	duel := commands.NewDuelCommand(app.services.DuelService)
+	app.services.CmdManagerService.Add(ctx, duel)
+	accept := commands.NewAcceptCommand(app.services.InteractionService, duel)
+	app.services.CmdManagerService.Add(ctx, accept)
+	decline := commands.NewDeclineCommand(app.services.InteractionService, duel)
+	app.services.CmdManagerService.Add(ctx, decline)
+	trivia := commands.NewTriviaCommand(app.services.TriviaService)
+	app.services.CmdManagerService.Add(ctx, trivia)
 
 	// load message broker controllers
 	app.mbControllers = &controller.Controllers{
-		MessageController: controller.NewMessageController(app.services.MessageService),
+		MessageController: controller.NewMessageController(
+			app.services.MessageService,
+			app.services.WhisperService,
+		),
+		UserCommandController: controller.NewUserCommandController(
+			app.services.UserCommandService,
+			app.services.UserCommandTransferService,
+			app.services.AuthorizationService,
+		),
+		ChannelController: controller.NewChannelController(
+			app.services.ChannelService,
+		),
+		TimerController: controller.NewTimerController(
+			app.services.TimerService,
+			app.services.AuthorizationService,
+		),
+		TriggerController: controller.NewTriggerController(
+			app.services.TriggerService,
+			app.services.AuthorizationService,
+		),
+		ModerationController: controller.NewModerationController(
+			app.services.ModerationService,
+			app.services.BannedPhraseService,
+			app.services.AuthorizationService,
+		),
+		PointsController: controller.NewPointsController(
+			app.services.PointsService,
+			app.services.AuthorizationService,
+		),
+		SlotController: controller.NewSlotController(
+			app.services.SlotService,
+			app.services.AuthorizationService,
+		),
+		FairController: controller.NewFairController(
+			app.services.FairService,
+			app.services.AuthorizationService,
+		),
+		GiveawayController: controller.NewGiveawayController(
+			app.services.GiveawayService,
+			app.services.AuthorizationService,
+		),
+		PollController: controller.NewPollController(
+			app.services.PollService,
+			app.services.AuthorizationService,
+		),
+		QuoteController: controller.NewQuoteController(
+			app.services.QuoteService,
+			app.services.AuthorizationService,
+		),
+		TriviaController: controller.NewTriviaController(
+			app.services.TriviaService,
+			app.services.AuthorizationService,
+		),
 	}
 
 	app.Start()
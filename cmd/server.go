@@ -14,6 +14,18 @@ func (app *application) Start() {
 	startError := make(chan error)
 	shutdownError := make(chan error)
 
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	go app.services.UserCommandService.WatchChanges(workerCtx, app.db)
+	go app.services.ChannelService.Run(workerCtx)
+	go app.services.TimerService.Run(workerCtx)
+	go app.services.PollService.Run(workerCtx)
+	go app.services.TriviaService.Run(workerCtx)
+	go app.services.BannedPhraseService.WatchChanges(workerCtx, app.db)
+	go app.services.TriggerService.WatchChanges(workerCtx, app.db)
+	go app.services.QuoteService.WatchChanges(workerCtx, app.db)
+
 	go func() {
 		quit := make(chan os.Signal, 1)
 
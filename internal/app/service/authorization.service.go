@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+// sessionPrefix is the scheme of the Authorization header, the same one the
+// gateways accept over HTTP.
+const sessionPrefix = "Session "
+
+type AuthorizationService struct {
+	authModule *service.AuthModule
+	logger     applog.Logger
+}
+
+func NewAuthorizationService(authModule *service.AuthModule) *AuthorizationService {
+	logger := applog.NewServiceLogger("authorization-service")
+
+	return &AuthorizationService{
+		authModule: authModule,
+		logger:     logger,
+	}
+}
+
+// Authenticate exchanges the session token of an Authorization header with the
+// auth service for the user it belongs to.
+func (s *AuthorizationService) Authenticate(ctx context.Context, header string) (data.Actor, error) {
+	token, ok := strings.CutPrefix(header, sessionPrefix)
+	if !ok || token == "" {
+		s.logger.DebugContext(ctx, "request has no session token")
+		return data.Actor{}, apperror.ErrUnauthorized
+	}
+
+	user, err := s.authModule.AuthSessionGetOwner(ctx, token)
+	if err != nil {
+		s.logger.DebugContext(ctx, "cannot exchange session token", "err", err)
+		return data.Actor{}, apperror.ErrUnauthorized
+	}
+	if user == nil || user.ID == uuid.Nil {
+		s.logger.DebugContext(ctx, "session token has no owner")
+		return data.Actor{}, apperror.ErrUnauthorized
+	}
+
+	return data.Actor{UserID: user.ID}, nil
+}
+
+// Authorize checks that actor may act on resources owned by userID.
+func (s *AuthorizationService) Authorize(ctx context.Context, actor data.Actor, userID uuid.UUID) error {
+	if actor.UserID == uuid.Nil {
+		s.logger.DebugContext(ctx, "request has no actor")
+		return apperror.ErrUnauthorized
+	}
+
+	if actor.UserID != userID {
+		s.logger.WarnContext(
+			ctx,
+			"actor is not allowed to access resource",
+			"actorUserID", actor.UserID,
+			"ownerUserID", userID,
+		)
+		return apperror.ErrForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/arnokay/arnobot-core/internal/banmatch"
+	"github.com/arnokay/arnobot-core/internal/cmdvalidate"
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/expiring"
+	"github.com/arnokay/arnobot-core/internal/storage"
+)
+
+const (
+	// bannedPhrasesTTL is the safety net for changes that were never
+	// notified, such as the ones made while no replica was listening.
+	bannedPhrasesTTL = 10 * time.Minute
+
+	bannedPhrasesChangedChannel = "core_banned_phrases_changed"
+	bannedPhrasesWatchBackoff   = 5 * time.Second
+
+	maxBannedPhrasesPerChannel = 200
+	maxBannedPhraseLength      = 100
+	bannedPhraseDefaultTimeout = 60
+)
+
+type BannedPhraseService struct {
+	store             storage.Storager
+	moderationService *ModerationService
+
+	matchers *expiring.Cache[uuid.UUID, bannedPhraseMatcher]
+
+	logger applog.Logger
+}
+
+func NewBannedPhraseService(
+	store storage.Storager,
+	moderationService *ModerationService,
+) *BannedPhraseService {
+	logger := applog.NewServiceLogger("banned-phrase-service")
+
+	return &BannedPhraseService{
+		store:             store,
+		moderationService: moderationService,
+		matchers:          expiring.New[uuid.UUID, bannedPhraseMatcher](bannedPhrasesTTL),
+
+		logger: logger,
+	}
+}
+
+type bannedPhraseMatcher struct {
+	phrases []data.BannedPhrase
+	matcher *banmatch.Matcher
+}
+
+func (s *BannedPhraseService) List(ctx context.Context, arg data.BannedPhraseList) ([]data.BannedPhrase, error) {
+	fromDBs, err := s.store.Query(ctx).CoreBannedPhraseGetByUserID(ctx, arg.UserID)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	phrases := make([]data.BannedPhrase, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		phrases = append(phrases, data.NewBannedPhraseFromDB(fromDB))
+	}
+
+	return phrases, nil
+}
+
+func (s *BannedPhraseService) Create(ctx context.Context, arg data.BannedPhraseCreate) (data.BannedPhrase, error) {
+	arg.Phrase = strings.TrimSpace(arg.Phrase)
+	pattern := banmatch.NormalizePattern(arg.Phrase)
+
+	errs := data.FieldErrors{}
+	errs.Add("phrase", checkBannedPhrase(arg.Phrase, pattern))
+
+	action := data.ModerationActionDelete
+	if arg.Action != nil {
+		action = *arg.Action
+		if action != data.ModerationActionDelete && action != data.ModerationActionTimeout {
+			errs.Add("action", "must be delete or timeout")
+		}
+	}
+	timeout := int32(bannedPhraseDefaultTimeout)
+	if arg.Timeout != nil {
+		timeout = *arg.Timeout
+		if timeout <= 0 || timeout > maxModerationTimeout {
+			errs.Add("timeout", "must be between 1 second and 2 weeks")
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.BannedPhrase{}, err
+	}
+
+	count, err := s.store.Query(ctx).CoreBannedPhraseCount(ctx, arg.UserID)
+	if err != nil {
+		return data.BannedPhrase{}, s.store.HandleErr(ctx, err)
+	}
+	if count >= maxBannedPhrasesPerChannel {
+		return data.BannedPhrase{}, apperror.New(apperror.CodeInvalidInput, "a channel can have at most "+strconv.Itoa(maxBannedPhrasesPerChannel)+" banned phrases", nil)
+	}
+
+	fromDB, err := s.store.Query(ctx).CoreBannedPhraseCreate(ctx, db.CoreBannedPhraseCreateParams{
+		UserID:  arg.UserID,
+		Phrase:  arg.Phrase,
+		Pattern: pattern,
+		Action:  action.String(),
+		Timeout: timeout,
+	})
+	if err != nil {
+		return data.BannedPhrase{}, s.store.HandleErr(ctx, err)
+	}
+	s.rebuild(ctx, arg.UserID)
+
+	return data.NewBannedPhraseFromDB(fromDB), nil
+}
+
+// Delete removes the banned phrase that is the same as the given one once
+// normalized, so it does not have to be typed exactly as it was added.
+func (s *BannedPhraseService) Delete(ctx context.Context, arg data.BannedPhraseDelete) (data.BannedPhrase, error) {
+	fromDB, err := s.store.Query(ctx).CoreBannedPhraseDelete(ctx, db.CoreBannedPhraseDeleteParams{
+		UserID:  arg.UserID,
+		Pattern: banmatch.NormalizePattern(strings.TrimSpace(arg.Phrase)),
+	})
+	if err != nil {
+		return data.BannedPhrase{}, s.store.HandleErr(ctx, err)
+	}
+	s.rebuild(ctx, arg.UserID)
+
+	return data.NewBannedPhraseFromDB(fromDB), nil
+}
+
+func checkBannedPhrase(phrase string, pattern string) string {
+	switch {
+	case phrase == "":
+		return "is required"
+	case utf8.RuneCountInString(phrase) > maxBannedPhraseLength || utf8.RuneCountInString(pattern) > maxBannedPhraseLength:
+		return "must be at most " + strconv.Itoa(maxBannedPhraseLength) + " characters long"
+	case banmatch.Literal(pattern) == "":
+		return "must contain letters or digits"
+	}
+	return ""
+}
+
+// Filter acts on messages containing one of the banned phrases of the
+// channel. Moderators and the broadcaster are never filtered.
+func (s *BannedPhraseService) Filter(ctx context.Context, message ChatMessage) bool {
+	event := message.Event
+
+	if message.Private || event.ChatterID == event.BotID || data.UserLevelModerator.Allows(event.ChatterRole) {
+		return false
+	}
+
+	loaded, err := s.load(ctx, event.UserID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot load banned phrases", "err", err, "userID", event.UserID)
+		return false
+	}
+
+	text := event.Message
+	if runes := []rune(text); len(runes) > cmdvalidate.MaxTextLength {
+		text = string(runes[:cmdvalidate.MaxTextLength])
+	}
+
+	i := loaded.matcher.Match(text)
+	if i < 0 {
+		return false
+	}
+
+	phrase := loaded.phrases[i]
+	s.logger.DebugContext(ctx, "message contains a banned phrase", "phraseID", phrase.ID, "chatterID", event.ChatterID)
+	s.moderationService.Act(ctx, event, phrase.Action, phrase.Timeout, "banned phrase")
+
+	return true
+}
+
+// load returns the compiled banned phrases of the channel, building them
+// when they are not cached or older than bannedPhrasesTTL.
+func (s *BannedPhraseService) load(ctx context.Context, userID uuid.UUID) (bannedPhraseMatcher, error) {
+	if cached, ok := s.matchers.Get(userID); ok {
+		return cached, nil
+	}
+
+	return s.build(ctx, userID)
+}
+
+func (s *BannedPhraseService) build(ctx context.Context, userID uuid.UUID) (bannedPhraseMatcher, error) {
+	phrases, err := s.List(ctx, data.BannedPhraseList{UserID: userID})
+	if err != nil {
+		return bannedPhraseMatcher{}, err
+	}
+
+	patterns := make([]string, 0, len(phrases))
+	for _, phrase := range phrases {
+		patterns = append(patterns, phrase.Pattern)
+	}
+
+	built := bannedPhraseMatcher{
+		phrases: phrases,
+		matcher: banmatch.Compile(patterns),
+	}
+
+	s.matchers.Set(userID, built)
+
+	return built, nil
+}
+
+// rebuild compiles the banned phrases of a channel again after they changed,
+// if the channel was in use. Channels that were not are built on their next
+// message.
+func (s *BannedPhraseService) rebuild(ctx context.Context, userID uuid.UUID) {
+	if _, ok := s.matchers.Get(userID); !ok {
+		return
+	}
+
+	_, err := s.build(ctx, userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot rebuild banned phrases", "err", err, "userID", userID)
+		s.matchers.Delete(userID)
+	}
+}
+
+// WatchChanges rebuilds the banned phrases of a channel as soon as they are
+// changed by any replica, or directly in the database, by listening to the
+// notifications of the banned phrases trigger. It blocks until ctx is done.
+func (s *BannedPhraseService) WatchChanges(ctx context.Context, pool *pgxpool.Pool) {
+	for {
+		err := s.listenChanges(ctx, pool)
+		if ctx.Err() != nil {
+			return
+		}
+		s.logger.ErrorContext(ctx, "stopped listening to banned phrase changes, retrying", "err", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(bannedPhrasesWatchBackoff):
+		}
+	}
+}
+
+func (s *BannedPhraseService) listenChanges(ctx context.Context, pool *pgxpool.Pool) error {
+	pooled, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// a listening connection must not go back to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+bannedPhrasesChangedChannel)
+	if err != nil {
+		return err
+	}
+
+	// changes made while nobody was listening are unknown, start over
+	s.matchers.Clear()
+	s.logger.DebugContext(ctx, "listening to banned phrase changes")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		userID, err := uuid.Parse(notification.Payload)
+		if err != nil {
+			s.logger.WarnContext(ctx, "cannot decode banned phrase change", "err", err, "payload", notification.Payload)
+			continue
+		}
+
+		s.rebuild(ctx, userID)
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/events"
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+// channelFlushInterval is how often the chat lines counted by a replica are
+// added to the cache, which is as late as timers may see them.
+const channelFlushInterval = 5 * time.Second
+
+// ChannelService keeps what core learns about a channel from its chat and
+// from its platform module: where to post, how many lines were sent, and the
+// state of the stream. It lives in the cache, so it is shared by replicas and
+// starts over when the cache is emptied.
+type ChannelService struct {
+	cache                 jetstream.KeyValue
+	platformModuleService *service.PlatformModuleIn
+
+	// pending holds the chat lines counted since the last flush, by cache key
+	mu      sync.Mutex
+	pending map[string]pendingChannel
+
+	logger applog.Logger
+}
+
+func NewChannelService(
+	cache jetstream.KeyValue,
+	platformModuleService *service.PlatformModuleIn,
+) *ChannelService {
+	logger := applog.NewServiceLogger("channel-service")
+
+	return &ChannelService{
+		cache:                 cache,
+		platformModuleService: platformModuleService,
+		pending:               map[string]pendingChannel{},
+
+		logger: logger,
+	}
+}
+
+// chatChannel is where to post in a channel, and how many lines were sent
+// there so far.
+type chatChannel struct {
+	Target events.EventCommon `json:"target"`
+	Lines  int64              `json:"lines"`
+}
+
+// pendingChannel is a chatChannel whose lines are yet to be added to the one
+// in the cache.
+type pendingChannel struct {
+	userID   uuid.UUID
+	platform platform.Platform
+	channel  chatChannel
+}
+
+func getChannelKVKey(userID uuid.UUID, platform platform.Platform) string {
+	return "chan." + userID.String() + "." + platform.String()
+}
+
+func getStreamStatusKVKey(platform platform.Platform, broadcasterID string) string {
+	return "stream." + platform.String() + "." + broadcasterID
+}
+
+func getStreamCategoryKVKey(platform platform.Platform, broadcasterID string) string {
+	return "stream.cat." + platform.String() + "." + broadcasterID
+}
+
+// Observe counts the chat lines of the channel and remembers where to post.
+// The count is kept in memory until Run adds it to the cache.
+func (s *ChannelService) Observe(ctx context.Context, message ChatMessage) {
+	event := message.Event
+	key := getChannelKVKey(event.UserID, event.Platform)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending := s.pending[key]
+	pending.userID = event.UserID
+	pending.platform = event.Platform
+	pending.channel.Target = event.EventCommon
+	pending.channel.Lines++
+	s.pending[key] = pending
+}
+
+// Run adds the chat lines counted by Observe to the cache every
+// channelFlushInterval until ctx is done, and once more then.
+func (s *ChannelService) Run(ctx context.Context) {
+	ticker := time.NewTicker(channelFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), channelFlushInterval)
+			s.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			s.flush(ctx)
+		}
+	}
+}
+
+func (s *ChannelService) flush(ctx context.Context) {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = map[string]pendingChannel{}
+	s.mu.Unlock()
+
+	for key, channel := range pending {
+		if !s.addLines(ctx, key, channel.channel) {
+			s.logger.DebugContext(ctx, "cannot count chat lines", "userID", channel.userID, "platform", channel.platform, "lines", channel.channel.Lines)
+		}
+	}
+}
+
+// addLines adds the lines of channel to the ones in the cache under key,
+// replacing where to post.
+func (s *ChannelService) addLines(ctx context.Context, key string, channel chatChannel) bool {
+	lines := channel.Lines
+
+	for range 3 {
+		entry, err := s.cache.Get(ctx, key)
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			channel.Lines = lines
+			b, _ := json.Marshal(channel)
+			_, err = s.cache.Create(ctx, key, b)
+			if err == nil {
+				return true
+			}
+			continue
+		}
+		if err != nil {
+			return false
+		}
+
+		var previous chatChannel
+		_ = json.Unmarshal(entry.Value(), &previous)
+		channel.Lines = previous.Lines + lines
+
+		b, _ := json.Marshal(channel)
+		_, err = s.cache.Update(ctx, key, b, entry.Revision())
+		if err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// get returns false while the channel had no chat since the cache was
+// emptied, as there is nowhere to post yet.
+func (s *ChannelService) get(ctx context.Context, userID uuid.UUID, platform platform.Platform) (chatChannel, bool, error) {
+	entry, err := s.cache.Get(ctx, getChannelKVKey(userID, platform))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return chatChannel{}, false, nil
+		}
+		return chatChannel{}, false, err
+	}
+
+	var channel chatChannel
+	err = json.Unmarshal(entry.Value(), &channel)
+	if err != nil {
+		return chatChannel{}, false, err
+	}
+
+	return channel, true, nil
+}
+
+// Send posts in the chat of the channel. It returns false while the channel
+// had no chat since the cache was emptied, as there is nowhere to post yet.
+func (s *ChannelService) Send(ctx context.Context, userID uuid.UUID, platform platform.Platform, message string) (bool, error) {
+	channel, ok, err := s.get(ctx, userID, platform)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	err = s.platformModuleService.ChatSendMessage(ctx, events.MessageSend{
+		EventCommon: channel.Target,
+		Message:     message,
+	})
+	return err == nil, err
+}
+
+func (s *ChannelService) SetStreamStatus(ctx context.Context, arg data.StreamStatus) error {
+	value := "0"
+	if arg.Online {
+		value = "1"
+	}
+
+	_, err := s.cache.PutString(ctx, getStreamStatusKVKey(arg.Platform, arg.BroadcasterID), value)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot cache stream status", "err", err, "status", arg)
+		return apperror.ErrExternal
+	}
+
+	// kept from the last status that had one, as not every module knows it
+	if arg.Category != "" {
+		_, err = s.cache.PutString(ctx, getStreamCategoryKVKey(arg.Platform, arg.BroadcasterID), arg.Category)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "cannot cache stream category", "err", err, "status", arg)
+			return apperror.ErrExternal
+		}
+	}
+
+	return nil
+}
+
+// IsOnline reports whether the stream was last reported online by its
+// platform module.
+func (s *ChannelService) IsOnline(ctx context.Context, platform platform.Platform, broadcasterID string) bool {
+	entry, err := s.cache.Get(ctx, getStreamStatusKVKey(platform, broadcasterID))
+	if err != nil {
+		if !errors.Is(err, jetstream.ErrKeyNotFound) {
+			s.logger.ErrorContext(ctx, "cannot get stream status from cache", "err", err, "broadcasterID", broadcasterID)
+		}
+		return false
+	}
+
+	return string(entry.Value()) == "1"
+}
+
+// StreamCategory returns the category the stream was last reported in by its
+// platform module, or nothing when it never was.
+func (s *ChannelService) StreamCategory(ctx context.Context, platform platform.Platform, broadcasterID string) string {
+	entry, err := s.cache.Get(ctx, getStreamCategoryKVKey(platform, broadcasterID))
+	if err != nil {
+		if !errors.Is(err, jetstream.ErrKeyNotFound) {
+			s.logger.ErrorContext(ctx, "cannot get stream category from cache", "err", err, "broadcasterID", broadcasterID)
+		}
+		return ""
+	}
+
+	return string(entry.Value())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
 
 	"strings"
 
@@ -48,28 +49,76 @@ func (m *CmdManagerService) IsCommand(cmdName string) bool {
 	return ok
 }
 
-func (m *CmdManagerService) IsCommandEvent(event events.Message) bool {
-	hasPrefix := strings.HasPrefix(event.Message, cmdtypes.CommandPrefix)
-	if !hasPrefix {
-		return false
+// Resolve matches prefixed messages against the built-in commands.
+func (m *CmdManagerService) Resolve(ctx context.Context, message ChatMessage) (ResolvedCommand, error) {
+	if message.Command.Prefix != cmdtypes.CommandPrefix {
+		return nil, nil
 	}
-	cmd := m.parseCommand(event.Message)
-	_, ok := m.commandNames[cmd.Command]
-	return ok
+
+	cmd, ok := m.commands[message.Command.Command]
+	if !ok {
+		return nil, nil
+	}
+
+	return resolvedBuiltinCommand{manager: m, cmd: cmd, message: message}, nil
 }
 
-func (m *CmdManagerService) parseCommand(message string) cmdtypes.ParsedCommand {
-	cmd, rest, _ := strings.Cut(message, " ")
-	name := strings.TrimPrefix(cmd, cmdtypes.CommandPrefix)
-	return cmdtypes.ParsedCommand{
-		Prefix:  cmdtypes.CommandPrefix,
-		Command: name,
-		Args:    rest,
+// ResolvePrivate is Resolve for whispers, which only run the commands that
+// allow it.
+func (m *CmdManagerService) ResolvePrivate(ctx context.Context, message ChatMessage) (ResolvedCommand, error) {
+	if message.Command.Prefix != cmdtypes.CommandPrefix {
+		return nil, nil
 	}
+
+	cmd, ok := m.commands[message.Command.Command].(cmdtypes.PrivateCommand)
+	if !ok || !cmd.AllowsPrivate(message.Command) {
+		return nil, nil
+	}
+
+	message.Private = true
+	return resolvedBuiltinCommand{manager: m, cmd: cmd, message: message}, nil
+}
+
+// Get returns the built-in command called name, or one of its aliases.
+func (m *CmdManagerService) Get(name string) (cmdtypes.Command, bool) {
+	cmd, ok := m.commands[name]
+	return cmd, ok
 }
 
-func (m *CmdManagerService) setBroadcasterCommandCooldown(ctx context.Context, platform platform.Platform, broadcasterID string, cmd cmdtypes.Command) error {
-	_, err := m.cache.Create(ctx, m.getCacheKey(platform, broadcasterID, cmd), []byte{}, jetstream.KeyTTL(cmd.Cooldown()))
+// Commands returns every built-in command once, sorted by name.
+func (m *CmdManagerService) Commands() []cmdtypes.Command {
+	commands := make([]cmdtypes.Command, 0, len(m.commands))
+	for name, cmd := range m.commands {
+		if name == cmd.Name() {
+			commands = append(commands, cmd)
+		}
+	}
+	slices.SortFunc(commands, func(a, b cmdtypes.Command) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return commands
+}
+
+type resolvedBuiltinCommand struct {
+	manager *CmdManagerService
+	cmd     cmdtypes.Command
+	message ChatMessage
+}
+
+func (r resolvedBuiltinCommand) Execute(ctx context.Context) (*events.MessageSend, error) {
+	return r.manager.execute(ctx, r.message, r.cmd)
+}
+
+// setBroadcasterCommandCooldown starts the channel-wide cooldown of cmd, or
+// the one of whisperer alone when it was whispered. Commands with no
+// cooldown, like those that only act for the chatter who typed them, are
+// skipped.
+func (m *CmdManagerService) setBroadcasterCommandCooldown(ctx context.Context, platform platform.Platform, broadcasterID string, whisperer string, cmd cmdtypes.Command) error {
+	if cmd.Cooldown() <= 0 {
+		return nil
+	}
+
+	_, err := m.cache.Create(ctx, m.getCacheKey(platform, broadcasterID, whisperer, cmd), []byte{}, jetstream.KeyTTL(cmd.Cooldown()))
 	if err != nil {
 		if errors.Is(err, jetstream.ErrKeyExists) {
 			m.logger.DebugContext(
@@ -95,13 +144,23 @@ func (m *CmdManagerService) setBroadcasterCommandCooldown(ctx context.Context, p
 	return nil
 }
 
-func (m *CmdManagerService) getCacheKey(platform platform.Platform, broadcasterID string, cmd cmdtypes.Command) string {
+// getCacheKey is the cooldown key of cmd in the channel. Whispers get one per
+// whisperer, as their replies only reach them and must not hold the command
+// back in chat.
+func (m *CmdManagerService) getCacheKey(platform platform.Platform, broadcasterID string, whisperer string, cmd cmdtypes.Command) string {
+	if whisperer != "" {
+		return "cmdm." + platform.String() + "." + broadcasterID + ".whisper." + whisperer + "." + cmd.Name()
+	}
 	key := "cmdm." + platform.String() + "." + broadcasterID + "." + cmd.Name()
 	return key
 }
 
-func (m *CmdManagerService) isBroadcasterCommandInCooldown(ctx context.Context, platform platform.Platform, broadcasterID string, cmd cmdtypes.Command) bool {
-	_, err := m.cache.Get(ctx, m.getCacheKey(platform, broadcasterID, cmd))
+func (m *CmdManagerService) isBroadcasterCommandInCooldown(ctx context.Context, platform platform.Platform, broadcasterID string, whisperer string, cmd cmdtypes.Command) bool {
+	if cmd.Cooldown() <= 0 {
+		return false
+	}
+
+	_, err := m.cache.Get(ctx, m.getCacheKey(platform, broadcasterID, whisperer, cmd))
 	if err != nil {
 		if !errors.Is(err, jetstream.ErrNoKeysFound) {
 			m.logger.ErrorContext(
@@ -118,8 +177,8 @@ func (m *CmdManagerService) isBroadcasterCommandInCooldown(ctx context.Context,
 	return true
 }
 
-func (m *CmdManagerService) Execute(ctx context.Context, event events.Message) (*events.MessageSend, error) {
-	parsedMessage := m.parseCommand(event.Message)
+func (m *CmdManagerService) execute(ctx context.Context, message ChatMessage, cmd cmdtypes.Command) (*events.MessageSend, error) {
+	event := message.Event
 
 	cmdCtx := cmdtypes.CommandContext{
 		Context: ctx,
@@ -146,17 +205,16 @@ func (m *CmdManagerService) Execute(ctx context.Context, event events.Message) (
 			Message: event.Message,
 			ReplyTo: event.ReplyTo,
 		},
-		Command: parsedMessage,
+		Command: message.Command,
+		Private: message.Private,
 	}
 
-	cmd, ok := m.commands[parsedMessage.Command]
-
-	if !ok {
-		m.logger.ErrorContext(ctx, "there is no command to execute", "command", parsedMessage.Command)
-		return nil, apperror.ErrInternal
+	var whisperer string
+	if message.Private {
+		whisperer = event.ChatterID
 	}
 
-	if m.isBroadcasterCommandInCooldown(ctx, event.Platform, event.BroadcasterID, cmd) {
+	if m.isBroadcasterCommandInCooldown(ctx, event.Platform, event.BroadcasterID, whisperer, cmd) {
 		m.logger.DebugContext(
 			ctx,
 			"command in cooldown",
@@ -167,7 +225,7 @@ func (m *CmdManagerService) Execute(ctx context.Context, event events.Message) (
 		return nil, apperror.ErrForbidden
 	}
 
-	err := m.setBroadcasterCommandCooldown(ctx, event.Platform, event.BroadcasterID, cmd)
+	err := m.setBroadcasterCommandCooldown(ctx, event.Platform, event.BroadcasterID, whisperer, cmd)
 	if err != nil {
 		m.logger.DebugContext(
 			ctx,
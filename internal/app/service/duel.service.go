@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/service"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	// duelTTL is how long a challenge waits for !accept, in seconds.
+	duelTTL = 60
+
+	maxDuelStake = 1_000_000
+)
+
+var errNotADuel = apperror.New(apperror.CodeInvalidInput, "that is not a duel", nil)
+
+type DuelService struct {
+	tx                 service.ITransactionService
+	interactionService *InteractionService
+	pointsService      *PointsService
+	fairService        *FairService
+
+	logger applog.Logger
+}
+
+func NewDuelService(
+	tx service.ITransactionService,
+	interactionService *InteractionService,
+	pointsService *PointsService,
+	fairService *FairService,
+) *DuelService {
+	logger := applog.NewServiceLogger("duel-service")
+
+	return &DuelService{
+		tx:                 tx,
+		interactionService: interactionService,
+		pointsService:      pointsService,
+		fairService:        fairService,
+
+		logger: logger,
+	}
+}
+
+// Challenge asks ToLogin to a duel. The stake is only checked against the
+// balance of the challenger here, and taken when the duel is fought.
+func (s *DuelService) Challenge(ctx context.Context, arg data.DuelChallenge) (data.Interaction, error) {
+	errs := data.FieldErrors{}
+	if arg.Stake < 0 || arg.Stake > maxDuelStake {
+		errs.Add("stake", "must be between 0 and "+strconv.Itoa(maxDuelStake))
+	}
+	if arg.Stake > 0 && !s.pointsService.Enabled(ctx, arg.From.UserID) {
+		errs.Add("stake", "needs points to be enabled")
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.Interaction{}, err
+	}
+
+	if arg.Stake > 0 {
+		account, err := s.pointsService.Balance(ctx, arg.From)
+		if err != nil {
+			return data.Interaction{}, err
+		}
+		if account.Balance < arg.Stake {
+			return data.Interaction{}, errNotEnoughPoints
+		}
+	}
+
+	payload, _ := json.Marshal(data.Duel{Stake: arg.Stake})
+
+	return s.interactionService.Offer(ctx, data.InteractionOffer{
+		Kind:      data.InteractionKindDuel,
+		UserID:    arg.From.UserID,
+		Platform:  arg.From.Platform,
+		FromID:    arg.From.ChatterID,
+		FromLogin: arg.From.ChatterLogin,
+		ToLogin:   arg.ToLogin,
+		Payload:   payload,
+		TTL:       duelTTL,
+	})
+}
+
+// Fight rolls the winner of an accepted duel, who takes the stake of the
+// loser. The roll and the points are recorded together, and the duel fails
+// as a whole when either chatter cannot cover the stake.
+func (s *DuelService) Fight(ctx context.Context, arg data.DuelFight) (data.DuelResult, error) {
+	interaction := arg.Interaction
+	if interaction.Kind != data.InteractionKindDuel {
+		return data.DuelResult{}, errNotADuel
+	}
+
+	var duel data.Duel
+	err := json.Unmarshal(interaction.Payload, &duel)
+	if err != nil {
+		return data.DuelResult{}, errNotADuel
+	}
+
+	challenger := data.PointsChatter{
+		UserID:       interaction.UserID,
+		Platform:     interaction.Platform,
+		ChatterID:    interaction.FromID,
+		ChatterLogin: interaction.FromLogin,
+	}
+	accepter := arg.To
+	accepter.ChatterLogin = normalizeLogin(accepter.ChatterLogin)
+
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return data.DuelResult{}, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	winner, loser := challenger, accepter
+	roll, err := s.fairService.Roll(txCtx, data.FairRollCreate{
+		UserID:       interaction.UserID,
+		Game:         data.FairGameDuel,
+		Platform:     interaction.Platform,
+		ChatterID:    accepter.ChatterID,
+		ChatterLogin: accepter.ChatterLogin,
+		ClientSeed:   arg.MessageID,
+	}, func(intN func(n int) int) string {
+		if intN(2) == 1 {
+			winner, loser = accepter, challenger
+		}
+		return winner.ChatterLogin
+	})
+	if err != nil {
+		return data.DuelResult{}, err
+	}
+
+	result := data.DuelResult{
+		UserID:      interaction.UserID,
+		Platform:    interaction.Platform,
+		WinnerLogin: winner.ChatterLogin,
+		LoserLogin:  loser.ChatterLogin,
+		Stake:       duel.Stake,
+		RollID:      roll.ID,
+	}
+
+	if duel.Stake > 0 {
+		key := "duel:" + interaction.ID.String()
+		_, err = s.pointsService.apply(txCtx, loser, -duel.Stake, data.PointsReasonDuel, key+":lose")
+		if err != nil {
+			return data.DuelResult{}, err
+		}
+		account, err := s.pointsService.apply(txCtx, winner, duel.Stake, data.PointsReasonDuel, key+":win")
+		if err != nil {
+			return data.DuelResult{}, err
+		}
+		result.Balance = account.Balance
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return data.DuelResult{}, err
+	}
+
+	return result, nil
+}
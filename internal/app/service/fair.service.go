@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/expiring"
+	"github.com/arnokay/arnobot-core/internal/fair"
+	"github.com/arnokay/arnobot-core/internal/storage"
+)
+
+const (
+	fairSettingsTTL = time.Minute
+
+	fairRollListDefaultLimit = 20
+	fairRollListMaxLimit     = 100
+	maxFairOutcomeLength     = 200
+	maxFairClientSeedLength  = 100
+)
+
+type FairService struct {
+	store storage.Storager
+	tx    service.ITransactionService
+
+	// settings tells whether a channel rolls provably fair
+	settings *expiring.Cache[uuid.UUID, bool]
+
+	logger applog.Logger
+}
+
+func NewFairService(
+	store storage.Storager,
+	tx service.ITransactionService,
+) *FairService {
+	logger := applog.NewServiceLogger("fair-service")
+
+	return &FairService{
+		store:    store,
+		tx:       tx,
+		settings: expiring.New[uuid.UUID, bool](fairSettingsTTL),
+
+		logger: logger,
+	}
+}
+
+// GetSettings returns whether the channel rolls provably fair and, when it
+// does, the hash of the server seed of its next rolls.
+func (s *FairService) GetSettings(ctx context.Context, arg data.FairSettingsGet) (data.FairSettings, error) {
+	settings := data.FairSettings{UserID: arg.UserID}
+
+	fromDB, err := s.store.Query(ctx).CoreFairSettingsGet(ctx, arg.UserID)
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return settings, nil
+		}
+		return data.FairSettings{}, err
+	}
+	settings.Enabled = fromDB.Enabled
+
+	return s.withSeedHash(ctx, settings)
+}
+
+func (s *FairService) UpdateSettings(ctx context.Context, arg data.FairSettingsUpdate) (data.FairSettings, error) {
+	fromDB, err := s.store.Query(ctx).CoreFairSettingsUpsert(ctx, db.CoreFairSettingsUpsertParams{
+		UserID:  arg.UserID,
+		Enabled: arg.Enabled,
+	})
+	if err != nil {
+		return data.FairSettings{}, s.store.HandleErr(ctx, err)
+	}
+	s.forget(arg.UserID)
+
+	return s.withSeedHash(ctx, data.FairSettings{UserID: fromDB.UserID, Enabled: fromDB.Enabled})
+}
+
+func (s *FairService) withSeedHash(ctx context.Context, settings data.FairSettings) (data.FairSettings, error) {
+	if !settings.Enabled {
+		return settings, nil
+	}
+
+	seed, err := s.active(ctx, settings.UserID)
+	if err != nil {
+		return data.FairSettings{}, err
+	}
+	settings.ServerSeedHash = seed.Hash
+
+	return settings, nil
+}
+
+// Roll plays one roll of a game, drawing its numbers from the intN given to
+// play. When the channel rolls provably fair the numbers come from its
+// server seed and the roll is recorded with the outcome play returns;
+// otherwise they come from math/rand and the roll has no ID.
+//
+// Roll joins the transaction of ctx, if any, so the roll is only recorded
+// when what it decided is.
+func (s *FairService) Roll(ctx context.Context, arg data.FairRollCreate, play func(intN func(n int) int) string) (data.FairRoll, error) {
+	roll := data.FairRoll{
+		UserID:       arg.UserID,
+		Game:         arg.Game,
+		Platform:     arg.Platform,
+		ChatterID:    arg.ChatterID,
+		ChatterLogin: arg.ChatterLogin,
+		ClientSeed:   arg.ClientSeed,
+	}
+
+	// a channel whose settings cannot be read plays like one without the
+	// fair mode rather than not at all
+	enabled, err := s.load(ctx, arg.UserID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot load fair settings, rolling without them", "err", err, "userID", arg.UserID)
+	}
+	if err != nil || !enabled {
+		roll.Outcome = play(rand.IntN)
+		return roll, nil
+	}
+
+	errs := data.FieldErrors{}
+	if arg.ClientSeed == "" || len(arg.ClientSeed) > maxFairClientSeedLength {
+		errs.Add("clientSeed", "must be between 1 and 100 bytes long")
+	}
+	if err := errs.Err(); err != nil {
+		return data.FairRoll{}, err
+	}
+
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return data.FairRoll{}, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	seed, err := s.next(txCtx, arg.UserID)
+	if err != nil {
+		return data.FairRoll{}, err
+	}
+
+	outcome := play(fair.NewRoller(seed.Seed, arg.ClientSeed, seed.Nonce).IntN)
+	if runes := []rune(outcome); len(runes) > maxFairOutcomeLength {
+		outcome = string(runes[:maxFairOutcomeLength])
+	}
+
+	fromDB, err := s.store.Query(txCtx).CoreFairRollCreate(txCtx, db.CoreFairRollCreateParams{
+		UserID:         arg.UserID,
+		SeedID:         seed.ID,
+		Game:           arg.Game.String(),
+		Platform:       arg.Platform,
+		ChatterID:      arg.ChatterID,
+		ChatterLogin:   arg.ChatterLogin,
+		ServerSeedHash: seed.Hash,
+		ClientSeed:     arg.ClientSeed,
+		Nonce:          seed.Nonce,
+		Outcome:        outcome,
+	})
+	if err != nil {
+		return data.FairRoll{}, s.store.HandleErr(txCtx, err)
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return data.FairRoll{}, err
+	}
+
+	return data.NewFairRollFromDB(fromDB), nil
+}
+
+// Verify reveals the server seed of a roll. The seed is retired and the
+// channel starts a new one, since every roll it decides is known once it is
+// revealed.
+func (s *FairService) Verify(ctx context.Context, arg data.FairRollGet) (data.FairVerification, error) {
+	fromDB, err := s.store.Query(ctx).CoreFairRollGet(ctx, db.CoreFairRollGetParams{
+		UserID: arg.UserID,
+		ID:     arg.ID,
+	})
+	if err != nil {
+		return data.FairVerification{}, s.store.HandleErr(ctx, err)
+	}
+
+	seed, err := s.store.Query(ctx).CoreFairSeedReveal(ctx, fromDB.SeedID)
+	if err != nil {
+		return data.FairVerification{}, s.store.HandleErr(ctx, err)
+	}
+
+	next, err := s.active(ctx, arg.UserID)
+	if err != nil {
+		return data.FairVerification{}, err
+	}
+
+	return data.FairVerification{
+		FairRoll:           data.NewFairRollFromDB(fromDB),
+		ServerSeed:         seed.Seed,
+		NextServerSeedHash: next.Hash,
+	}, nil
+}
+
+// Rolls lists the recorded rolls of the channel, newest first, for audits.
+func (s *FairService) Rolls(ctx context.Context, arg data.FairRollList) ([]data.FairRoll, error) {
+	limit := arg.Limit
+	if limit <= 0 {
+		limit = fairRollListDefaultLimit
+	}
+	limit = min(limit, fairRollListMaxLimit)
+
+	params := db.CoreFairRollListParams{
+		UserID: arg.UserID,
+		Limit:  limit,
+	}
+	if login := normalizeLogin(arg.ChatterLogin); login != "" {
+		params.ChatterLogin = &login
+	}
+	if arg.BeforeID > 0 {
+		params.BeforeID = &arg.BeforeID
+	}
+
+	fromDBs, err := s.store.Query(ctx).CoreFairRollList(ctx, params)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	rolls := make([]data.FairRoll, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		rolls = append(rolls, data.NewFairRollFromDB(fromDB))
+	}
+
+	return rolls, nil
+}
+
+// next takes the next nonce of the server seed of the channel, starting a
+// seed when it has none.
+func (s *FairService) next(ctx context.Context, userID uuid.UUID) (db.CoreFairSeed, error) {
+	seed, err := s.store.Query(ctx).CoreFairSeedNext(ctx, userID)
+	if err == nil {
+		return seed, nil
+	}
+	err = s.store.HandleErr(ctx, err)
+	if !errors.Is(err, apperror.ErrNotFound) {
+		return db.CoreFairSeed{}, err
+	}
+
+	_, err = s.active(ctx, userID)
+	if err != nil {
+		return db.CoreFairSeed{}, err
+	}
+
+	seed, err = s.store.Query(ctx).CoreFairSeedNext(ctx, userID)
+	if err != nil {
+		return db.CoreFairSeed{}, s.store.HandleErr(ctx, err)
+	}
+	return seed, nil
+}
+
+// active returns the unrevealed server seed of the channel, starting one
+// when it has none.
+func (s *FairService) active(ctx context.Context, userID uuid.UUID) (db.CoreFairSeed, error) {
+	seed, err := s.store.Query(ctx).CoreFairSeedGetActive(ctx, userID)
+	if err == nil {
+		return seed, nil
+	}
+	err = s.store.HandleErr(ctx, err)
+	if !errors.Is(err, apperror.ErrNotFound) {
+		return db.CoreFairSeed{}, err
+	}
+
+	serverSeed := fair.NewServerSeed()
+	seed, err = s.store.Query(ctx).CoreFairSeedCreate(ctx, db.CoreFairSeedCreateParams{
+		UserID: userID,
+		Seed:   serverSeed,
+		Hash:   fair.Hash(serverSeed),
+	})
+	if err == nil {
+		return seed, nil
+	}
+	err = s.store.HandleErr(ctx, err)
+	if !errors.Is(err, apperror.ErrNotFound) {
+		return db.CoreFairSeed{}, err
+	}
+
+	// another replica started one first
+	seed, err = s.store.Query(ctx).CoreFairSeedGetActive(ctx, userID)
+	if err != nil {
+		return db.CoreFairSeed{}, s.store.HandleErr(ctx, err)
+	}
+	return seed, nil
+}
+
+// load reports whether the channel rolls provably fair, reading it from the
+// database at most once per fairSettingsTTL.
+func (s *FairService) load(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if enabled, ok := s.settings.Get(userID); ok {
+		return enabled, nil
+	}
+
+	var enabled bool
+	fromDB, err := s.store.Query(ctx).CoreFairSettingsGet(ctx, userID)
+	if err == nil {
+		enabled = fromDB.Enabled
+	} else if err = s.store.HandleErr(ctx, err); !errors.Is(err, apperror.ErrNotFound) {
+		return false, err
+	}
+
+	s.settings.Set(userID, enabled)
+
+	return enabled, nil
+}
+
+func (s *FairService) forget(userID uuid.UUID) {
+	s.settings.Delete(userID)
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	sharedData "github.com/arnokay/arnobot-shared/data"
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/storage"
+)
+
+const (
+	maxGiveawayKeywordLength = 25
+	maxGiveawayDuration      = 24 * 60 * 60
+	maxGiveawayTicketCost    = 1_000_000
+	maxGiveawayTickets       = 100
+	maxGiveawaySubLuck       = 10
+)
+
+var (
+	errGiveawayNotFound  = apperror.New(apperror.CodeNotFound, "there is no giveaway yet", nil)
+	errGiveawayRunning   = apperror.New(apperror.CodeAlreadyExists, "a giveaway is running already", nil)
+	errGiveawayNoOneLeft = apperror.New(apperror.CodeNotFound, "there is no one left to draw", nil)
+)
+
+type GiveawayService struct {
+	cache         jetstream.KeyValue
+	store         storage.Storager
+	tx            service.ITransactionService
+	pointsService *PointsService
+
+	logger applog.Logger
+}
+
+func NewGiveawayService(
+	cache jetstream.KeyValue,
+	store storage.Storager,
+	tx service.ITransactionService,
+	pointsService *PointsService,
+) *GiveawayService {
+	logger := applog.NewServiceLogger("giveaway-service")
+
+	return &GiveawayService{
+		cache:         cache,
+		store:         store,
+		tx:            tx,
+		pointsService: pointsService,
+
+		logger: logger,
+	}
+}
+
+// getGiveawayKVKey is the key of the open giveaway of a channel, mirrored
+// from the database so chat messages are matched to its keyword without a
+// query each.
+func getGiveawayKVKey(userID uuid.UUID, platform platform.Platform) string {
+	return "gvw." + userID.String() + "." + platform.String()
+}
+
+func (s *GiveawayService) Start(ctx context.Context, arg data.GiveawayStart) (data.Giveaway, error) {
+	arg.Keyword = strings.ToLower(strings.TrimSpace(arg.Keyword))
+	if arg.MinLevel == "" {
+		arg.MinLevel = data.UserLevelEveryone
+	}
+	if arg.MaxTickets == 0 {
+		arg.MaxTickets = 1
+	}
+	if arg.SubLuck == 0 {
+		arg.SubLuck = 1
+	}
+
+	errs := data.FieldErrors{}
+	if !arg.Platform.IsEnum() {
+		errs.Add("platform", "unknown platform")
+	}
+	if arg.Keyword == "" || len(arg.Keyword) > maxGiveawayKeywordLength || strings.ContainsAny(arg.Keyword, " \t\n") {
+		errs.Add("keyword", "must be a single word of at most "+strconv.Itoa(maxGiveawayKeywordLength)+" bytes")
+	}
+	if arg.Duration < 0 || arg.Duration > maxGiveawayDuration {
+		errs.Add("duration", "must be between 0 and "+strconv.Itoa(maxGiveawayDuration)+" seconds")
+	}
+	if !arg.MinLevel.IsEnum() {
+		errs.Add("minLevel", "unknown user level")
+	}
+	if arg.TicketCost < 0 || arg.TicketCost > maxGiveawayTicketCost {
+		errs.Add("ticketCost", "must be between 0 and "+strconv.Itoa(maxGiveawayTicketCost))
+	}
+	if arg.MaxTickets < 1 || arg.MaxTickets > maxGiveawayTickets {
+		errs.Add("maxTickets", "must be between 1 and "+strconv.Itoa(maxGiveawayTickets))
+	}
+	if arg.SubLuck < 1 || arg.SubLuck > maxGiveawaySubLuck {
+		errs.Add("subLuck", "must be between 1 and "+strconv.Itoa(maxGiveawaySubLuck))
+	}
+
+	if arg.TicketCost > 0 && !s.pointsService.Enabled(ctx, arg.UserID) {
+		errs.Add("ticketCost", "needs points to be enabled")
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.Giveaway{}, err
+	}
+
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return data.Giveaway{}, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	latest, err := s.latest(txCtx, arg.UserID, arg.Platform)
+	switch {
+	case errors.Is(err, errGiveawayNotFound):
+	case err != nil:
+		return data.Giveaway{}, err
+	case latest.Open(time.Now()):
+		return data.Giveaway{}, errGiveawayRunning
+	case latest.ClosedAt == nil:
+		// it ended without being closed
+		_, err = s.store.Query(txCtx).CoreGiveawayClose(txCtx, latest.ID)
+		if err != nil {
+			return data.Giveaway{}, s.store.HandleErr(txCtx, err)
+		}
+	}
+
+	params := db.CoreGiveawayCreateParams{
+		UserID:     arg.UserID,
+		Platform:   arg.Platform,
+		Keyword:    arg.Keyword,
+		MinLevel:   arg.MinLevel.String(),
+		TicketCost: arg.TicketCost,
+		MaxTickets: arg.MaxTickets,
+		SubLuck:    arg.SubLuck,
+	}
+	if arg.Duration > 0 {
+		endsAt := time.Now().UTC().Add(time.Second * time.Duration(arg.Duration))
+		params.EndsAt = &endsAt
+	}
+
+	fromDB, err := s.store.Query(txCtx).CoreGiveawayCreate(txCtx, params)
+	if err != nil {
+		err = s.store.HandleErr(txCtx, err)
+		if errors.Is(err, apperror.ErrAlreadyExists) {
+			return data.Giveaway{}, errGiveawayRunning
+		}
+		return data.Giveaway{}, err
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return data.Giveaway{}, err
+	}
+
+	giveaway := data.NewGiveawayFromDB(fromDB)
+	s.mirror(ctx, giveaway)
+
+	s.logger.InfoContext(ctx, "giveaway started",
+		"userID", giveaway.UserID,
+		"giveawayID", giveaway.ID,
+		"keyword", giveaway.Keyword,
+		"startedBy", appctx.GetActor(ctx),
+	)
+
+	return giveaway, nil
+}
+
+// Get returns the last giveaway of the channel, with its entries and
+// winners.
+func (s *GiveawayService) Get(ctx context.Context, arg data.GiveawayGet) (data.Giveaway, error) {
+	giveaway, err := s.latest(ctx, arg.UserID, arg.Platform)
+	if err != nil {
+		return data.Giveaway{}, err
+	}
+	if giveaway.Open(time.Now()) {
+		_, err = s.cache.Get(ctx, getGiveawayKVKey(giveaway.UserID, giveaway.Platform))
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			// the cache lost it
+			s.mirror(ctx, giveaway)
+		}
+	}
+
+	return s.describe(ctx, giveaway)
+}
+
+// Close stops the entries of the last giveaway of the channel.
+func (s *GiveawayService) Close(ctx context.Context, arg data.GiveawayGet) (data.Giveaway, error) {
+	giveaway, err := s.latest(ctx, arg.UserID, arg.Platform)
+	if err != nil {
+		return data.Giveaway{}, err
+	}
+
+	if giveaway.ClosedAt == nil {
+		fromDB, err := s.store.Query(ctx).CoreGiveawayClose(ctx, giveaway.ID)
+		if err != nil {
+			return data.Giveaway{}, s.store.HandleErr(ctx, err)
+		}
+		giveaway = data.NewGiveawayFromDB(fromDB)
+	}
+	s.unmirror(ctx, giveaway)
+
+	return s.describe(ctx, giveaway)
+}
+
+// Draw closes the last giveaway of the channel and draws one of its
+// entries, weighted by tickets. Drawing again draws another winner, for when
+// the first one does not claim the prize.
+func (s *GiveawayService) Draw(ctx context.Context, arg data.GiveawayGet) (data.GiveawayWinner, error) {
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return data.GiveawayWinner{}, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	giveaway, err := s.latest(txCtx, arg.UserID, arg.Platform)
+	if err != nil {
+		return data.GiveawayWinner{}, err
+	}
+	if giveaway.ClosedAt == nil {
+		_, err = s.store.Query(txCtx).CoreGiveawayClose(txCtx, giveaway.ID)
+		if err != nil {
+			return data.GiveawayWinner{}, s.store.HandleErr(txCtx, err)
+		}
+	}
+
+	entries, err := s.store.Query(txCtx).CoreGiveawayEntryGetUndrawn(txCtx, giveaway.ID)
+	if err != nil {
+		return data.GiveawayWinner{}, s.store.HandleErr(txCtx, err)
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += giveawayWeight(giveaway, entry)
+	}
+	if total == 0 {
+		return data.GiveawayWinner{}, errGiveawayNoOneLeft
+	}
+
+	var winner db.CoreGiveawayEntry
+	n := rand.Int64N(total)
+	for _, entry := range entries {
+		n -= giveawayWeight(giveaway, entry)
+		if n < 0 {
+			winner = entry
+			break
+		}
+	}
+
+	fromDB, err := s.store.Query(txCtx).CoreGiveawayEntryMarkDrawn(txCtx, db.CoreGiveawayEntryMarkDrawnParams{
+		GiveawayID: giveaway.ID,
+		ChatterID:  winner.ChatterID,
+	})
+	if err != nil {
+		return data.GiveawayWinner{}, s.store.HandleErr(txCtx, err)
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return data.GiveawayWinner{}, err
+	}
+	s.unmirror(ctx, giveaway)
+
+	s.logger.InfoContext(ctx, "giveaway winner drawn",
+		"userID", giveaway.UserID,
+		"giveawayID", giveaway.ID,
+		"chatterID", fromDB.ChatterID,
+		"chatterLogin", fromDB.ChatterLogin,
+		"drawnBy", appctx.GetActor(ctx),
+	)
+
+	return data.NewGiveawayWinnerFromDB(fromDB), nil
+}
+
+func giveawayWeight(giveaway data.Giveaway, entry db.CoreGiveawayEntry) int64 {
+	weight := int64(entry.Tickets)
+	if entry.Sub {
+		weight *= int64(giveaway.SubLuck)
+	}
+	return weight
+}
+
+// Observe enters the chatter in the open giveaway of the channel when the
+// message starts with its keyword, optionally followed by a number of
+// tickets to buy.
+func (s *GiveawayService) Observe(ctx context.Context, message ChatMessage) {
+	event := message.Event
+
+	if message.Private || event.ChatterID == event.BotID {
+		return
+	}
+
+	entry, err := s.cache.Get(ctx, getGiveawayKVKey(event.UserID, event.Platform))
+	if err != nil {
+		if !errors.Is(err, jetstream.ErrKeyNotFound) {
+			s.logger.ErrorContext(ctx, "cannot get giveaway from cache", "err", err)
+		}
+		return
+	}
+
+	var giveaway data.Giveaway
+	err = json.Unmarshal(entry.Value(), &giveaway)
+	if err != nil {
+		s.logger.WarnContext(ctx, "cannot decode giveaway", "err", err)
+		return
+	}
+
+	fields := strings.Fields(strings.ToLower(event.Message))
+	if len(fields) == 0 || fields[0] != giveaway.Keyword || !giveaway.MinLevel.Allows(event.ChatterRole) {
+		return
+	}
+
+	tickets := int32(1)
+	if giveaway.TicketCost > 0 && len(fields) > 1 {
+		n, err := strconv.ParseInt(fields[1], 10, 32)
+		if err == nil && n > 0 {
+			tickets = int32(min(n, int64(giveaway.MaxTickets)))
+		}
+	}
+
+	err = s.enter(ctx, giveaway, data.PointsChatter{
+		UserID:       event.UserID,
+		Platform:     event.Platform,
+		ChatterID:    event.ChatterID,
+		ChatterLogin: event.ChatterLogin,
+	}, tickets, event.ChatterRole >= sharedData.ChatterSub)
+	if err != nil {
+		s.logger.DebugContext(ctx, "cannot enter giveaway", "err", err, "chatterID", event.ChatterID)
+	}
+}
+
+// enter records the entry and takes the points of its tickets together, so
+// an entry is never paid twice nor free.
+func (s *GiveawayService) enter(ctx context.Context, giveaway data.Giveaway, chatter data.PointsChatter, tickets int32, sub bool) error {
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	_, err = s.store.Query(txCtx).CoreGiveawayEntryCreate(txCtx, db.CoreGiveawayEntryCreateParams{
+		GiveawayID:   giveaway.ID,
+		ChatterID:    chatter.ChatterID,
+		ChatterLogin: normalizeLogin(chatter.ChatterLogin),
+		Tickets:      tickets,
+		Sub:          sub,
+	})
+	if err != nil {
+		// closed, or entered already
+		return s.store.HandleErr(txCtx, err)
+	}
+
+	if giveaway.TicketCost > 0 {
+		key := "giveaway:" + strconv.FormatInt(giveaway.ID, 10) + ":" + chatter.ChatterID
+		_, err = s.pointsService.apply(txCtx, chatter, -int64(tickets)*int64(giveaway.TicketCost), data.PointsReasonGiveaway, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.tx.Commit(txCtx)
+}
+
+func (s *GiveawayService) latest(ctx context.Context, userID uuid.UUID, platform platform.Platform) (data.Giveaway, error) {
+	fromDB, err := s.store.Query(ctx).CoreGiveawayGetLatest(ctx, db.CoreGiveawayGetLatestParams{
+		UserID:   userID,
+		Platform: platform,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.Giveaway{}, errGiveawayNotFound
+		}
+		return data.Giveaway{}, err
+	}
+
+	return data.NewGiveawayFromDB(fromDB), nil
+}
+
+// describe adds the entries and winners to the giveaway.
+func (s *GiveawayService) describe(ctx context.Context, giveaway data.Giveaway) (data.Giveaway, error) {
+	stats, err := s.store.Query(ctx).CoreGiveawayEntryStats(ctx, giveaway.ID)
+	if err != nil {
+		return data.Giveaway{}, s.store.HandleErr(ctx, err)
+	}
+	giveaway.Entries = stats.Entries
+	giveaway.Tickets = stats.Tickets
+
+	winners, err := s.store.Query(ctx).CoreGiveawayGetWinners(ctx, giveaway.ID)
+	if err != nil {
+		return data.Giveaway{}, s.store.HandleErr(ctx, err)
+	}
+	giveaway.Winners = make([]data.GiveawayWinner, 0, len(winners))
+	for _, winner := range winners {
+		giveaway.Winners = append(giveaway.Winners, data.NewGiveawayWinnerFromDB(winner))
+	}
+
+	return giveaway, nil
+}
+
+// mirror caches the open giveaway for Observe, until it ends.
+func (s *GiveawayService) mirror(ctx context.Context, giveaway data.Giveaway) {
+	var ttl time.Duration
+	if giveaway.EndsAt != nil {
+		ttl = time.Until(*giveaway.EndsAt)
+	}
+
+	err := mirrorWithTTL(ctx, s.cache, getGiveawayKVKey(giveaway.UserID, giveaway.Platform), giveaway, ttl)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot cache giveaway", "err", err, "giveawayID", giveaway.ID)
+	}
+}
+
+func (s *GiveawayService) unmirror(ctx context.Context, giveaway data.Giveaway) {
+	err := s.cache.Purge(ctx, getGiveawayKVKey(giveaway.UserID, giveaway.Platform))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot uncache giveaway", "err", err, "giveawayID", giveaway.ID)
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	// interaction TTLs are in seconds
+	minInteractionTTL = 10
+	maxInteractionTTL = 10 * 60
+)
+
+var (
+	errInteractionPending  = apperror.New(apperror.CodeAlreadyExists, "they have something to answer already", nil)
+	errNoInteraction       = apperror.New(apperror.CodeNotFound, "there is nothing to answer", nil)
+	errInteractionSelf     = apperror.New(apperror.CodeInvalidInput, "cannot ask yourself", nil)
+	errInteractionNoTarget = apperror.New(apperror.CodeInvalidInput, "no one to ask", nil)
+)
+
+// InteractionService keeps the interactions chatters ask each other until
+// they are answered in a later message, such as a duel waiting for !accept.
+// They only live in the cache, and expire with it.
+type InteractionService struct {
+	cache jetstream.KeyValue
+
+	logger applog.Logger
+}
+
+func NewInteractionService(
+	cache jetstream.KeyValue,
+) *InteractionService {
+	logger := applog.NewServiceLogger("interaction-service")
+
+	return &InteractionService{
+		cache: cache,
+
+		logger: logger,
+	}
+}
+
+func getInteractionKVKey(userID uuid.UUID, platform platform.Platform, login string) string {
+	return "pending." + userID.String() + "." + platform.String() + "." + login
+}
+
+// Offer asks the interaction, failing when the chatter asked has one to
+// answer already.
+func (s *InteractionService) Offer(ctx context.Context, arg data.InteractionOffer) (data.Interaction, error) {
+	arg.FromLogin = normalizeLogin(arg.FromLogin)
+	arg.ToLogin = normalizeLogin(arg.ToLogin)
+
+	errs := data.FieldErrors{}
+	if !arg.Kind.IsEnum() {
+		errs.Add("kind", "unknown interaction")
+	}
+	if !arg.Platform.IsEnum() {
+		errs.Add("platform", "unknown platform")
+	}
+	if arg.TTL < minInteractionTTL || arg.TTL > maxInteractionTTL {
+		errs.Add("ttl", "must be between "+strconv.Itoa(minInteractionTTL)+" and "+strconv.Itoa(maxInteractionTTL)+" seconds")
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.Interaction{}, err
+	}
+	switch arg.ToLogin {
+	case "":
+		return data.Interaction{}, errInteractionNoTarget
+	case arg.FromLogin:
+		return data.Interaction{}, errInteractionSelf
+	}
+
+	now := time.Now().UTC()
+	ttl := time.Second * time.Duration(arg.TTL)
+	interaction := data.Interaction{
+		ID:        uuid.New(),
+		Kind:      arg.Kind,
+		UserID:    arg.UserID,
+		Platform:  arg.Platform,
+		FromID:    arg.FromID,
+		FromLogin: arg.FromLogin,
+		ToLogin:   arg.ToLogin,
+		Payload:   arg.Payload,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	b, _ := json.Marshal(interaction)
+	_, err := s.cache.Create(ctx, getInteractionKVKey(arg.UserID, arg.Platform, arg.ToLogin), b, jetstream.KeyTTL(ttl))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return data.Interaction{}, errInteractionPending
+		}
+		s.logger.ErrorContext(ctx, "cannot cache interaction", "err", err, "kind", arg.Kind)
+		return data.Interaction{}, apperror.ErrExternal
+	}
+
+	return interaction, nil
+}
+
+// Take removes the pending interaction of the chatter and returns it, to be
+// carried out or dropped. Only one of concurrent takes gets it.
+func (s *InteractionService) Take(ctx context.Context, arg data.InteractionTake) (data.Interaction, error) {
+	key := getInteractionKVKey(arg.UserID, arg.Platform, normalizeLogin(arg.ChatterLogin))
+
+	entry, err := s.cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return data.Interaction{}, errNoInteraction
+		}
+		s.logger.ErrorContext(ctx, "cannot get interaction from cache", "err", err)
+		return data.Interaction{}, apperror.ErrExternal
+	}
+
+	err = s.cache.Delete(ctx, key, jetstream.LastRevision(entry.Revision()))
+	if err != nil {
+		// taken by an answer that came first
+		return data.Interaction{}, errNoInteraction
+	}
+
+	var interaction data.Interaction
+	err = json.Unmarshal(entry.Value(), &interaction)
+	if err != nil {
+		s.logger.WarnContext(ctx, "cannot decode interaction", "err", err)
+		return data.Interaction{}, errNoInteraction
+	}
+	if time.Now().After(interaction.ExpiresAt) {
+		// the cache has not expired it yet
+		return data.Interaction{}, errNoInteraction
+	}
+
+	return interaction, nil
+}
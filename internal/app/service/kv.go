@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// minKeyTTL is the shortest TTL the cache accepts on a key.
+const minKeyTTL = time.Second
+
+// mirrorWithTTL stores value under key, replacing what was there, until ttl
+// passes. A zero ttl keeps it until it is purged. A ttl shorter than a
+// second, or already past, is raised to a second. Mirrors only spare queries:
+// the database stays the judge, so a stale one costs a query at worst.
+func mirrorWithTTL(ctx context.Context, cache jetstream.KeyValue, key string, value any, ttl time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	var opts []jetstream.KVCreateOpt
+	if ttl != 0 {
+		opts = append(opts, jetstream.KeyTTL(max(ttl, minKeyTTL)))
+	}
+
+	// only Create takes a TTL, so what was there is purged to make room
+	_, err = cache.Create(ctx, key, b, opts...)
+	if errors.Is(err, jetstream.ErrKeyExists) {
+		err = cache.Purge(ctx, key)
+		if err == nil {
+			_, err = cache.Create(ctx, key, b, opts...)
+		}
+	}
+	return err
+}
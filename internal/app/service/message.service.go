@@ -3,42 +3,124 @@ package service
 import (
 	"context"
 	"errors"
-	
+	"strings"
 
 	"github.com/arnokay/arnobot-shared/apperror"
 	"github.com/arnokay/arnobot-shared/applog"
 	"github.com/arnokay/arnobot-shared/events"
 	"github.com/arnokay/arnobot-shared/service"
+
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
 )
 
+// ChatMessage is a chat message parsed once and shared by every resolver.
+type ChatMessage struct {
+	Event   events.Message
+	Command cmdtypes.ParsedCommand
+	// Private is set for whispers, whose channel is the one of the bot
+	// that was whispered.
+	Private bool
+}
+
+// Word is the first word of the message as typed, prefix included.
+func (m ChatMessage) Word() string {
+	return m.Command.Prefix + m.Command.Command
+}
+
+func parseChatMessage(event events.Message) ChatMessage {
+	word, rest, _ := strings.Cut(event.Message, " ")
+
+	var prefix string
+	if strings.HasPrefix(word, cmdtypes.CommandPrefix) {
+		prefix = cmdtypes.CommandPrefix
+	}
+
+	return ChatMessage{
+		Event: event,
+		Command: cmdtypes.ParsedCommand{
+			Prefix:  prefix,
+			Command: strings.TrimPrefix(word, prefix),
+			Args:    rest,
+		},
+	}
+}
+
+// ResolvedCommand is a command matched to a message, holding everything it
+// needs to run, so nothing is looked up twice.
+type ResolvedCommand interface {
+	Execute(ctx context.Context) (*events.MessageSend, error)
+}
+
+// MessageResolver matches a chat message to a command of one kind. It returns
+// nil without an error when the message is not for it.
+type MessageResolver interface {
+	Resolve(ctx context.Context, message ChatMessage) (ResolvedCommand, error)
+}
+
+// MessageFilter moderates chat messages before anything else sees them.
+// Filter reports whether it acted on the message, which then goes no further.
+type MessageFilter interface {
+	Filter(ctx context.Context, message ChatMessage) bool
+}
+
+// MessageObserver sees every chat message before it is resolved, whether or
+// not it turns out to be a command.
+type MessageObserver interface {
+	Observe(ctx context.Context, message ChatMessage)
+}
+
 type MessageService struct {
-	cmdManagerService     *CmdManagerService
-	userCmdManagerService *UserCmdManagerService
+	filters               []MessageFilter
+	observers             []MessageObserver
+	resolvers             []MessageResolver
 	platformModuleService *service.PlatformModuleIn
 
 	logger applog.Logger
 }
 
+// NewMessageService dispatches messages to the first of resolvers that
+// resolves them, so their order is the order of precedence.
 func NewMessageService(
-	commandManager *CmdManagerService,
-	userCommand *UserCmdManagerService,
+	filters []MessageFilter,
+	observers []MessageObserver,
+	resolvers []MessageResolver,
 	platformModuleService *service.PlatformModuleIn,
 ) *MessageService {
 	logger := applog.NewServiceLogger("message-service")
 
 	return &MessageService{
-		cmdManagerService:     commandManager,
+		filters:               filters,
+		observers:             observers,
+		resolvers:             resolvers,
 		platformModuleService: platformModuleService,
-		userCmdManagerService: userCommand,
 		logger:                logger,
 	}
 }
 
 func (s *MessageService) HandleNewMessage(ctx context.Context, event events.Message) error {
-	switch {
-	case s.cmdManagerService.IsCommandEvent(event):
+	message := parseChatMessage(event)
+
+	for _, filter := range s.filters {
+		if filter.Filter(ctx, message) {
+			return nil
+		}
+	}
+
+	for _, observer := range s.observers {
+		observer.Observe(ctx, message)
+	}
+
+	for _, resolver := range s.resolvers {
+		resolved, err := resolver.Resolve(ctx, message)
+		if err != nil {
+			return err
+		}
+		if resolved == nil {
+			continue
+		}
+
 		s.logger.DebugContext(ctx, "new command", "event", event)
-		response, err := s.cmdManagerService.Execute(ctx, event)
+		response, err := resolved.Execute(ctx)
 		if err != nil {
 			if errors.Is(err, apperror.ErrNoAction) {
 				s.logger.DebugContext(ctx, "no action is needed")
@@ -51,17 +133,7 @@ func (s *MessageService) HandleNewMessage(ctx context.Context, event events.Mess
 			s.logger.ErrorContext(ctx, "cannot send chat message")
 			return err
 		}
-	case s.userCmdManagerService.IsCommandEvent(ctx, event):
-		s.logger.DebugContext(ctx, "new user command", "event", event)
-		response, err := s.userCmdManagerService.Execute(ctx, event)
-		if err != nil {
-			return err
-		}
-		err = s.platformModuleService.ChatSendMessage(ctx, *response)
-		if err != nil {
-			s.logger.ErrorContext(ctx, "cannot send chat message")
-			return err
-		}
+		return nil
 	}
 
 	return nil
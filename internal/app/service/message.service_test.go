@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/events"
+)
+
+type benchFilter struct{}
+
+func (benchFilter) Filter(ctx context.Context, message ChatMessage) bool { return false }
+
+type benchObserver struct{}
+
+func (benchObserver) Observe(ctx context.Context, message ChatMessage) {}
+
+// benchResolver resolves only messages starting with word, to a command that
+// takes no action, so nothing is sent.
+type benchResolver struct {
+	word string
+}
+
+func (r benchResolver) Resolve(ctx context.Context, message ChatMessage) (ResolvedCommand, error) {
+	if message.Word() != r.word {
+		return nil, nil
+	}
+	return benchCommand{}, nil
+}
+
+type benchCommand struct{}
+
+func (benchCommand) Execute(ctx context.Context) (*events.MessageSend, error) {
+	return nil, apperror.ErrNoAction
+}
+
+func benchMessage(text string) events.Message {
+	return events.Message{
+		MessageID:        "message-id",
+		Message:          text,
+		BroadcasterLogin: "broadcaster",
+		ChatterID:        "chatter-id",
+		ChatterLogin:     "chatter",
+	}
+}
+
+func BenchmarkParseChatMessage(b *testing.B) {
+	messages := map[string]string{
+		"command": "!so @someone with a few more words",
+		"chat":    "just a plain chat message that is not a command at all",
+		"single":  "!points",
+	}
+
+	for name, text := range messages {
+		event := benchMessage(text)
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				parseChatMessage(event)
+			}
+		})
+	}
+}
+
+func BenchmarkMessageServiceHandleNewMessage(b *testing.B) {
+	for _, n := range []int{1, 8, 32} {
+		resolvers := make([]MessageResolver, n)
+		for i := range resolvers {
+			resolvers[i] = benchResolver{word: fmt.Sprintf("!cmd%d", i)}
+		}
+		s := NewMessageService(
+			[]MessageFilter{benchFilter{}, benchFilter{}},
+			[]MessageObserver{benchObserver{}},
+			resolvers,
+			nil,
+		)
+		ctx := context.Background()
+
+		b.Run(fmt.Sprintf("resolvers=%d/miss", n), func(b *testing.B) {
+			event := benchMessage("just chatting, nothing to resolve")
+			for b.Loop() {
+				if err := s.HandleNewMessage(ctx, event); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("resolvers=%d/last", n), func(b *testing.B) {
+			event := benchMessage(fmt.Sprintf("!cmd%d some args", n-1))
+			for b.Loop() {
+				if err := s.HandleNewMessage(ctx, event); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
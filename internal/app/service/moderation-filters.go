@@ -0,0 +1,148 @@
+package service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+// filter defaults, used for the settings left at zero
+const (
+	filterMinLength      = 10
+	filterCapsPercent    = 70
+	filterSymbolsPercent = 50
+	filterMaxWordRepeats = 8
+	filterMaxRepeats     = 3
+	filterWindow         = 60
+	filterMaxLength      = 300
+)
+
+// linkPattern finds anything shaped like a host, with or without a scheme,
+// capturing the scheme, the host and its top level domain.
+var linkPattern = regexp.MustCompile(`(?i)(https?://)?((?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+([a-z]{2,24}))\b`)
+
+// linkTLDs are the top level domains a host without a scheme or www. must end
+// in to count as a link, so that typos like "ok.so" or "hi.how" do not. They
+// are the common ones and the ones spam favors, leaving out the ones that are
+// mostly words, like so, it, me or be.
+var linkTLDs = map[string]bool{
+	"com": true, "net": true, "org": true, "info": true, "biz": true,
+	"io": true, "gg": true, "tv": true, "co": true, "ly": true, "gl": true, "gd": true, "cc": true, "ws": true, "xyz": true,
+	"app": true, "dev": true, "site": true, "online": true, "shop": true,
+	"store": true, "live": true, "link": true, "club": true, "top": true,
+	"click": true, "tk": true, "ml": true, "ga": true, "cf": true, "gq": true,
+	"ru": true, "de": true, "uk": true, "us": true, "eu": true, "ca": true,
+	"au": true, "fr": true, "nl": true, "pl": true, "br": true, "jp": true,
+}
+
+func orDefault(value int32, fallback int32) int32 {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// checkFilter returns the reason text breaks the filter, or an empty string.
+// Repetition depends on earlier messages and is not checked here.
+func checkFilter(filter data.ModerationFilter, text string) string {
+	settings := filter.Settings
+
+	switch filter.Kind {
+	case data.FilterKindLinks:
+		if host := findLink(text, settings.AllowedDomains); host != "" {
+			return "links are not allowed"
+		}
+	case data.FilterKindCaps:
+		if exceedsPercent(text, settings, filterCapsPercent, unicode.IsUpper, unicode.IsLetter) {
+			return "too many caps"
+		}
+	case data.FilterKindSymbols:
+		if exceedsPercent(text, settings, filterSymbolsPercent, isSymbol, isCounted) {
+			return "too many symbols"
+		}
+	case data.FilterKindRepetitionWords:
+		if maxWordRepeats(text) > int(orDefault(settings.MaxWordRepeats, filterMaxWordRepeats)) {
+			return "too many repeated words"
+		}
+	case data.FilterKindLength:
+		maxLength := orDefault(settings.MaxLength, filterMaxLength)
+		if utf8.RuneCountInString(text) > int(maxLength) {
+			return "message longer than " + strconv.Itoa(int(maxLength)) + " characters"
+		}
+	}
+
+	return ""
+}
+
+// findLink returns the first host linked in text that is not one of allowed
+// or their subdomains.
+func findLink(text string, allowed []string) string {
+	for _, match := range linkPattern.FindAllStringSubmatch(text, -1) {
+		host := strings.ToLower(match[2])
+		if match[1] == "" && !strings.HasPrefix(host, "www.") && !linkTLDs[strings.ToLower(match[3])] {
+			continue
+		}
+		if !isAllowedHost(host, allowed) {
+			return host
+		}
+	}
+	return ""
+}
+
+func isAllowedHost(host string, allowed []string) bool {
+	for _, domain := range allowed {
+		domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// exceedsPercent reports whether more than the allowed percent of the counted
+// runes of text are matching ones, once text has enough counted runes.
+func exceedsPercent(text string, settings data.FilterSettings, fallback int32, matches, counted func(rune) bool) bool {
+	var total, matching int
+	for _, r := range text {
+		if !counted(r) {
+			continue
+		}
+		total++
+		if matches(r) {
+			matching++
+		}
+	}
+
+	if total < int(orDefault(settings.MinLength, filterMinLength)) {
+		return false
+	}
+	return matching*100 > total*int(orDefault(settings.MaxPercent, fallback))
+}
+
+func isSymbol(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func isCounted(r rune) bool {
+	return !unicode.IsSpace(r)
+}
+
+func maxWordRepeats(text string) int {
+	counts := map[string]int{}
+	var most int
+	for _, word := range strings.Fields(text) {
+		counts[word]++
+		most = max(most, counts[word])
+	}
+	return most
+}
+
+// normalizeRepetition makes messages that only differ in case or spacing
+// count as the same one.
+func normalizeRepetition(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+func TestFindLink(t *testing.T) {
+	tests := []struct {
+		text    string
+		allowed []string
+		want    string
+	}{
+		{"check example.com", nil, "example.com"},
+		{"https://Example.org/page", nil, "example.org"},
+		{"http://example.so", nil, "example.so"},
+		{"www.example.so", nil, "www.example.so"},
+		{"join discord.gg/abc", nil, "discord.gg"},
+		{"bit.ly/x", nil, "bit.ly"},
+		{"ok.so", nil, ""},
+		{"hi.how are you", nil, ""},
+		{"end of sentence.next one", nil, ""},
+		{"3.14", nil, ""},
+		{"no links here", nil, ""},
+		{"clips.twitch.tv/abc", []string{"twitch.tv"}, ""},
+		{"twitch.tv", []string{".twitch.tv"}, ""},
+		{"eviltwitch.tv", []string{"twitch.tv"}, "eviltwitch.tv"},
+		{"twitch.tv and example.com", []string{"twitch.tv"}, "example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := findLink(tt.text, tt.allowed); got != tt.want {
+			t.Errorf("findLink(%q, %q) = %q, want %q", tt.text, tt.allowed, got, tt.want)
+		}
+	}
+}
+
+func TestExceedsPercent(t *testing.T) {
+	tests := []struct {
+		text     string
+		settings data.FilterSettings
+		want     bool
+	}{
+		{"HELLO THERE CHAT", data.FilterSettings{}, true},
+		{"Hello there chat", data.FilterSettings{}, false},
+		{"HI CHAT", data.FilterSettings{}, false},
+		{"HI CHAT", data.FilterSettings{MinLength: 5}, true},
+		{"HELLO there", data.FilterSettings{}, false},
+		{"HELLO there", data.FilterSettings{MaxPercent: 40}, true},
+		{"HELLO12345", data.FilterSettings{}, false},
+	}
+
+	for _, tt := range tests {
+		if got := exceedsPercent(tt.text, tt.settings, filterCapsPercent, unicode.IsUpper, unicode.IsLetter); got != tt.want {
+			t.Errorf("exceedsPercent(%q, %+v) = %v, want %v", tt.text, tt.settings, got, tt.want)
+		}
+	}
+}
+
+func TestCheckFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     data.FilterKind
+		settings data.FilterSettings
+		text     string
+		want     string
+	}{
+		{"link", data.FilterKindLinks, data.FilterSettings{}, "go to example.com", "links are not allowed"},
+		{"allowed link", data.FilterKindLinks, data.FilterSettings{AllowedDomains: []string{"example.com"}}, "go to example.com", ""},
+		{"typo", data.FilterKindLinks, data.FilterSettings{}, "ok.so what now", ""},
+		{"caps", data.FilterKindCaps, data.FilterSettings{}, "WHAT IS GOING ON", "too many caps"},
+		{"some caps", data.FilterKindCaps, data.FilterSettings{}, "What is going on", ""},
+		{"symbols", data.FilterKindSymbols, data.FilterSettings{}, "!!!!!!!!!!?????", "too many symbols"},
+		{"some symbols", data.FilterKindSymbols, data.FilterSettings{}, "hello chat!!", ""},
+		{"repeated words", data.FilterKindRepetitionWords, data.FilterSettings{MaxWordRepeats: 3}, "Kappa Kappa Kappa Kappa", "too many repeated words"},
+		{"few repeated words", data.FilterKindRepetitionWords, data.FilterSettings{MaxWordRepeats: 3}, "Kappa Kappa Kappa", ""},
+		{"length", data.FilterKindLength, data.FilterSettings{MaxLength: 5}, "toolong", "message longer than 5 characters"},
+		{"default length", data.FilterKindLength, data.FilterSettings{}, strings.Repeat("a", filterMaxLength), ""},
+		{"repetition", data.FilterKindRepetition, data.FilterSettings{}, "same", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := data.ModerationFilter{Kind: tt.kind, Settings: tt.settings}
+			if got := checkFilter(filter, tt.text); got != tt.want {
+				t.Errorf("checkFilter(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
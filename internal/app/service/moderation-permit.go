@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/events"
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	// linkPermitOnceWindow is how long a permit for a single link lasts.
+	linkPermitOnceWindow  = 5 * time.Minute
+	maxLinkPermitDuration = 24 * 60 * 60
+)
+
+// linkPermitGrant is the cached value of a link permit.
+type linkPermitGrant struct {
+	Once      bool        `json:"once"`
+	GrantedBy *data.Actor `json:"grantedBy,omitempty"`
+}
+
+func getLinkPermitKVKey(platform platform.Platform, broadcasterID string, chatterLogin string) string {
+	return "mod.permit." + platform.String() + "." + broadcasterID + "." + chatterLogin
+}
+
+// Permit lets a chatter post links despite the links filter, replacing the
+// permit they may already have.
+func (s *ModerationService) Permit(ctx context.Context, arg data.LinkPermit) (data.LinkPermit, error) {
+	arg.ChatterLogin = normalizeLogin(arg.ChatterLogin)
+
+	errs := data.FieldErrors{}
+	if arg.ChatterLogin == "" {
+		errs.Add("chatterLogin", "is required")
+	}
+	if arg.Duration < 0 || arg.Duration > maxLinkPermitDuration {
+		errs.Add("duration", "must be between 0 and "+strconv.Itoa(maxLinkPermitDuration)+" seconds")
+	}
+	if err := errs.Err(); err != nil {
+		return data.LinkPermit{}, err
+	}
+
+	grant := linkPermitGrant{
+		Once:      arg.Duration == 0,
+		GrantedBy: appctx.GetActor(ctx),
+	}
+	ttl := time.Second * time.Duration(arg.Duration)
+	if grant.Once {
+		ttl = linkPermitOnceWindow
+	}
+
+	err := mirrorWithTTL(ctx, s.cache, getLinkPermitKVKey(arg.Platform, arg.BroadcasterID, arg.ChatterLogin), grant, ttl)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot store link permit", "err", err)
+		return data.LinkPermit{}, apperror.ErrInternal
+	}
+
+	s.logger.InfoContext(ctx, "link permit granted",
+		"platform", arg.Platform,
+		"broadcasterID", arg.BroadcasterID,
+		"chatterLogin", arg.ChatterLogin,
+		"once", grant.Once,
+		"ttl", ttl,
+		"grantedBy", grant.GrantedBy,
+	)
+
+	return arg, nil
+}
+
+// usePermit reports whether the chatter of event has a link permit, using it
+// up when it is for a single link.
+func (s *ModerationService) usePermit(ctx context.Context, event events.Message) bool {
+	key := getLinkPermitKVKey(event.Platform, event.BroadcasterID, normalizeLogin(event.ChatterLogin))
+
+	entry, err := s.cache.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, jetstream.ErrKeyNotFound) {
+			s.logger.ErrorContext(ctx, "cannot get link permit", "err", err)
+		}
+		return false
+	}
+
+	var grant linkPermitGrant
+	err = json.Unmarshal(entry.Value(), &grant)
+	if err != nil {
+		s.logger.WarnContext(ctx, "cannot decode link permit", "err", err)
+		return false
+	}
+
+	if grant.Once {
+		// the revision makes sure two messages cannot use the same permit
+		err = s.cache.Delete(ctx, key, jetstream.LastRevision(entry.Revision()))
+		if err != nil {
+			s.logger.DebugContext(ctx, "link permit already used", "err", err)
+			return false
+		}
+	}
+
+	s.logger.InfoContext(ctx, "link permit used",
+		"platform", event.Platform,
+		"broadcasterID", event.BroadcasterID,
+		"chatterLogin", event.ChatterLogin,
+		"messageID", event.MessageID,
+		"once", grant.Once,
+		"grantedBy", grant.GrantedBy,
+	)
+
+	return true
+}
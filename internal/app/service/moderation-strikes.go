@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/events"
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+const (
+	maxStrikeSteps = 10
+	minStrikeDecay = 60
+	maxStrikeDecay = 30 * 24 * 60 * 60
+)
+
+// getStrikeKVKey is the key of one strike. Every strike is its own key that
+// expires after the decay of the ladder, so strikes are forgotten one by one.
+func getStrikeKVKey(platform platform.Platform, broadcasterID string, chatterID string, at time.Time) string {
+	return getStrikesKVPrefix(platform, broadcasterID, chatterID) + strconv.FormatInt(at.UnixNano(), 10)
+}
+
+func getStrikesKVPrefix(platform platform.Platform, broadcasterID string, chatterID string) string {
+	return "mod.strike." + platform.String() + "." + broadcasterID + "." + chatterID + "."
+}
+
+// getStrikeLoginKVKey maps the login of a chatter with strikes to their ID,
+// so mods can name them in !strikes and !pardon. It lives as long as the
+// last strike does.
+func getStrikeLoginKVKey(platform platform.Platform, broadcasterID string, login string) string {
+	return "mod.strikelogin." + platform.String() + "." + broadcasterID + "." + login
+}
+
+// GetLadder returns the strike ladder of the channel, or the default one when
+// it was never configured.
+func (s *ModerationService) GetLadder(ctx context.Context, arg data.StrikeLadderGet) (data.StrikeLadder, error) {
+	fromDB, err := s.store.Query(ctx).CoreStrikeLadderGet(ctx, arg.UserID)
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.NewStrikeLadder(arg.UserID), nil
+		}
+		return data.StrikeLadder{}, err
+	}
+
+	return data.NewStrikeLadderFromDB(fromDB), nil
+}
+
+func (s *ModerationService) UpdateLadder(ctx context.Context, arg data.StrikeLadderUpdate) (data.StrikeLadder, error) {
+	errs := data.FieldErrors{}
+	if arg.Steps != nil {
+		errs.Add("steps", checkStrikeSteps(arg.Steps))
+	}
+	if arg.Decay != nil && (*arg.Decay < minStrikeDecay || *arg.Decay > maxStrikeDecay) {
+		errs.Add("decay", "must be between 1 minute and 30 days")
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.StrikeLadder{}, err
+	}
+
+	params := db.CoreStrikeLadderUpsertParams{
+		UserID:  arg.UserID,
+		Enabled: arg.Enabled,
+		Decay:   arg.Decay,
+	}
+	if arg.Steps != nil {
+		params.Steps, _ = json.Marshal(arg.Steps)
+	}
+
+	fromDB, err := s.store.Query(ctx).CoreStrikeLadderUpsert(ctx, params)
+	if err != nil {
+		return data.StrikeLadder{}, s.store.HandleErr(ctx, err)
+	}
+	s.forget(arg.UserID)
+
+	return data.NewStrikeLadderFromDB(fromDB), nil
+}
+
+func checkStrikeSteps(steps []data.StrikeStep) string {
+	if len(steps) == 0 || len(steps) > maxStrikeSteps {
+		return "must have between 1 and " + strconv.Itoa(maxStrikeSteps) + " steps"
+	}
+	for _, step := range steps {
+		if !step.Action.IsEnum() {
+			return "unknown action " + step.Action.String()
+		}
+		if step.Action == data.ModerationActionTimeout && (step.Duration <= 0 || step.Duration > maxModerationTimeout) {
+			return "timeouts must be between 1 second and 2 weeks"
+		}
+	}
+	return ""
+}
+
+// Strikes counts the strikes of a chatter that have not decayed yet.
+func (s *ModerationService) Strikes(ctx context.Context, arg data.StrikeChatter) (data.Strikes, error) {
+	arg, err := s.resolveStrikeChatter(ctx, arg)
+	if err != nil {
+		return data.Strikes{}, err
+	}
+	if arg.ChatterID == "" {
+		return data.Strikes{ChatterLogin: arg.ChatterLogin}, nil
+	}
+
+	keys, err := s.strikeKeys(ctx, arg.Platform, arg.BroadcasterID, arg.ChatterID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot list strikes", "err", err)
+		return data.Strikes{}, apperror.ErrInternal
+	}
+
+	return data.Strikes{ChatterLogin: arg.ChatterLogin, Count: len(keys)}, nil
+}
+
+// Pardon forgets every strike of a chatter, returning how many they had.
+func (s *ModerationService) Pardon(ctx context.Context, arg data.StrikeChatter) (data.Strikes, error) {
+	arg, err := s.resolveStrikeChatter(ctx, arg)
+	if err != nil {
+		return data.Strikes{}, err
+	}
+	if arg.ChatterID == "" {
+		return data.Strikes{ChatterLogin: arg.ChatterLogin}, nil
+	}
+
+	keys, err := s.strikeKeys(ctx, arg.Platform, arg.BroadcasterID, arg.ChatterID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot list strikes", "err", err)
+		return data.Strikes{}, apperror.ErrInternal
+	}
+	for _, key := range keys {
+		err = s.cache.Purge(ctx, key)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "cannot purge strike", "err", err, "key", key)
+			return data.Strikes{}, apperror.ErrInternal
+		}
+	}
+
+	s.logger.InfoContext(ctx, "strikes pardoned",
+		"platform", arg.Platform,
+		"broadcasterID", arg.BroadcasterID,
+		"chatterID", arg.ChatterID,
+		"chatterLogin", arg.ChatterLogin,
+		"strikes", len(keys),
+		"pardonedBy", appctx.GetActor(ctx),
+	)
+
+	return data.Strikes{ChatterLogin: arg.ChatterLogin, Count: len(keys)}, nil
+}
+
+// resolveStrikeChatter fills in the ID of a chatter named by login from the
+// login their last strike was given under. The ID stays empty when no strike
+// of that login is left, so the chatter has none to count or pardon.
+func (s *ModerationService) resolveStrikeChatter(ctx context.Context, arg data.StrikeChatter) (data.StrikeChatter, error) {
+	arg.ChatterLogin = normalizeLogin(arg.ChatterLogin)
+	if arg.ChatterID != "" {
+		return arg, nil
+	}
+
+	errs := data.FieldErrors{}
+	if arg.ChatterLogin == "" {
+		errs.Add("chatterLogin", "is required without chatterId")
+	}
+	if err := errs.Err(); err != nil {
+		return data.StrikeChatter{}, err
+	}
+
+	entry, err := s.cache.Get(ctx, getStrikeLoginKVKey(arg.Platform, arg.BroadcasterID, arg.ChatterLogin))
+	if err != nil {
+		// a login that cannot be a key was never given a strike either
+		if errors.Is(err, jetstream.ErrKeyNotFound) || errors.Is(err, jetstream.ErrInvalidKey) {
+			return arg, nil
+		}
+		s.logger.ErrorContext(ctx, "cannot get strike login", "err", err)
+		return data.StrikeChatter{}, apperror.ErrInternal
+	}
+
+	err = json.Unmarshal(entry.Value(), &arg.ChatterID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot unmarshal strike login", "err", err)
+		return data.StrikeChatter{}, apperror.ErrInternal
+	}
+	return arg, nil
+}
+
+// strike gives the chatter of event a strike, returning how many they have
+// now, or zero when it could not be counted.
+func (s *ModerationService) strike(ctx context.Context, ladder data.StrikeLadder, event events.Message) int {
+	decay := time.Second * time.Duration(ladder.Decay)
+
+	_, err := s.cache.Create(ctx, getStrikeKVKey(event.Platform, event.BroadcasterID, event.ChatterID, time.Now()), []byte{}, jetstream.KeyTTL(decay))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot add strike", "err", err)
+		return 0
+	}
+
+	err = mirrorWithTTL(ctx, s.cache, getStrikeLoginKVKey(event.Platform, event.BroadcasterID, normalizeLogin(event.ChatterLogin)), event.ChatterID, decay)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot map strike login", "err", err)
+	}
+
+	keys, err := s.strikeKeys(ctx, event.Platform, event.BroadcasterID, event.ChatterID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot count strikes", "err", err)
+		return 0
+	}
+
+	return len(keys)
+}
+
+func (s *ModerationService) strikeKeys(ctx context.Context, platform platform.Platform, broadcasterID string, chatterID string) ([]string, error) {
+	lister, err := s.cache.ListKeysFiltered(ctx, getStrikesKVPrefix(platform, broadcasterID, chatterID)+"*")
+	if err != nil {
+		return nil, err
+	}
+	defer lister.Stop()
+
+	var keys []string
+	for key := range lister.Keys() {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// normalizeLogin turns a login as mods write it, like @User, into the login
+// chat events carry.
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(login), "@"))
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/events"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/arnokay/arnobot-shared/topics"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/cmdvalidate"
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/expiring"
+	"github.com/arnokay/arnobot-core/internal/storage"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+const (
+	moderationFiltersTTL = time.Minute
+
+	maxModerationTimeout = 14 * 24 * 60 * 60
+)
+
+type ModerationService struct {
+	mb                    *nats.Conn
+	cache                 jetstream.KeyValue
+	store                 storage.Storager
+	platformModuleService *service.PlatformModuleIn
+
+	filters *expiring.Cache[uuid.UUID, moderationFilters]
+
+	logger applog.Logger
+}
+
+func NewModerationService(
+	mb *nats.Conn,
+	cache jetstream.KeyValue,
+	store storage.Storager,
+	platformModuleService *service.PlatformModuleIn,
+) *ModerationService {
+	logger := applog.NewServiceLogger("moderation-service")
+
+	return &ModerationService{
+		mb:                    mb,
+		cache:                 cache,
+		store:                 store,
+		platformModuleService: platformModuleService,
+		filters:               expiring.New[uuid.UUID, moderationFilters](moderationFiltersTTL),
+
+		logger: logger,
+	}
+}
+
+type moderationFilters struct {
+	enabled []data.ModerationFilter
+	ladder  data.StrikeLadder
+}
+
+func getRepetitionKVKey(event events.Message, hash uint64, n int) string {
+	return "mod.rep." + event.Platform.String() + "." + event.BroadcasterID + "." + event.ChatterID + "." +
+		strconv.FormatUint(hash, 16) + "." + strconv.Itoa(n)
+}
+
+// List returns every kind of filter of the channel, with defaults for the
+// ones never configured.
+func (s *ModerationService) List(ctx context.Context, arg data.ModerationFilterList) ([]data.ModerationFilter, error) {
+	fromDBs, err := s.store.Query(ctx).CoreModerationFilterGetByUserID(ctx, arg.UserID)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	configured := make(map[data.FilterKind]data.ModerationFilter, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		filter := data.NewModerationFilterFromDB(fromDB)
+		configured[filter.Kind] = filter
+	}
+
+	kinds := data.FilterKinds()
+	filters := make([]data.ModerationFilter, 0, len(kinds))
+	for _, kind := range kinds {
+		filter, ok := configured[kind]
+		if !ok {
+			filter = data.NewModerationFilter(arg.UserID, kind)
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+func (s *ModerationService) Update(ctx context.Context, arg data.ModerationFilterUpdate) (data.ModerationFilter, error) {
+	errs := data.FieldErrors{}
+	if !arg.Kind.IsEnum() {
+		errs.Add("kind", "unknown filter")
+	}
+	if arg.Action != nil && !arg.Action.IsEnum() {
+		errs.Add("action", "must be delete, timeout, warn or ban")
+	}
+	if arg.Timeout != nil && (*arg.Timeout <= 0 || *arg.Timeout > maxModerationTimeout) {
+		errs.Add("timeout", "must be between 1 second and 2 weeks")
+	}
+	for _, level := range arg.Exempt {
+		if !level.IsEnum() {
+			errs.Add("exempt", "unknown user level "+level.String())
+		}
+	}
+	if arg.Settings != nil {
+		errs.Add("settings", checkFilterSettings(*arg.Settings))
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.ModerationFilter{}, err
+	}
+
+	params := db.CoreModerationFilterUpsertParams{
+		UserID:  arg.UserID,
+		Kind:    arg.Kind.String(),
+		Enabled: arg.Enabled,
+		Action:  (*string)(arg.Action),
+		Timeout: arg.Timeout,
+	}
+	if arg.Exempt != nil {
+		params.Exempt = make([]string, 0, len(arg.Exempt))
+		for _, level := range arg.Exempt {
+			params.Exempt = append(params.Exempt, level.String())
+		}
+	}
+	if arg.Settings != nil {
+		params.Settings, _ = json.Marshal(arg.Settings)
+	}
+
+	fromDB, err := s.store.Query(ctx).CoreModerationFilterUpsert(ctx, params)
+	if err != nil {
+		return data.ModerationFilter{}, s.store.HandleErr(ctx, err)
+	}
+	s.forget(arg.UserID)
+
+	return data.NewModerationFilterFromDB(fromDB), nil
+}
+
+func checkFilterSettings(settings data.FilterSettings) string {
+	for _, value := range []int32{
+		settings.MinLength,
+		settings.MaxPercent,
+		settings.MaxWordRepeats,
+		settings.MaxRepeats,
+		settings.Window,
+		settings.MaxLength,
+	} {
+		if value < 0 {
+			return "cannot be negative"
+		}
+	}
+	if settings.MaxPercent > 100 {
+		return "percent cannot be over 100"
+	}
+	if settings.Window > cmdvalidate.MaxCooldown {
+		return "window must be at most " + strconv.Itoa(cmdvalidate.MaxCooldown) + " seconds"
+	}
+	return ""
+}
+
+// Filter runs the enabled filters of the channel on the message, acting on
+// the first one it breaks. It reports whether the message was moderated, in
+// which case nothing else should handle it.
+func (s *ModerationService) Filter(ctx context.Context, message ChatMessage) bool {
+	event := message.Event
+
+	if message.Private || event.ChatterID == event.BotID {
+		return false
+	}
+
+	channel, err := s.load(ctx, event.UserID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot load moderation filters", "err", err, "userID", event.UserID)
+		return false
+	}
+
+	for _, filter := range channel.enabled {
+		if filter.Exempts(event.ChatterRole) {
+			continue
+		}
+
+		reason := checkFilter(filter, event.Message)
+		if filter.Kind == data.FilterKindRepetition {
+			reason = s.checkRepetition(ctx, filter, event)
+		}
+		if reason == "" {
+			continue
+		}
+		if filter.Kind == data.FilterKindLinks && s.usePermit(ctx, event) {
+			continue
+		}
+
+		s.logger.DebugContext(ctx, "message broke a moderation filter", "filter", filter.Kind, "reason", reason, "chatterID", event.ChatterID)
+		s.Act(ctx, event, filter.Action, filter.Timeout, reason)
+		return true
+	}
+
+	return false
+}
+
+// checkRepetition counts the message in one of MaxRepeats expiring slots; it
+// is a repetition once every slot is taken.
+func (s *ModerationService) checkRepetition(ctx context.Context, filter data.ModerationFilter, event events.Message) string {
+	hash := fnv.New64a()
+	hash.Write([]byte(normalizeRepetition(event.Message)))
+	sum := hash.Sum64()
+
+	maxRepeats := int(orDefault(filter.Settings.MaxRepeats, filterMaxRepeats))
+	window := time.Second * time.Duration(orDefault(filter.Settings.Window, filterWindow))
+
+	for n := range maxRepeats {
+		_, err := s.cache.Create(ctx, getRepetitionKVKey(event, sum, n), []byte{}, jetstream.KeyTTL(window))
+		if err == nil {
+			return ""
+		}
+		if !errors.Is(err, jetstream.ErrKeyExists) {
+			s.logger.ErrorContext(ctx, "cannot count repeated message", "err", err)
+			return ""
+		}
+	}
+
+	return "repeated message"
+}
+
+// Act takes action against the chatter of event for reason. The timeout, in
+// seconds, is only used by the timeout action. When the channel has enabled
+// its strike ladder, the chatter gets a strike and the step of the ladder is
+// taken instead.
+func (s *ModerationService) Act(ctx context.Context, event events.Message, action data.ModerationAction, timeout int32, reason string) {
+	channel, err := s.load(ctx, event.UserID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot load strike ladder", "err", err, "userID", event.UserID)
+	}
+	if err == nil && channel.ladder.Enabled {
+		if strike := s.strike(ctx, channel.ladder, event); strike > 0 {
+			step := channel.ladder.Step(strike)
+			action, timeout = step.Action, step.Duration
+			reason += " (strike " + strconv.Itoa(strike) + ")"
+		}
+	}
+
+	if action == data.ModerationActionWarn {
+		err := s.platformModuleService.ChatSendMessage(ctx, events.MessageSend{
+			EventCommon: event.EventCommon,
+			Message:     "@" + event.ChatterLogin + " " + reason,
+			ReplyTo:     event.MessageID,
+		})
+		if err != nil {
+			s.logger.ErrorContext(ctx, "cannot send moderation warning", "err", err)
+		}
+		return
+	}
+
+	moderation := data.ModerationSend{
+		EventCommon:  event.EventCommon,
+		Action:       action,
+		MessageID:    event.MessageID,
+		ChatterID:    event.ChatterID,
+		ChatterLogin: event.ChatterLogin,
+		Reason:       reason,
+	}
+	if action == data.ModerationActionTimeout {
+		moderation.Duration = timeout
+	}
+
+	err = s.send(ctx, moderation)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot send moderation action", "err", err, "action", action)
+	}
+}
+
+func (s *ModerationService) send(ctx context.Context, arg data.ModerationSend) error {
+	topic := topics.TopicBuilder(coreTopics.PlatformBroadcasterChatModerate).
+		Platform(arg.Platform).
+		BroadcasterID(arg.BroadcasterID).
+		Build()
+
+	return service.HandlePublish(ctx, s.mb, s.logger, topic, arg)
+}
+
+// load returns the enabled filters and the strike ladder of the channel,
+// reading them from the database at most once per moderationFiltersTTL.
+func (s *ModerationService) load(ctx context.Context, userID uuid.UUID) (moderationFilters, error) {
+	if cached, ok := s.filters.Get(userID); ok {
+		return cached, nil
+	}
+
+	filters, err := s.List(ctx, data.ModerationFilterList{UserID: userID})
+	if err != nil {
+		return moderationFilters{}, err
+	}
+	ladder, err := s.GetLadder(ctx, data.StrikeLadderGet{UserID: userID})
+	if err != nil {
+		return moderationFilters{}, err
+	}
+
+	cached := moderationFilters{ladder: ladder}
+	for _, filter := range filters {
+		if filter.Enabled {
+			cached.enabled = append(cached.enabled, filter)
+		}
+	}
+
+	s.filters.Set(userID, cached)
+
+	return cached, nil
+}
+
+func (s *ModerationService) forget(userID uuid.UUID) {
+	s.filters.Delete(userID)
+}
@@ -0,0 +1,467 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	"unicode"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/events"
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/expiring"
+	"github.com/arnokay/arnobot-core/internal/storage"
+)
+
+const (
+	pointsSettingsTTL = time.Minute
+
+	maxPointsAmount         = 1_000_000_000
+	maxPointsEarnAmount     = 1000
+	maxPointsEarnInterval   = 3600
+	maxPointsMinLength      = 500
+	maxPointsDailyCap       = 1_000_000
+	maxPointsIdempotencyKey = 100
+)
+
+var errNotEnoughPoints = apperror.New(apperror.CodeInvalidInput, "not enough points", nil)
+
+type PointsService struct {
+	cache jetstream.KeyValue
+	store storage.Storager
+	tx    service.ITransactionService
+
+	settings *expiring.Cache[uuid.UUID, data.PointsSettings]
+
+	logger applog.Logger
+}
+
+func NewPointsService(
+	cache jetstream.KeyValue,
+	store storage.Storager,
+	tx service.ITransactionService,
+) *PointsService {
+	logger := applog.NewServiceLogger("points-service")
+
+	return &PointsService{
+		cache:    cache,
+		store:    store,
+		tx:       tx,
+		settings: expiring.New[uuid.UUID, data.PointsSettings](pointsSettingsTTL),
+
+		logger: logger,
+	}
+}
+
+func getPointsEarnKVKey(event events.Message) string {
+	return "pts.earn." + event.Platform.String() + "." + event.BroadcasterID + "." + event.ChatterID
+}
+
+// PointsIdempotencyKey makes the idempotency key of the points moved by a
+// chat message, so handling the message twice moves them once.
+func PointsIdempotencyKey(reason data.PointsReason, platform platform.Platform, messageID string) string {
+	return reason.String() + ":" + platform.String() + ":" + messageID
+}
+
+// GetSettings returns the points settings of the channel, or the defaults
+// when it never configured them.
+func (s *PointsService) GetSettings(ctx context.Context, arg data.PointsSettingsGet) (data.PointsSettings, error) {
+	fromDB, err := s.store.Query(ctx).CorePointsSettingsGet(ctx, arg.UserID)
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.NewPointsSettings(arg.UserID), nil
+		}
+		return data.PointsSettings{}, err
+	}
+
+	return data.NewPointsSettingsFromDB(fromDB), nil
+}
+
+func (s *PointsService) UpdateSettings(ctx context.Context, arg data.PointsSettingsUpdate) (data.PointsSettings, error) {
+	errs := data.FieldErrors{}
+	errs.Add("earnAmount", checkPointsSetting(arg.EarnAmount, maxPointsEarnAmount))
+	errs.Add("earnInterval", checkPointsSetting(arg.EarnInterval, maxPointsEarnInterval))
+	errs.Add("minMessageLength", checkPointsSetting(arg.MinMessageLength, maxPointsMinLength))
+	errs.Add("dailyCap", checkPointsSetting(arg.DailyCap, maxPointsDailyCap))
+
+	if err := errs.Err(); err != nil {
+		return data.PointsSettings{}, err
+	}
+
+	fromDB, err := s.store.Query(ctx).CorePointsSettingsUpsert(ctx, db.CorePointsSettingsUpsertParams{
+		UserID:           arg.UserID,
+		Enabled:          arg.Enabled,
+		EarnAmount:       arg.EarnAmount,
+		EarnInterval:     arg.EarnInterval,
+		MinMessageLength: arg.MinMessageLength,
+		DailyCap:         arg.DailyCap,
+	})
+	if err != nil {
+		return data.PointsSettings{}, s.store.HandleErr(ctx, err)
+	}
+	s.forget(arg.UserID)
+
+	return data.NewPointsSettingsFromDB(fromDB), nil
+}
+
+func checkPointsSetting(value *int32, maximum int32) string {
+	if value != nil && (*value < 0 || *value > maximum) {
+		return "must be between 0 and " + strconv.Itoa(int(maximum))
+	}
+	return ""
+}
+
+// Balance returns the account of the chatter, with a zero balance when they
+// never had points. Chatters named by login must have had points before.
+func (s *PointsService) Balance(ctx context.Context, arg data.PointsChatter) (data.PointsAccount, error) {
+	chatter, err := s.resolve(ctx, arg)
+	if err != nil {
+		return data.PointsAccount{}, err
+	}
+
+	fromDB, err := s.store.Query(ctx).CorePointsAccountGet(ctx, db.CorePointsAccountGetParams{
+		UserID:    chatter.UserID,
+		Platform:  chatter.Platform,
+		ChatterID: chatter.ChatterID,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.PointsAccount{
+				UserID:       chatter.UserID,
+				Platform:     chatter.Platform,
+				ChatterID:    chatter.ChatterID,
+				ChatterLogin: chatter.ChatterLogin,
+			}, nil
+		}
+		return data.PointsAccount{}, err
+	}
+
+	return data.NewPointsAccountFromDB(fromDB), nil
+}
+
+// Adjust adds or removes points of a chatter, failing when their balance
+// would go negative. It joins the transaction of ctx, if any, so other
+// services can move points together with their own changes.
+func (s *PointsService) Adjust(ctx context.Context, arg data.PointsAdjust) (data.PointsAccount, error) {
+	errs := data.FieldErrors{}
+	errs.Add("amount", checkPointsAmount(arg.Amount, false))
+	errs.Add("reason", checkPointsReason(arg.Reason))
+	errs.Add("idempotencyKey", checkPointsIdempotencyKey(arg.IdempotencyKey))
+
+	if err := errs.Err(); err != nil {
+		return data.PointsAccount{}, err
+	}
+
+	chatter, err := s.resolve(ctx, arg.PointsChatter)
+	if err != nil {
+		return data.PointsAccount{}, err
+	}
+
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return data.PointsAccount{}, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	account, err := s.apply(txCtx, chatter, arg.Amount, arg.Reason, arg.IdempotencyKey)
+	if err != nil {
+		return data.PointsAccount{}, err
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return data.PointsAccount{}, err
+	}
+
+	return account, nil
+}
+
+// Transfer moves points between two chatters of the same channel.
+func (s *PointsService) Transfer(ctx context.Context, arg data.PointsTransfer) (data.PointsTransferResult, error) {
+	errs := data.FieldErrors{}
+	errs.Add("amount", checkPointsAmount(arg.Amount, true))
+	errs.Add("reason", checkPointsReason(arg.Reason))
+	errs.Add("idempotencyKey", checkPointsIdempotencyKey(arg.IdempotencyKey))
+	if arg.From.UserID != arg.To.UserID || arg.From.Platform != arg.To.Platform {
+		errs.Add("to", "must be in the same channel")
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.PointsTransferResult{}, err
+	}
+
+	from, err := s.resolve(ctx, arg.From)
+	if err != nil {
+		return data.PointsTransferResult{}, err
+	}
+	to, err := s.resolve(ctx, arg.To)
+	if err != nil {
+		return data.PointsTransferResult{}, err
+	}
+	if from.ChatterID == to.ChatterID {
+		return data.PointsTransferResult{}, apperror.New(apperror.CodeInvalidInput, "cannot transfer points to the same chatter", nil)
+	}
+
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return data.PointsTransferResult{}, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	var result data.PointsTransferResult
+	result.From, err = s.apply(txCtx, from, -arg.Amount, arg.Reason, arg.IdempotencyKey+":from")
+	if err != nil {
+		return data.PointsTransferResult{}, err
+	}
+	result.To, err = s.apply(txCtx, to, arg.Amount, arg.Reason, arg.IdempotencyKey+":to")
+	if err != nil {
+		return data.PointsTransferResult{}, err
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return data.PointsTransferResult{}, err
+	}
+
+	return result, nil
+}
+
+func checkPointsAmount(amount int64, positive bool) string {
+	switch {
+	case amount == 0 || (positive && amount < 0):
+		return "must be positive"
+	case amount > maxPointsAmount || amount < -maxPointsAmount:
+		return "must be at most " + strconv.Itoa(maxPointsAmount)
+	}
+	return ""
+}
+
+func checkPointsReason(reason data.PointsReason) string {
+	if !reason.IsEnum() {
+		return "unknown reason"
+	}
+	return ""
+}
+
+func checkPointsIdempotencyKey(key string) string {
+	switch {
+	case key == "":
+		return "is required"
+	case len(key) > maxPointsIdempotencyKey-len(":from"):
+		return "must be at most " + strconv.Itoa(maxPointsIdempotencyKey-len(":from")) + " bytes long"
+	}
+	return ""
+}
+
+// apply records a ledger entry and moves the balance with it, inside the
+// transaction of ctx. An entry already recorded under the same key is not
+// applied again, and the account is returned as it is.
+func (s *PointsService) apply(
+	ctx context.Context,
+	chatter data.PointsChatter,
+	amount int64,
+	reason data.PointsReason,
+	key string,
+) (data.PointsAccount, error) {
+	account, _, err := s.applyOnce(ctx, chatter, amount, reason, key)
+	return account, err
+}
+
+// applyOnce is apply, also reporting whether the entry was applied now
+// rather than by an earlier delivery of the same request.
+func (s *PointsService) applyOnce(
+	ctx context.Context,
+	chatter data.PointsChatter,
+	amount int64,
+	reason data.PointsReason,
+	key string,
+) (data.PointsAccount, bool, error) {
+	_, err := s.store.Query(ctx).CorePointsLedgerCreate(ctx, db.CorePointsLedgerCreateParams{
+		UserID:         chatter.UserID,
+		Platform:       chatter.Platform,
+		ChatterID:      chatter.ChatterID,
+		Amount:         amount,
+		Reason:         reason.String(),
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			s.logger.DebugContext(ctx, "points entry already applied", "key", key)
+			account, err := s.Balance(ctx, chatter)
+			return account, false, err
+		}
+		return data.PointsAccount{}, false, err
+	}
+
+	fromDB, err := s.store.Query(ctx).CorePointsAccountAdd(ctx, db.CorePointsAccountAddParams{
+		UserID:       chatter.UserID,
+		Platform:     chatter.Platform,
+		ChatterID:    chatter.ChatterID,
+		ChatterLogin: chatter.ChatterLogin,
+		Amount:       amount,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrInvalidInput) {
+			return data.PointsAccount{}, false, errNotEnoughPoints
+		}
+		return data.PointsAccount{}, false, err
+	}
+
+	return data.NewPointsAccountFromDB(fromDB), true, nil
+}
+
+// resolve fills in the ID of a chatter named by login from the account they
+// last used it with.
+func (s *PointsService) resolve(ctx context.Context, chatter data.PointsChatter) (data.PointsChatter, error) {
+	chatter.ChatterLogin = normalizeLogin(chatter.ChatterLogin)
+	if chatter.ChatterID != "" {
+		return chatter, nil
+	}
+
+	errs := data.FieldErrors{}
+	if chatter.ChatterLogin == "" {
+		errs.Add("chatterLogin", "is required without chatterId")
+	}
+	if err := errs.Err(); err != nil {
+		return data.PointsChatter{}, err
+	}
+
+	fromDB, err := s.store.Query(ctx).CorePointsAccountGetByLogin(ctx, db.CorePointsAccountGetByLoginParams{
+		UserID:       chatter.UserID,
+		Platform:     chatter.Platform,
+		ChatterLogin: chatter.ChatterLogin,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.PointsChatter{}, apperror.New(apperror.CodeNotFound, "unknown chatter "+chatter.ChatterLogin, nil)
+		}
+		return data.PointsChatter{}, err
+	}
+
+	chatter.ChatterID = fromDB.ChatterID
+	return chatter, nil
+}
+
+// Observe pays the chatter for the message, within the anti-farm limits of
+// the channel: at most once per earn interval, only for long enough
+// messages, and up to the daily cap.
+func (s *PointsService) Observe(ctx context.Context, message ChatMessage) {
+	event := message.Event
+
+	if message.Private || event.ChatterID == event.BotID {
+		return
+	}
+
+	settings, err := s.load(ctx, event.UserID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot load points settings", "err", err, "userID", event.UserID)
+		return
+	}
+	if !settings.Enabled || settings.EarnAmount <= 0 || countPointsLength(event.Message) < int(settings.MinMessageLength) {
+		return
+	}
+
+	if settings.EarnInterval > 0 {
+		interval := time.Second * time.Duration(settings.EarnInterval)
+		_, err = s.cache.Create(ctx, getPointsEarnKVKey(event), []byte{}, jetstream.KeyTTL(interval))
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "cannot check points interval", "err", err)
+			return
+		}
+	}
+
+	chatter := data.PointsChatter{
+		UserID:       event.UserID,
+		Platform:     event.Platform,
+		ChatterID:    event.ChatterID,
+		ChatterLogin: event.ChatterLogin,
+	}
+
+	amount := int64(settings.EarnAmount)
+	if settings.DailyCap > 0 {
+		now := time.Now().UTC()
+		earned, err := s.store.Query(ctx).CorePointsLedgerSumSince(ctx, db.CorePointsLedgerSumSinceParams{
+			UserID:    chatter.UserID,
+			Platform:  chatter.Platform,
+			ChatterID: chatter.ChatterID,
+			Reason:    data.PointsReasonChat.String(),
+			Since:     time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			s.logger.ErrorContext(ctx, "cannot sum earned points", "err", s.store.HandleErr(ctx, err))
+			return
+		}
+		amount = min(amount, int64(settings.DailyCap)-earned)
+		if amount <= 0 {
+			return
+		}
+	}
+
+	_, err = s.Adjust(ctx, data.PointsAdjust{
+		PointsChatter:  chatter,
+		Amount:         amount,
+		Reason:         data.PointsReasonChat,
+		IdempotencyKey: PointsIdempotencyKey(data.PointsReasonChat, event.Platform, event.MessageID),
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot pay points for message", "err", err)
+	}
+}
+
+func countPointsLength(text string) int {
+	var n int
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// Enabled reports whether the channel uses points, for commands to stay
+// quiet otherwise.
+func (s *PointsService) Enabled(ctx context.Context, userID uuid.UUID) bool {
+	settings, err := s.load(ctx, userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot load points settings", "err", err, "userID", userID)
+		return false
+	}
+	return settings.Enabled
+}
+
+// load returns the points settings of the channel, reading them from the
+// database at most once per pointsSettingsTTL.
+func (s *PointsService) load(ctx context.Context, userID uuid.UUID) (data.PointsSettings, error) {
+	if settings, ok := s.settings.Get(userID); ok {
+		return settings, nil
+	}
+
+	settings, err := s.GetSettings(ctx, data.PointsSettingsGet{UserID: userID})
+	if err != nil {
+		return data.PointsSettings{}, err
+	}
+
+	s.settings.Set(userID, settings)
+
+	return settings, nil
+}
+
+func (s *PointsService) forget(userID uuid.UUID) {
+	s.settings.Delete(userID)
+}
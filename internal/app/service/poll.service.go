@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/storage"
+)
+
+const (
+	pollTick       = 10 * time.Second
+	pollClaimLimit = 50
+
+	maxPollQuestionLength = 200
+	maxPollOptionLength   = 50
+	minPollOptions        = 2
+	maxPollOptions        = 10
+
+	// poll durations and update intervals are in seconds
+	minPollDuration = 10
+	maxPollDuration = 24 * 60 * 60
+	// pollUpdateInterval is how often the standings are posted while votes
+	// come in.
+	pollUpdateInterval = 60
+
+	pollListDefaultLimit = 20
+	pollListMaxLimit     = 100
+)
+
+var (
+	errPollNotFound = apperror.New(apperror.CodeNotFound, "there is no poll yet", nil)
+	errPollRunning  = apperror.New(apperror.CodeAlreadyExists, "a poll is running already", nil)
+	errPollClosed   = apperror.New(apperror.CodeNoAction, "the poll is over already", nil)
+)
+
+type PollService struct {
+	cache          jetstream.KeyValue
+	store          storage.Storager
+	tx             service.ITransactionService
+	channelService *ChannelService
+
+	logger applog.Logger
+}
+
+func NewPollService(
+	cache jetstream.KeyValue,
+	store storage.Storager,
+	tx service.ITransactionService,
+	channelService *ChannelService,
+) *PollService {
+	logger := applog.NewServiceLogger("poll-service")
+
+	return &PollService{
+		cache:          cache,
+		store:          store,
+		tx:             tx,
+		channelService: channelService,
+
+		logger: logger,
+	}
+}
+
+// getPollKVKey is the key of the open poll of a channel, mirrored from the
+// database so chat messages are matched to its options without a query each.
+func getPollKVKey(userID uuid.UUID, platform platform.Platform) string {
+	return "poll." + userID.String() + "." + platform.String()
+}
+
+func (s *PollService) Start(ctx context.Context, arg data.PollStart) (data.Poll, error) {
+	arg.Question = strings.TrimSpace(arg.Question)
+	for i := range arg.Options {
+		arg.Options[i] = strings.TrimSpace(arg.Options[i])
+	}
+
+	errs := data.FieldErrors{}
+	if !arg.Platform.IsEnum() {
+		errs.Add("platform", "unknown platform")
+	}
+	if arg.Question == "" || len(arg.Question) > maxPollQuestionLength {
+		errs.Add("question", "must be between 1 and "+strconv.Itoa(maxPollQuestionLength)+" bytes")
+	}
+	if len(arg.Options) < minPollOptions || len(arg.Options) > maxPollOptions {
+		errs.Add("options", "must be between "+strconv.Itoa(minPollOptions)+" and "+strconv.Itoa(maxPollOptions))
+	}
+	seen := make(map[string]bool, len(arg.Options))
+	for _, option := range arg.Options {
+		if option == "" || len(option) > maxPollOptionLength {
+			errs.Add("options", "must each be between 1 and "+strconv.Itoa(maxPollOptionLength)+" bytes")
+			break
+		}
+		if seen[strings.ToLower(option)] {
+			errs.Add("options", "must be unique")
+			break
+		}
+		seen[strings.ToLower(option)] = true
+	}
+	if arg.Duration != 0 && (arg.Duration < minPollDuration || arg.Duration > maxPollDuration) {
+		errs.Add("duration", "must be 0 or between "+strconv.Itoa(minPollDuration)+" and "+strconv.Itoa(maxPollDuration)+" seconds")
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.Poll{}, err
+	}
+
+	params := db.CorePollCreateParams{
+		UserID:   arg.UserID,
+		Platform: arg.Platform,
+		Question: arg.Question,
+		Options:  arg.Options,
+		UpdateIn: pollUpdateInterval,
+	}
+	if arg.Duration > 0 {
+		endsAt := time.Now().UTC().Add(time.Second * time.Duration(arg.Duration))
+		params.EndsAt = &endsAt
+	}
+
+	fromDB, err := s.store.Query(ctx).CorePollCreate(ctx, params)
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrAlreadyExists) {
+			return data.Poll{}, errPollRunning
+		}
+		return data.Poll{}, err
+	}
+
+	poll := data.NewPollFromDB(fromDB)
+	s.mirror(ctx, poll)
+
+	s.logger.InfoContext(ctx, "poll started",
+		"userID", poll.UserID,
+		"pollID", poll.ID,
+		"startedBy", appctx.GetActor(ctx),
+	)
+
+	return poll, nil
+}
+
+// Get returns the poll with its votes, the last one of the channel when no
+// ID is given.
+func (s *PollService) Get(ctx context.Context, arg data.PollGet) (data.Poll, error) {
+	var (
+		fromDB db.CorePoll
+		err    error
+	)
+	if arg.ID > 0 {
+		fromDB, err = s.store.Query(ctx).CorePollGet(ctx, db.CorePollGetParams{
+			UserID: arg.UserID,
+			ID:     arg.ID,
+		})
+	} else {
+		fromDB, err = s.store.Query(ctx).CorePollGetLatest(ctx, db.CorePollGetLatestParams{
+			UserID:   arg.UserID,
+			Platform: arg.Platform,
+		})
+	}
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.Poll{}, errPollNotFound
+		}
+		return data.Poll{}, err
+	}
+
+	poll := data.NewPollFromDB(fromDB)
+	if arg.ID == 0 && poll.Open(time.Now()) {
+		_, err = s.cache.Get(ctx, getPollKVKey(poll.UserID, poll.Platform))
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			// the cache lost it
+			s.mirror(ctx, poll)
+		}
+	}
+
+	return s.tally(ctx, poll)
+}
+
+// End closes the open poll of the channel and posts its results to chat.
+func (s *PollService) End(ctx context.Context, arg data.PollEnd) (data.Poll, error) {
+	poll, err := s.Get(ctx, data.PollGet{
+		UserID:   arg.UserID,
+		Platform: arg.Platform,
+	})
+	if err != nil {
+		return data.Poll{}, err
+	}
+	if poll.ClosedAt != nil {
+		return data.Poll{}, errPollClosed
+	}
+
+	fromDB, err := s.store.Query(ctx).CorePollClose(ctx, poll.ID)
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			// the results are being posted already
+			return data.Poll{}, errPollClosed
+		}
+		return data.Poll{}, err
+	}
+	poll.ClosedAt = fromDB.ClosedAt
+	s.unmirror(ctx, poll)
+
+	s.logger.InfoContext(ctx, "poll ended",
+		"userID", poll.UserID,
+		"pollID", poll.ID,
+		"endedBy", appctx.GetActor(ctx),
+	)
+
+	// the closing query could have counted a vote or two more
+	poll, err = s.tally(ctx, poll)
+	if err != nil {
+		return data.Poll{}, err
+	}
+	s.announce(ctx, poll, poll.Results())
+
+	return poll, nil
+}
+
+// List lists the polls of the user, newest first, with their votes.
+func (s *PollService) List(ctx context.Context, arg data.PollList) ([]data.Poll, error) {
+	limit := arg.Limit
+	if limit <= 0 {
+		limit = pollListDefaultLimit
+	}
+	limit = min(limit, pollListMaxLimit)
+
+	params := db.CorePollListParams{
+		UserID: arg.UserID,
+		Limit:  limit,
+	}
+	if arg.BeforeID > 0 {
+		params.BeforeID = &arg.BeforeID
+	}
+
+	fromDBs, err := s.store.Query(ctx).CorePollList(ctx, params)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	polls := make([]data.Poll, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		poll, err := s.tally(ctx, data.NewPollFromDB(fromDB))
+		if err != nil {
+			return nil, err
+		}
+		polls = append(polls, poll)
+	}
+
+	return polls, nil
+}
+
+// Observe votes for the chatter when the whole message is the number or the
+// name of an option of the open poll of the channel.
+func (s *PollService) Observe(ctx context.Context, message ChatMessage) {
+	event := message.Event
+
+	if message.Private || event.ChatterID == event.BotID {
+		return
+	}
+
+	entry, err := s.cache.Get(ctx, getPollKVKey(event.UserID, event.Platform))
+	if err != nil {
+		if !errors.Is(err, jetstream.ErrKeyNotFound) {
+			s.logger.ErrorContext(ctx, "cannot get poll from cache", "err", err)
+		}
+		return
+	}
+
+	var poll data.Poll
+	err = json.Unmarshal(entry.Value(), &poll)
+	if err != nil {
+		s.logger.WarnContext(ctx, "cannot decode poll", "err", err)
+		return
+	}
+
+	option, ok := pollOptionOf(poll, event.Message)
+	if !ok {
+		return
+	}
+
+	_, err = s.store.Query(ctx).CorePollVoteUpsert(ctx, db.CorePollVoteUpsertParams{
+		PollID:       poll.ID,
+		ChatterID:    event.ChatterID,
+		ChatterLogin: normalizeLogin(event.ChatterLogin),
+		Option:       int16(option),
+	})
+	if err != nil {
+		// closed
+		s.logger.DebugContext(ctx, "cannot vote in poll", "err", s.store.HandleErr(ctx, err), "chatterID", event.ChatterID)
+	}
+}
+
+// pollOptionOf returns the index of the option the message votes for.
+func pollOptionOf(poll data.Poll, message string) (int, bool) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(message)
+	if err == nil {
+		return n - 1, n >= 1 && n <= len(poll.Options)
+	}
+	for i, option := range poll.Options {
+		if strings.EqualFold(option.Name, message) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Run posts the standings of open polls as votes come in, and the results of
+// the ones that ran out of time, until ctx is done. Polls are claimed in the
+// database, so any number of replicas can run it.
+func (s *PollService) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.runDue(ctx)
+			if err != nil {
+				s.logger.ErrorContext(ctx, "cannot run due polls", "err", err)
+			}
+		}
+	}
+}
+
+func (s *PollService) runDue(ctx context.Context) error {
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	polls, err := s.store.Query(txCtx).CorePollClaimDue(txCtx, pollClaimLimit)
+	if err != nil {
+		return s.store.HandleErr(ctx, err)
+	}
+
+	type announcement struct {
+		poll    data.Poll
+		message string
+	}
+	var announcements []announcement
+
+	for _, fromDB := range polls {
+		poll, err := s.tally(txCtx, data.NewPollFromDB(fromDB))
+		if err != nil {
+			return err
+		}
+
+		if !poll.Open(time.Now()) {
+			closed, err := s.store.Query(txCtx).CorePollClose(txCtx, poll.ID)
+			if err != nil {
+				return s.store.HandleErr(ctx, err)
+			}
+			poll.ClosedAt = closed.ClosedAt
+			announcements = append(announcements, announcement{poll, poll.Results()})
+			continue
+		}
+
+		err = s.store.Query(txCtx).CorePollMarkUpdate(txCtx, db.CorePollMarkUpdateParams{
+			ID:           poll.ID,
+			Votes:        poll.Votes,
+			NextUpdateIn: pollUpdateInterval,
+		})
+		if err != nil {
+			return s.store.HandleErr(ctx, err)
+		}
+
+		// standings that did not move are not worth a line of chat
+		if poll.Votes != fromDB.VotesAtLastUpdate {
+			announcements = append(announcements, announcement{poll, poll.Standings()})
+		}
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return err
+	}
+
+	// sent only once the claim is committed, so no other replica sends it too
+	for _, a := range announcements {
+		if a.poll.ClosedAt != nil {
+			s.unmirror(ctx, a.poll)
+		}
+		s.announce(ctx, a.poll, a.message)
+	}
+
+	return nil
+}
+
+// tally adds the votes to the poll.
+func (s *PollService) tally(ctx context.Context, poll data.Poll) (data.Poll, error) {
+	fromDBs, err := s.store.Query(ctx).CorePollTally(ctx, poll.ID)
+	if err != nil {
+		return data.Poll{}, s.store.HandleErr(ctx, err)
+	}
+	poll.Tally(fromDBs)
+
+	return poll, nil
+}
+
+// announce posts to the chat of the channel. Until the channel had chat
+// since the cache was emptied there is nowhere to post, and the results are
+// left to be queried.
+func (s *PollService) announce(ctx context.Context, poll data.Poll, message string) {
+	_, err := s.channelService.Send(ctx, poll.UserID, poll.Platform, message)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot send poll message", "err", err, "pollID", poll.ID)
+	}
+}
+
+// mirror caches the open poll for Observe, until it ends.
+func (s *PollService) mirror(ctx context.Context, poll data.Poll) {
+	var ttl time.Duration
+	if poll.EndsAt != nil {
+		ttl = time.Until(*poll.EndsAt)
+	}
+
+	err := mirrorWithTTL(ctx, s.cache, getPollKVKey(poll.UserID, poll.Platform), poll, ttl)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot cache poll", "err", err, "pollID", poll.ID)
+	}
+}
+
+func (s *PollService) unmirror(ctx context.Context, poll data.Poll) {
+	err := s.cache.Purge(ctx, getPollKVKey(poll.UserID, poll.Platform))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot uncache poll", "err", err, "pollID", poll.ID)
+	}
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/expiring"
+	"github.com/arnokay/arnobot-core/internal/storage"
+)
+
+const (
+	// quotesTTL is the safety net for changes that were never notified, such
+	// as the ones made while no replica was listening.
+	quotesTTL = time.Minute
+
+	quotesChangedChannel = "core_quotes_changed"
+	quotesWatchBackoff   = 5 * time.Second
+
+	maxQuotesPerChannel    = 5000
+	maxQuoteLength         = 400
+	maxQuoteCategoryLength = 100
+)
+
+var errQuoteNotFound = apperror.New(apperror.CodeNotFound, "there is no such quote", nil)
+
+type QuoteService struct {
+	channelService *ChannelService
+	store          storage.Storager
+
+	// quotes of a channel, by number
+	quotes *expiring.Cache[uuid.UUID, []data.Quote]
+
+	logger applog.Logger
+}
+
+func NewQuoteService(
+	channelService *ChannelService,
+	store storage.Storager,
+) *QuoteService {
+	logger := applog.NewServiceLogger("quote-service")
+
+	return &QuoteService{
+		channelService: channelService,
+		store:          store,
+		quotes:         expiring.New[uuid.UUID, []data.Quote](quotesTTL),
+
+		logger: logger,
+	}
+}
+
+func (s *QuoteService) List(ctx context.Context, arg data.QuoteList) ([]data.Quote, error) {
+	fromDBs, err := s.store.Query(ctx).CoreQuoteGetByUserID(ctx, arg.UserID)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	quotes := make([]data.Quote, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		quotes = append(quotes, data.NewQuoteFromDB(fromDB))
+	}
+
+	return quotes, nil
+}
+
+// Get returns the quote of the given number or a random one, among the ones
+// whose text or category contain the search.
+func (s *QuoteService) Get(ctx context.Context, arg data.QuoteGet) (data.Quote, error) {
+	quotes, err := s.load(ctx, arg.UserID)
+	if err != nil {
+		return data.Quote{}, err
+	}
+
+	if arg.Number != 0 {
+		i, ok := slices.BinarySearchFunc(quotes, arg.Number, func(quote data.Quote, number int32) int {
+			return int(quote.Number - number)
+		})
+		if !ok {
+			return data.Quote{}, errQuoteNotFound
+		}
+		return quotes[i], nil
+	}
+
+	if search := strings.ToLower(strings.TrimSpace(arg.Search)); search != "" {
+		var found []data.Quote
+		for _, quote := range quotes {
+			if strings.Contains(strings.ToLower(quote.Text), search) || strings.Contains(strings.ToLower(quote.Category), search) {
+				found = append(found, quote)
+			}
+		}
+		quotes = found
+	}
+
+	if len(quotes) == 0 {
+		return data.Quote{}, errQuoteNotFound
+	}
+	return quotes[rand.IntN(len(quotes))], nil
+}
+
+func (s *QuoteService) Create(ctx context.Context, arg data.QuoteCreate) (data.Quote, error) {
+	arg.Text = strings.TrimSpace(arg.Text)
+
+	var category string
+	if arg.Category != nil {
+		category = strings.TrimSpace(*arg.Category)
+	} else if arg.BroadcasterID != "" {
+		category = s.channelService.StreamCategory(ctx, arg.Platform, arg.BroadcasterID)
+	}
+
+	errs := data.FieldErrors{}
+	if arg.Text == "" || len(arg.Text) > maxQuoteLength {
+		errs.Add("text", "must be between 1 and "+strconv.Itoa(maxQuoteLength)+" bytes")
+	}
+	if len(category) > maxQuoteCategoryLength {
+		errs.Add("category", "must be at most "+strconv.Itoa(maxQuoteCategoryLength)+" bytes")
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.Quote{}, err
+	}
+
+	count, err := s.store.Query(ctx).CoreQuoteCount(ctx, arg.UserID)
+	if err != nil {
+		return data.Quote{}, s.store.HandleErr(ctx, err)
+	}
+	if count >= maxQuotesPerChannel {
+		return data.Quote{}, apperror.New(apperror.CodeInvalidInput, "a channel can have at most "+strconv.Itoa(maxQuotesPerChannel)+" quotes", nil)
+	}
+
+	actor := appctx.GetActor(ctx)
+	fromDB, err := s.store.Query(ctx).CoreQuoteCreate(ctx, db.CoreQuoteCreateParams{
+		UserID:   arg.UserID,
+		Text:     arg.Text,
+		Category: category,
+		AddedBy:  normalizeLogin(actor.ChatterLogin),
+	})
+	if err != nil {
+		return data.Quote{}, s.store.HandleErr(ctx, err)
+	}
+	s.forget(arg.UserID)
+
+	s.logger.InfoContext(ctx, "quote added",
+		"userID", arg.UserID,
+		"number", fromDB.Number,
+		"addedBy", actor,
+	)
+
+	return data.NewQuoteFromDB(fromDB), nil
+}
+
+func (s *QuoteService) Update(ctx context.Context, arg data.QuoteUpdate) (data.Quote, error) {
+	errs := data.FieldErrors{}
+	if arg.Text != nil {
+		text := strings.TrimSpace(*arg.Text)
+		arg.Text = &text
+		if text == "" || len(text) > maxQuoteLength {
+			errs.Add("text", "must be between 1 and "+strconv.Itoa(maxQuoteLength)+" bytes")
+		}
+	}
+	if arg.Category != nil {
+		category := strings.TrimSpace(*arg.Category)
+		arg.Category = &category
+		if len(category) > maxQuoteCategoryLength {
+			errs.Add("category", "must be at most "+strconv.Itoa(maxQuoteCategoryLength)+" bytes")
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.Quote{}, err
+	}
+
+	fromDB, err := s.store.Query(ctx).CoreQuoteUpdate(ctx, db.CoreQuoteUpdateParams{
+		UserID:   arg.UserID,
+		Number:   arg.Number,
+		Text:     arg.Text,
+		Category: arg.Category,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.Quote{}, errQuoteNotFound
+		}
+		return data.Quote{}, err
+	}
+	s.forget(arg.UserID)
+
+	s.logger.InfoContext(ctx, "quote updated",
+		"userID", arg.UserID,
+		"number", arg.Number,
+		"updatedBy", appctx.GetActor(ctx),
+	)
+
+	return data.NewQuoteFromDB(fromDB), nil
+}
+
+func (s *QuoteService) Delete(ctx context.Context, arg data.QuoteDelete) (data.Quote, error) {
+	fromDB, err := s.store.Query(ctx).CoreQuoteDelete(ctx, db.CoreQuoteDeleteParams{
+		UserID: arg.UserID,
+		Number: arg.Number,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.Quote{}, errQuoteNotFound
+		}
+		return data.Quote{}, err
+	}
+	s.forget(arg.UserID)
+
+	s.logger.InfoContext(ctx, "quote deleted",
+		"userID", arg.UserID,
+		"number", arg.Number,
+		"deletedBy", appctx.GetActor(ctx),
+	)
+
+	return data.NewQuoteFromDB(fromDB), nil
+}
+
+// load returns the quotes of the channel, reading them from the database at
+// most once per quotesTTL.
+func (s *QuoteService) load(ctx context.Context, userID uuid.UUID) ([]data.Quote, error) {
+	if quotes, ok := s.quotes.Get(userID); ok {
+		return quotes, nil
+	}
+
+	quotes, err := s.List(ctx, data.QuoteList{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	s.quotes.Set(userID, quotes)
+
+	return quotes, nil
+}
+
+func (s *QuoteService) forget(userID uuid.UUID) {
+	s.quotes.Delete(userID)
+}
+
+// WatchChanges forgets the quotes of a channel as soon as they are changed by
+// any replica, or directly in the database, by listening to the
+// notifications of the quotes trigger. It blocks until ctx is done.
+func (s *QuoteService) WatchChanges(ctx context.Context, pool *pgxpool.Pool) {
+	for {
+		err := s.listenChanges(ctx, pool)
+		if ctx.Err() != nil {
+			return
+		}
+		s.logger.ErrorContext(ctx, "stopped listening to quote changes, retrying", "err", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(quotesWatchBackoff):
+		}
+	}
+}
+
+func (s *QuoteService) listenChanges(ctx context.Context, pool *pgxpool.Pool) error {
+	pooled, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// a listening connection must not go back to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+quotesChangedChannel)
+	if err != nil {
+		return err
+	}
+
+	// changes made while nobody was listening are unknown, start over
+	s.quotes.Clear()
+	s.logger.DebugContext(ctx, "listening to quote changes")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		userID, err := uuid.Parse(notification.Payload)
+		if err != nil {
+			s.logger.WarnContext(ctx, "cannot decode quote change", "err", err, "payload", notification.Payload)
+			continue
+		}
+
+		s.forget(userID)
+	}
+}
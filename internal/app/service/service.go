@@ -3,9 +3,27 @@ package service
 import "github.com/arnokay/arnobot-shared/service"
 
 type Services struct {
-	MessageService        *MessageService
-	PlatformModuleService *service.PlatformModuleIn
-	UserCommandService    *UserCommandService
-	CmdManagerService     *CmdManagerService
-	UserCmdManagerService *UserCmdManagerService
+	MessageService             *MessageService
+	PlatformModuleService      *service.PlatformModuleIn
+	UserCommandService         *UserCommandService
+	UserCommandTransferService *UserCommandTransferService
+	CmdManagerService          *CmdManagerService
+	UserCmdManagerService      *UserCmdManagerService
+	AuthorizationService       *AuthorizationService
+	ChannelService             *ChannelService
+	TimerService               *TimerService
+	TriggerService             *TriggerService
+	WhisperService             *WhisperService
+	ModerationService          *ModerationService
+	BannedPhraseService        *BannedPhraseService
+	PointsService              *PointsService
+	SlotService                *SlotService
+	FairService                *FairService
+	GiveawayService            *GiveawayService
+	PollService                *PollService
+	QuoteService               *QuoteService
+	InteractionService         *InteractionService
+	DuelService                *DuelService
+	TriviaService              *TriviaService
+	TransactionService         service.ITransactionService
 }
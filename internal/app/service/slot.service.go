@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/storage"
+)
+
+const (
+	slotReels = 3
+
+	minSlotSymbols      = 2
+	maxSlotSymbols      = 20
+	maxSlotSymbolWeight = 1000
+	maxSlotPayouts      = 30
+	maxSlotMultiplier   = 1000
+	maxSlotBet          = 1_000_000
+	maxSlotJackpotPct   = 50
+	maxSlotJackpotSeed  = 1_000_000_000
+	maxSlotSymbolLength = 32
+)
+
+type SlotService struct {
+	store         storage.Storager
+	tx            service.ITransactionService
+	pointsService *PointsService
+	fairService   *FairService
+
+	logger applog.Logger
+}
+
+func NewSlotService(
+	store storage.Storager,
+	tx service.ITransactionService,
+	pointsService *PointsService,
+	fairService *FairService,
+) *SlotService {
+	logger := applog.NewServiceLogger("slot-service")
+
+	return &SlotService{
+		store:         store,
+		tx:            tx,
+		pointsService: pointsService,
+		fairService:   fairService,
+
+		logger: logger,
+	}
+}
+
+// Get returns the slot machine of the channel with how much it pays back,
+// or the default machine when it was never configured.
+func (s *SlotService) Get(ctx context.Context, arg data.SlotMachineGet) (data.SlotReport, error) {
+	machine, err := s.get(ctx, arg.UserID)
+	if err != nil {
+		return data.SlotReport{}, err
+	}
+
+	return newSlotReport(machine), nil
+}
+
+func (s *SlotService) Update(ctx context.Context, arg data.SlotMachineUpdate) (data.SlotReport, error) {
+	errs := data.FieldErrors{}
+	if arg.Symbols != nil {
+		errs.Add("symbols", checkSlotSymbols(arg.Symbols))
+	}
+	if arg.MinBet != nil && (*arg.MinBet < 1 || *arg.MinBet > maxSlotBet) {
+		errs.Add("minBet", "must be between 1 and "+strconv.Itoa(maxSlotBet))
+	}
+	if arg.MaxBet != nil && (*arg.MaxBet < 1 || *arg.MaxBet > maxSlotBet) {
+		errs.Add("maxBet", "must be between 1 and "+strconv.Itoa(maxSlotBet))
+	}
+	if arg.Symbols != nil || arg.Payouts != nil || arg.MinBet != nil || arg.MaxBet != nil {
+		// payouts must only name symbols of the reels and the bets must stay
+		// in order, whichever of them changes
+		machine, err := s.get(ctx, arg.UserID)
+		if err != nil {
+			return data.SlotReport{}, err
+		}
+		if arg.Symbols != nil {
+			machine.Symbols = arg.Symbols
+		}
+		if arg.Payouts != nil {
+			machine.Payouts = arg.Payouts
+		}
+		if arg.MinBet != nil {
+			machine.MinBet = *arg.MinBet
+		}
+		if arg.MaxBet != nil {
+			machine.MaxBet = *arg.MaxBet
+		}
+
+		errs.Add("payouts", checkSlotPayouts(machine.Payouts, machine.Symbols))
+		if machine.MinBet > machine.MaxBet {
+			errs.Add("maxBet", "must not be below minBet")
+		}
+	}
+	if arg.JackpotPercent != nil && (*arg.JackpotPercent < 0 || *arg.JackpotPercent > maxSlotJackpotPct) {
+		errs.Add("jackpotPercent", "must be between 0 and "+strconv.Itoa(maxSlotJackpotPct))
+	}
+	if arg.JackpotSeed != nil && (*arg.JackpotSeed < 0 || *arg.JackpotSeed > maxSlotJackpotSeed) {
+		errs.Add("jackpotSeed", "must be between 0 and "+strconv.Itoa(maxSlotJackpotSeed))
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.SlotReport{}, err
+	}
+
+	params := db.CoreSlotMachineUpsertParams{
+		UserID:         arg.UserID,
+		MinBet:         arg.MinBet,
+		MaxBet:         arg.MaxBet,
+		JackpotPercent: arg.JackpotPercent,
+		JackpotSeed:    arg.JackpotSeed,
+	}
+	if arg.Symbols != nil {
+		params.Symbols, _ = json.Marshal(arg.Symbols)
+	}
+	if arg.Payouts != nil {
+		params.Payouts, _ = json.Marshal(arg.Payouts)
+	}
+
+	fromDB, err := s.store.Query(ctx).CoreSlotMachineUpsert(ctx, params)
+	if err != nil {
+		return data.SlotReport{}, s.store.HandleErr(ctx, err)
+	}
+
+	return newSlotReport(data.NewSlotMachineFromDB(fromDB)), nil
+}
+
+func checkSlotSymbols(symbols []data.SlotSymbol) string {
+	if len(symbols) < minSlotSymbols || len(symbols) > maxSlotSymbols {
+		return "must have between " + strconv.Itoa(minSlotSymbols) + " and " + strconv.Itoa(maxSlotSymbols) + " symbols"
+	}
+	seen := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		switch {
+		case symbol.Emoji == "" || len(symbol.Emoji) > maxSlotSymbolLength:
+			return "symbols must be between 1 and " + strconv.Itoa(maxSlotSymbolLength) + " bytes long"
+		case seen[symbol.Emoji]:
+			return "duplicate symbol " + symbol.Emoji
+		case symbol.Weight < 1 || symbol.Weight > maxSlotSymbolWeight:
+			return "weights must be between 1 and " + strconv.Itoa(maxSlotSymbolWeight)
+		}
+		seen[symbol.Emoji] = true
+	}
+	return ""
+}
+
+func checkSlotPayouts(payouts []data.SlotPayout, symbols []data.SlotSymbol) string {
+	if len(payouts) > maxSlotPayouts {
+		return "must have at most " + strconv.Itoa(maxSlotPayouts) + " payouts"
+	}
+	// no payouts means the default ones, which only name the default symbols
+	if len(payouts) == 0 && !slices.Equal(symbols, data.NewSlotMachine(uuid.Nil).Symbols) {
+		return "must not be empty with custom symbols"
+	}
+	for _, payout := range payouts {
+		if slotSymbolIndex(symbols, payout.Symbol) < 0 {
+			return "unknown symbol " + payout.Symbol
+		}
+		if payout.Count < 1 || payout.Count > slotReels {
+			return "counts must be between 1 and " + strconv.Itoa(slotReels)
+		}
+		if payout.Multiplier < 0 || payout.Multiplier > maxSlotMultiplier {
+			return "multipliers must be between 0 and " + strconv.Itoa(maxSlotMultiplier)
+		}
+	}
+	return ""
+}
+
+// Spin spins the slot machine of the channel. With a bet, the bet is taken
+// from the chatter, the win paid to them and the jackpot updated all in one
+// transaction; without one it only shows the reels. A message delivered again
+// after its bet was taken is not spun again and gets apperror.ErrNoAction.
+func (s *SlotService) Spin(ctx context.Context, arg data.SlotSpin) (data.SlotSpinResult, error) {
+	if arg.Bet == 0 {
+		machine, err := s.get(ctx, arg.UserID)
+		if err != nil {
+			return data.SlotSpinResult{}, err
+		}
+		result := data.SlotSpinResult{Pool: machine.Jackpot}
+		result.Reels, result.RollID, err = s.roll(ctx, arg, machine.Symbols)
+		if err != nil {
+			return data.SlotSpinResult{}, err
+		}
+		return result, nil
+	}
+
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return data.SlotSpinResult{}, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	fromDB, err := s.store.Query(txCtx).CoreSlotMachineLock(txCtx, arg.UserID)
+	if err != nil {
+		return data.SlotSpinResult{}, s.store.HandleErr(txCtx, err)
+	}
+	machine := data.NewSlotMachineFromDB(fromDB)
+
+	if arg.Bet < int64(machine.MinBet) || arg.Bet > int64(machine.MaxBet) {
+		return data.SlotSpinResult{}, apperror.New(
+			apperror.CodeInvalidInput,
+			"bet must be between "+strconv.Itoa(int(machine.MinBet))+" and "+strconv.Itoa(int(machine.MaxBet)),
+			nil,
+		)
+	}
+
+	key := PointsIdempotencyKey(data.PointsReasonGamba, arg.Platform, arg.MessageID)
+	account, applied, err := s.pointsService.applyOnce(txCtx, arg.PointsChatter, -arg.Bet, data.PointsReasonGamba, key+":bet")
+	if err != nil {
+		return data.SlotSpinResult{}, err
+	}
+	if !applied {
+		// the first delivery rolled, paid and recorded the spin already
+		return data.SlotSpinResult{}, apperror.ErrNoAction
+	}
+
+	var result data.SlotSpinResult
+	result.Reels, result.RollID, err = s.roll(txCtx, arg, machine.Symbols)
+	if err != nil {
+		return data.SlotSpinResult{}, err
+	}
+	pool := machine.Jackpot + arg.Bet*int64(machine.JackpotPercent)/100
+	if payout, ok := bestSlotPayout(machine.Payouts, result.Reels); ok {
+		result.Won = arg.Bet * int64(payout.Multiplier)
+		if payout.Jackpot {
+			result.Won += pool
+			result.Jackpot = true
+			pool = machine.JackpotSeed
+		}
+	}
+	result.Pool = pool
+
+	if result.Won > 0 {
+		account, err = s.pointsService.apply(txCtx, arg.PointsChatter, result.Won, data.PointsReasonGamba, key+":win")
+		if err != nil {
+			return data.SlotSpinResult{}, err
+		}
+	}
+	result.Balance = account.Balance
+
+	err = s.store.Query(txCtx).CoreSlotMachineRecordSpin(txCtx, db.CoreSlotMachineRecordSpinParams{
+		UserID:  arg.UserID,
+		Jackpot: pool,
+		Wagered: arg.Bet,
+		Paid:    result.Won,
+	})
+	if err != nil {
+		return data.SlotSpinResult{}, s.store.HandleErr(txCtx, err)
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return data.SlotSpinResult{}, err
+	}
+
+	if result.Jackpot {
+		s.logger.InfoContext(ctx, "slot jackpot won",
+			"userID", arg.UserID,
+			"platform", arg.Platform,
+			"chatterID", arg.ChatterID,
+			"won", result.Won,
+		)
+	}
+
+	return result, nil
+}
+
+func (s *SlotService) get(ctx context.Context, userID uuid.UUID) (data.SlotMachine, error) {
+	fromDB, err := s.store.Query(ctx).CoreSlotMachineGet(ctx, userID)
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.NewSlotMachine(userID), nil
+		}
+		return data.SlotMachine{}, err
+	}
+
+	return data.NewSlotMachineFromDB(fromDB), nil
+}
+
+// roll picks the symbol of every reel in proportion to the weights,
+// returning the ID of the roll when it is provably fair.
+func (s *SlotService) roll(ctx context.Context, arg data.SlotSpin, symbols []data.SlotSymbol) ([]string, int64, error) {
+	var total int
+	for _, symbol := range symbols {
+		total += int(symbol.Weight)
+	}
+
+	reels := make([]string, slotReels)
+	roll, err := s.fairService.Roll(ctx, data.FairRollCreate{
+		UserID:       arg.UserID,
+		Game:         data.FairGameGamba,
+		Platform:     arg.Platform,
+		ChatterID:    arg.ChatterID,
+		ChatterLogin: arg.ChatterLogin,
+		ClientSeed:   arg.MessageID,
+	}, func(intN func(n int) int) string {
+		for i := range reels {
+			n := intN(total)
+			for _, symbol := range symbols {
+				n -= int(symbol.Weight)
+				if n < 0 {
+					reels[i] = symbol.Emoji
+					break
+				}
+			}
+		}
+		return strings.Join(reels, "")
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reels, roll.ID, nil
+}
+
+// bestSlotPayout returns the payout the reels win, preferring the jackpot
+// and then the highest multiplier.
+func bestSlotPayout(payouts []data.SlotPayout, reels []string) (data.SlotPayout, bool) {
+	var best data.SlotPayout
+	var found bool
+	for _, payout := range payouts {
+		var count int32
+		for _, reel := range reels {
+			if reel == payout.Symbol {
+				count++
+			}
+		}
+		if count < payout.Count {
+			continue
+		}
+		if !found || (payout.Jackpot && !best.Jackpot) ||
+			(payout.Jackpot == best.Jackpot && payout.Multiplier > best.Multiplier) {
+			best, found = payout, true
+		}
+	}
+	return best, found
+}
+
+func slotSymbolIndex(symbols []data.SlotSymbol, emoji string) int {
+	for i, symbol := range symbols {
+		if symbol.Emoji == emoji {
+			return i
+		}
+	}
+	return -1
+}
+
+// newSlotReport works out the theoretical return of the machine by going
+// through every combination of the reels.
+func newSlotReport(machine data.SlotMachine) data.SlotReport {
+	report := data.SlotReport{SlotMachine: machine}
+
+	var total float64
+	for _, symbol := range machine.Symbols {
+		total += float64(symbol.Weight)
+	}
+
+	reels := make([]string, slotReels)
+	var walk func(reel int, chance float64)
+	walk = func(reel int, chance float64) {
+		if reel == slotReels {
+			if payout, ok := bestSlotPayout(machine.Payouts, reels); ok {
+				report.TheoreticalReturn += chance * float64(payout.Multiplier)
+			}
+			return
+		}
+		for _, symbol := range machine.Symbols {
+			reels[reel] = symbol.Emoji
+			walk(reel+1, chance*float64(symbol.Weight)/total)
+		}
+	}
+	walk(0, 1)
+	report.TheoreticalReturn += float64(machine.JackpotPercent) / 100
+
+	if machine.Wagered > 0 {
+		report.ActualReturn = float64(machine.Paid) / float64(machine.Wagered)
+	}
+
+	return report
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+func TestNewSlotReport(t *testing.T) {
+	even := []data.SlotSymbol{{Emoji: "a", Weight: 1}, {Emoji: "b", Weight: 1}}
+
+	tests := []struct {
+		name    string
+		machine data.SlotMachine
+		want    float64
+	}{
+		{
+			name: "three of a kind",
+			machine: data.SlotMachine{
+				Symbols: even,
+				Payouts: []data.SlotPayout{{Symbol: "a", Count: 3, Multiplier: 8}},
+			},
+			want: 1,
+		},
+		{
+			name: "only the best payout",
+			machine: data.SlotMachine{
+				Symbols: even,
+				Payouts: []data.SlotPayout{
+					{Symbol: "a", Count: 2, Multiplier: 2},
+					{Symbol: "a", Count: 3, Multiplier: 8},
+				},
+			},
+			// aaa pays 8 in 1 of 8 spins, two a pay 2 in 3 of 8
+			want: 1.75,
+		},
+		{
+			name: "weights",
+			machine: data.SlotMachine{
+				Symbols: []data.SlotSymbol{{Emoji: "a", Weight: 3}, {Emoji: "b", Weight: 1}},
+				Payouts: []data.SlotPayout{{Symbol: "a", Count: 3, Multiplier: 1}},
+			},
+			want: 27.0 / 64,
+		},
+		{
+			name: "jackpot contributions",
+			machine: data.SlotMachine{
+				Symbols:        even,
+				Payouts:        []data.SlotPayout{{Symbol: "b", Count: 3, Multiplier: 0, Jackpot: true}},
+				JackpotPercent: 5,
+			},
+			want: 0.05,
+		},
+		{
+			name:    "no payouts",
+			machine: data.SlotMachine{Symbols: even},
+			want:    0,
+		},
+		{
+			name:    "default",
+			machine: data.NewSlotMachine(uuid.Nil),
+			want:    0.9234128943758574,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := newSlotReport(tt.machine)
+			if math.Abs(report.TheoreticalReturn-tt.want) > 1e-9 {
+				t.Errorf("TheoreticalReturn = %v, want %v", report.TheoreticalReturn, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSlotReportActualReturn(t *testing.T) {
+	machine := data.NewSlotMachine(uuid.Nil)
+	if got := newSlotReport(machine).ActualReturn; got != 0 {
+		t.Errorf("ActualReturn = %v before any bet, want 0", got)
+	}
+
+	machine.Wagered, machine.Paid = 200, 50
+	if got := newSlotReport(machine).ActualReturn; got != 0.25 {
+		t.Errorf("ActualReturn = %v, want 0.25", got)
+	}
+}
+
+func TestBestSlotPayout(t *testing.T) {
+	payouts := []data.SlotPayout{
+		{Symbol: "a", Count: 2, Multiplier: 2},
+		{Symbol: "a", Count: 3, Multiplier: 8},
+		{Symbol: "j", Count: 3, Multiplier: 1, Jackpot: true},
+		{Symbol: "j", Count: 2, Multiplier: 50},
+	}
+
+	tests := []struct {
+		reels []string
+		want  data.SlotPayout
+		ok    bool
+	}{
+		{[]string{"a", "b", "a"}, payouts[0], true},
+		{[]string{"a", "a", "a"}, payouts[1], true},
+		{[]string{"j", "j", "j"}, payouts[2], true},
+		{[]string{"j", "j", "a"}, payouts[3], true},
+		{[]string{"a", "b", "c"}, data.SlotPayout{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := bestSlotPayout(payouts, tt.reels)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("bestSlotPayout(%q) = %+v, %v, want %+v, %v", tt.reels, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCheckSlotPayouts(t *testing.T) {
+	defaults := data.NewSlotMachine(uuid.Nil)
+	custom := []data.SlotSymbol{{Emoji: "a", Weight: 1}, {Emoji: "b", Weight: 1}}
+
+	tests := []struct {
+		name    string
+		payouts []data.SlotPayout
+		symbols []data.SlotSymbol
+		ok      bool
+	}{
+		{"defaults", defaults.Payouts, defaults.Symbols, true},
+		{"empty with default symbols", []data.SlotPayout{}, defaults.Symbols, true},
+		{"empty with custom symbols", []data.SlotPayout{}, custom, false},
+		{"custom", []data.SlotPayout{{Symbol: "a", Count: 3, Multiplier: 5}}, custom, true},
+		{"unknown symbol", []data.SlotPayout{{Symbol: "c", Count: 3, Multiplier: 5}}, custom, false},
+		{"count too high", []data.SlotPayout{{Symbol: "a", Count: 4, Multiplier: 5}}, custom, false},
+		{"negative multiplier", []data.SlotPayout{{Symbol: "a", Count: 3, Multiplier: -1}}, custom, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkSlotPayouts(tt.payouts, tt.symbols); (got == "") != tt.ok {
+				t.Errorf("checkSlotPayouts = %q, want ok %v", got, tt.ok)
+			}
+		})
+	}
+}
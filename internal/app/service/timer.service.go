@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/events"
+	"github.com/arnokay/arnobot-shared/service"
+
+	"github.com/arnokay/arnobot-core/internal/cmdvalidate"
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/storage"
+)
+
+const (
+	timerTick       = 10 * time.Second
+	timerClaimLimit = 50
+
+	// timer intervals are in seconds
+	timerMinInterval = 60
+	timerMaxInterval = 24 * 60 * 60
+	// timerRecheck is how soon a timer held back by its gates is checked again.
+	timerRecheck = 60
+
+	timerMaxMinLines = 1000
+)
+
+type TimerService struct {
+	channelService        *ChannelService
+	store                 storage.Storager
+	tx                    service.ITransactionService
+	platformModuleService *service.PlatformModuleIn
+
+	logger applog.Logger
+}
+
+func NewTimerService(
+	channelService *ChannelService,
+	store storage.Storager,
+	tx service.ITransactionService,
+	platformModuleService *service.PlatformModuleIn,
+) *TimerService {
+	logger := applog.NewServiceLogger("timer-service")
+
+	return &TimerService{
+		channelService:        channelService,
+		store:                 store,
+		tx:                    tx,
+		platformModuleService: platformModuleService,
+
+		logger: logger,
+	}
+}
+
+func (s *TimerService) List(ctx context.Context, arg data.TimerList) ([]data.Timer, error) {
+	fromDBs, err := s.store.Query(ctx).CoreTimerGetByUserID(ctx, arg.UserID)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	timers := make([]data.Timer, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		timers = append(timers, data.NewTimerFromDB(fromDB))
+	}
+
+	return timers, nil
+}
+
+func (s *TimerService) Create(ctx context.Context, arg data.TimerCreate) (data.Timer, error) {
+	arg.Name = strings.TrimSpace(arg.Name)
+	arg.Text = strings.TrimSpace(arg.Text)
+
+	errs := data.FieldErrors{}
+	if !arg.Platform.IsEnum() {
+		errs.Add("platform", "unknown platform")
+	}
+	errs.Add("name", cmdvalidate.Identifier(arg.Name))
+	errs.Add("text", cmdvalidate.Text(arg.Text))
+	errs.Add("interval", checkTimerInterval(arg.Interval))
+
+	var minLines int32
+	if arg.MinLines != nil {
+		minLines = *arg.MinLines
+		errs.Add("minLines", checkTimerMinLines(minLines))
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.Timer{}, err
+	}
+
+	fromDB, err := s.store.Query(ctx).CoreTimerCreate(ctx, db.CoreTimerCreateParams{
+		UserID:     arg.UserID,
+		Platform:   arg.Platform,
+		Name:       arg.Name,
+		Text:       arg.Text,
+		Interval:   arg.Interval,
+		MinLines:   minLines,
+		OnlineOnly: arg.OnlineOnly == nil || *arg.OnlineOnly,
+		Enabled:    arg.Enabled == nil || *arg.Enabled,
+	})
+	if err != nil {
+		return data.Timer{}, s.store.HandleErr(ctx, err)
+	}
+
+	return data.NewTimerFromDB(fromDB), nil
+}
+
+func (s *TimerService) Update(ctx context.Context, arg data.TimerUpdate) (data.Timer, error) {
+	arg.Name = strings.TrimSpace(arg.Name)
+
+	errs := data.FieldErrors{}
+	if arg.NewName != nil {
+		newName := strings.TrimSpace(*arg.NewName)
+		arg.NewName = &newName
+		errs.Add("newName", cmdvalidate.Identifier(newName))
+	}
+	if arg.Text != nil {
+		text := strings.TrimSpace(*arg.Text)
+		arg.Text = &text
+		errs.Add("text", cmdvalidate.Text(text))
+	}
+	if arg.Interval != nil {
+		errs.Add("interval", checkTimerInterval(*arg.Interval))
+	}
+	if arg.MinLines != nil {
+		errs.Add("minLines", checkTimerMinLines(*arg.MinLines))
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.Timer{}, err
+	}
+
+	fromDB, err := s.store.Query(ctx).CoreTimerUpdate(ctx, db.CoreTimerUpdateParams{
+		UserID:     arg.UserID,
+		Platform:   arg.Platform,
+		Name:       arg.Name,
+		NewName:    arg.NewName,
+		Text:       arg.Text,
+		Interval:   arg.Interval,
+		MinLines:   arg.MinLines,
+		OnlineOnly: arg.OnlineOnly,
+		Enabled:    arg.Enabled,
+	})
+	if err != nil {
+		return data.Timer{}, s.store.HandleErr(ctx, err)
+	}
+
+	return data.NewTimerFromDB(fromDB), nil
+}
+
+func (s *TimerService) Delete(ctx context.Context, arg data.TimerDelete) (data.Timer, error) {
+	fromDB, err := s.store.Query(ctx).CoreTimerDelete(ctx, db.CoreTimerDeleteParams{
+		UserID:   arg.UserID,
+		Platform: arg.Platform,
+		Name:     strings.TrimSpace(arg.Name),
+	})
+	if err != nil {
+		return data.Timer{}, s.store.HandleErr(ctx, err)
+	}
+
+	return data.NewTimerFromDB(fromDB), nil
+}
+
+func checkTimerInterval(seconds int32) string {
+	if seconds < timerMinInterval || seconds > timerMaxInterval {
+		return "must be between 1 minute and 24 hours"
+	}
+	return ""
+}
+
+func checkTimerMinLines(lines int32) string {
+	if lines < 0 || lines > timerMaxMinLines {
+		return "must be between 0 and 1000"
+	}
+	return ""
+}
+
+// Run posts due timers until ctx is done. Every replica runs it; a due timer
+// is claimed by exactly one of them through a row lock.
+func (s *TimerService) Run(ctx context.Context) {
+	ticker := time.NewTicker(timerTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.runDue(ctx)
+			if err != nil {
+				s.logger.ErrorContext(ctx, "cannot run due timers", "err", err)
+			}
+		}
+	}
+}
+
+func (s *TimerService) runDue(ctx context.Context) error {
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	timers, err := s.store.Query(txCtx).CoreTimerClaimDue(txCtx, timerClaimLimit)
+	if err != nil {
+		return s.store.HandleErr(ctx, err)
+	}
+
+	var messages []events.MessageSend
+
+	for _, fromDB := range timers {
+		timer := data.NewTimerFromDB(fromDB)
+		channel, ok, err := s.channelService.get(ctx, timer.UserID, timer.Platform)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "cannot get timer channel", "err", err, "timerID", timer.ID)
+		}
+
+		lines := channel.Lines - fromDB.LinesAtLastRun
+		if lines < 0 {
+			// the line counter expired from the cache and started over
+			lines = channel.Lines
+		}
+
+		post := ok && lines >= int64(timer.MinLines) && (!timer.OnlineOnly || s.channelService.IsOnline(ctx, channel.Target.Platform, channel.Target.BroadcasterID))
+
+		nextRunIn := timer.Interval
+		if !post {
+			nextRunIn = min(timer.Interval, timerRecheck)
+		}
+
+		err = s.store.Query(txCtx).CoreTimerMarkRun(txCtx, db.CoreTimerMarkRunParams{
+			ID:        timer.ID,
+			Posted:    post,
+			Lines:     channel.Lines,
+			NextRunIn: nextRunIn,
+		})
+		if err != nil {
+			return s.store.HandleErr(ctx, err)
+		}
+
+		if post {
+			messages = append(messages, events.MessageSend{
+				EventCommon: channel.Target,
+				Message:     timer.Text,
+			})
+		}
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return err
+	}
+
+	// sent only once the claim is committed, so no other replica sends it too
+	for _, message := range messages {
+		err = s.platformModuleService.ChatSendMessage(ctx, message)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "cannot send timer message", "err", err, "broadcasterID", message.BroadcasterID)
+		}
+	}
+
+	return nil
+}
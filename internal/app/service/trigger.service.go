@@ -0,0 +1,472 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/events"
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/cmdvalidate"
+	"github.com/arnokay/arnobot-core/internal/cmdvars"
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/expiring"
+	"github.com/arnokay/arnobot-core/internal/storage"
+)
+
+const (
+	// triggerSetTTL is the safety net for changes that were never notified,
+	// such as the ones made while no replica was listening.
+	triggerSetTTL = time.Minute
+
+	triggersChangedChannel = "core_triggers_changed"
+	triggersWatchBackoff   = 5 * time.Second
+
+	maxTriggersPerChannel  = 50
+	triggerDefaultCooldown = 10
+)
+
+type TriggerService struct {
+	cache jetstream.KeyValue
+	store storage.Storager
+	tx    service.ITransactionService
+
+	// sets holds the compiled triggers of a channel
+	sets *expiring.Cache[uuid.UUID, []compiledTrigger]
+
+	logger applog.Logger
+}
+
+func NewTriggerService(
+	cache jetstream.KeyValue,
+	store storage.Storager,
+	tx service.ITransactionService,
+) *TriggerService {
+	logger := applog.NewServiceLogger("trigger-service")
+
+	return &TriggerService{
+		cache: cache,
+		store: store,
+		tx:    tx,
+		sets:  expiring.New[uuid.UUID, []compiledTrigger](triggerSetTTL),
+
+		logger: logger,
+	}
+}
+
+type compiledTrigger struct {
+	trigger data.Trigger
+	// phrase is the lowercased pattern of a phrase trigger
+	phrase string
+	re     *regexp.Regexp
+}
+
+func getTriggerCooldownKVKey(platform platform.Platform, broadcasterID string, trigger data.Trigger) string {
+	return "trg." + platform.String() + "." + broadcasterID + "." + strconv.Itoa(int(trigger.ID))
+}
+
+func (s *TriggerService) List(ctx context.Context, arg data.TriggerList) ([]data.Trigger, error) {
+	fromDBs, err := s.store.Query(ctx).CoreTriggerGetByUserID(ctx, arg.UserID)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	triggers := make([]data.Trigger, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		triggers = append(triggers, data.NewTriggerFromDB(fromDB))
+	}
+
+	return triggers, nil
+}
+
+func (s *TriggerService) Create(ctx context.Context, arg data.TriggerCreate) (data.Trigger, error) {
+	arg.Name = strings.TrimSpace(arg.Name)
+	arg.Text = strings.TrimSpace(arg.Text)
+	arg.Pattern = strings.TrimSpace(arg.Pattern)
+
+	errs := data.FieldErrors{}
+	errs.Add("name", cmdvalidate.Identifier(arg.Name))
+	errs.Add("text", cmdvalidate.Text(arg.Text))
+	if !arg.Kind.IsEnum() {
+		errs.Add("kind", "must be phrase or regex")
+	} else {
+		errs.Add("pattern", checkTriggerPattern(arg.Kind, arg.Pattern))
+	}
+
+	cooldown := int32(triggerDefaultCooldown)
+	if arg.Cooldown != nil {
+		cooldown = *arg.Cooldown
+		errs.Add("cooldown", cmdvalidate.Cooldown(cooldown))
+	}
+	userLevel := data.UserLevelEveryone
+	if arg.UserLevel != nil {
+		userLevel = *arg.UserLevel
+		if !userLevel.IsEnum() {
+			errs.Add("userLevel", "unknown user level")
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.Trigger{}, err
+	}
+
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return data.Trigger{}, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	// without the lock, concurrent creates could all count one below the limit
+	err = s.store.Query(txCtx).CoreTriggerLock(txCtx, arg.UserID)
+	if err != nil {
+		return data.Trigger{}, s.store.HandleErr(ctx, err)
+	}
+
+	count, err := s.store.Query(txCtx).CoreTriggerCount(txCtx, arg.UserID)
+	if err != nil {
+		return data.Trigger{}, s.store.HandleErr(ctx, err)
+	}
+	if count >= maxTriggersPerChannel {
+		return data.Trigger{}, apperror.New(apperror.CodeInvalidInput, "a channel can have at most "+strconv.Itoa(maxTriggersPerChannel)+" triggers", nil)
+	}
+
+	fromDB, err := s.store.Query(txCtx).CoreTriggerCreate(txCtx, db.CoreTriggerCreateParams{
+		UserID:    arg.UserID,
+		Name:      arg.Name,
+		Kind:      arg.Kind.String(),
+		Pattern:   arg.Pattern,
+		Text:      arg.Text,
+		Cooldown:  cooldown,
+		UserLevel: userLevel.String(),
+		Enabled:   arg.Enabled == nil || *arg.Enabled,
+	})
+	if err != nil {
+		return data.Trigger{}, s.store.HandleErr(ctx, err)
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return data.Trigger{}, err
+	}
+	s.forget(arg.UserID)
+
+	return data.NewTriggerFromDB(fromDB), nil
+}
+
+func (s *TriggerService) Update(ctx context.Context, arg data.TriggerUpdate) (data.Trigger, error) {
+	arg.Name = strings.TrimSpace(arg.Name)
+
+	errs := data.FieldErrors{}
+	if arg.NewName != nil {
+		newName := strings.TrimSpace(*arg.NewName)
+		arg.NewName = &newName
+		errs.Add("newName", cmdvalidate.Identifier(newName))
+	}
+	if arg.Text != nil {
+		text := strings.TrimSpace(*arg.Text)
+		arg.Text = &text
+		errs.Add("text", cmdvalidate.Text(text))
+	}
+	if arg.Cooldown != nil {
+		errs.Add("cooldown", cmdvalidate.Cooldown(*arg.Cooldown))
+	}
+	if arg.UserLevel != nil && !arg.UserLevel.IsEnum() {
+		errs.Add("userLevel", "unknown user level")
+	}
+	if arg.Kind != nil && !arg.Kind.IsEnum() {
+		errs.Add("kind", "must be phrase or regex")
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.Trigger{}, err
+	}
+
+	// the pattern is only valid together with the kind, so the stored one
+	// fills in whichever of them is not changed
+	if arg.Kind != nil || arg.Pattern != nil {
+		current, err := s.getOne(ctx, arg.UserID, arg.Name)
+		if err != nil {
+			return data.Trigger{}, err
+		}
+		kind, pattern := current.Kind, current.Pattern
+		if arg.Kind != nil {
+			kind = *arg.Kind
+		}
+		if arg.Pattern != nil {
+			pattern = strings.TrimSpace(*arg.Pattern)
+			arg.Pattern = &pattern
+		}
+		errs.Add("pattern", checkTriggerPattern(kind, pattern))
+		if err := errs.Err(); err != nil {
+			return data.Trigger{}, err
+		}
+	}
+
+	fromDB, err := s.store.Query(ctx).CoreTriggerUpdate(ctx, db.CoreTriggerUpdateParams{
+		UserID:    arg.UserID,
+		Name:      arg.Name,
+		NewName:   arg.NewName,
+		Kind:      (*string)(arg.Kind),
+		Pattern:   arg.Pattern,
+		Text:      arg.Text,
+		Cooldown:  arg.Cooldown,
+		UserLevel: (*string)(arg.UserLevel),
+		Enabled:   arg.Enabled,
+	})
+	if err != nil {
+		return data.Trigger{}, s.store.HandleErr(ctx, err)
+	}
+	s.forget(arg.UserID)
+
+	return data.NewTriggerFromDB(fromDB), nil
+}
+
+func (s *TriggerService) Delete(ctx context.Context, arg data.TriggerDelete) (data.Trigger, error) {
+	fromDB, err := s.store.Query(ctx).CoreTriggerDelete(ctx, db.CoreTriggerDeleteParams{
+		UserID: arg.UserID,
+		Name:   strings.TrimSpace(arg.Name),
+	})
+	if err != nil {
+		return data.Trigger{}, s.store.HandleErr(ctx, err)
+	}
+	s.forget(arg.UserID)
+
+	return data.NewTriggerFromDB(fromDB), nil
+}
+
+func (s *TriggerService) getOne(ctx context.Context, userID uuid.UUID, name string) (data.Trigger, error) {
+	triggers, err := s.List(ctx, data.TriggerList{UserID: userID})
+	if err != nil {
+		return data.Trigger{}, err
+	}
+	for _, trigger := range triggers {
+		if trigger.Name == name {
+			return trigger, nil
+		}
+	}
+	return data.Trigger{}, apperror.ErrNotFound
+}
+
+func checkTriggerPattern(kind data.TriggerKind, pattern string) string {
+	if kind == data.TriggerKindRegex {
+		_, problem := cmdvalidate.Regexp(pattern)
+		return problem
+	}
+	return cmdvalidate.Phrase(pattern)
+}
+
+// Resolve matches the message against the enabled triggers of the channel, in
+// the order they were created, skipping the ones the chatter cannot use and
+// the ones in cooldown. The cooldown of the matching trigger starts here.
+func (s *TriggerService) Resolve(ctx context.Context, message ChatMessage) (ResolvedCommand, error) {
+	event := message.Event
+
+	// the bot must not answer itself, its responses may well match
+	if event.ChatterID == event.BotID {
+		return nil, nil
+	}
+
+	triggers, err := s.load(ctx, event.UserID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot load triggers", "err", err, "userID", event.UserID)
+		return nil, nil
+	}
+	if len(triggers) == 0 {
+		return nil, nil
+	}
+
+	text := event.Message
+	if runes := []rune(text); len(runes) > cmdvalidate.MaxTextLength {
+		text = string(runes[:cmdvalidate.MaxTextLength])
+	}
+	lower := strings.ToLower(text)
+
+	for _, compiled := range triggers {
+		trigger := compiled.trigger
+		if !trigger.Enabled || !trigger.UserLevel.Allows(event.ChatterRole) {
+			continue
+		}
+
+		var groups []string
+		if compiled.re == nil {
+			if !strings.Contains(lower, compiled.phrase) {
+				continue
+			}
+		} else {
+			groups = compiled.re.FindStringSubmatch(text)
+			if groups == nil {
+				continue
+			}
+		}
+
+		if !s.startCooldown(ctx, event, trigger) {
+			s.logger.DebugContext(ctx, "trigger in cooldown", "broadcasterID", event.BroadcasterID, "trigger", trigger.Name)
+			continue
+		}
+		return resolvedTrigger{service: s, trigger: trigger, message: message, groups: groups}, nil
+	}
+
+	return nil, nil
+}
+
+type resolvedTrigger struct {
+	service *TriggerService
+	trigger data.Trigger
+	message ChatMessage
+	groups  []string
+}
+
+func (r resolvedTrigger) Execute(ctx context.Context) (*events.MessageSend, error) {
+	return r.service.execute(ctx, r.message, r.trigger, r.groups)
+}
+
+// startCooldown starts the cooldown of trigger, reporting false when it is
+// still in the previous one. A cache error lets the trigger run.
+func (s *TriggerService) startCooldown(ctx context.Context, event events.Message, trigger data.Trigger) bool {
+	if trigger.Cooldown <= 0 {
+		return true
+	}
+
+	_, err := s.cache.Create(
+		ctx,
+		getTriggerCooldownKVKey(event.Platform, event.BroadcasterID, trigger),
+		[]byte{},
+		jetstream.KeyTTL(time.Second*time.Duration(trigger.Cooldown)),
+	)
+	if errors.Is(err, jetstream.ErrKeyExists) {
+		return false
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot cache trigger cooldown", "err", err, "trigger", trigger.Name)
+	}
+	return true
+}
+
+func (s *TriggerService) execute(ctx context.Context, message ChatMessage, trigger data.Trigger, groups []string) (*events.MessageSend, error) {
+	event := message.Event
+
+	if groups == nil {
+		groups = []string{}
+	}
+	text := cmdvars.Render(trigger.Text, cmdvars.Values{
+		User:    event.ChatterName,
+		Channel: event.BroadcasterName,
+		Args:    event.Message,
+		Groups:  groups,
+	})
+	if cmdvalidate.IsPlatformCommand(text) {
+		s.logger.WarnContext(ctx, "trigger rendered to a platform command, not sending", "trigger", trigger.Name)
+		return nil, apperror.ErrNoAction
+	}
+
+	var response events.MessageSend
+
+	response.BroadcasterID = event.BroadcasterID
+	response.BotID = event.BotID
+	response.Platform = event.Platform
+	response.UserID = event.UserID
+	response.Message = text
+	return &response, nil
+}
+
+// load returns the compiled triggers of the channel, reading them from the
+// database at most once per triggerSetTTL.
+func (s *TriggerService) load(ctx context.Context, userID uuid.UUID) ([]compiledTrigger, error) {
+	if set, ok := s.sets.Get(userID); ok {
+		return set, nil
+	}
+
+	triggers, err := s.List(ctx, data.TriggerList{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	var set []compiledTrigger
+	for _, trigger := range triggers {
+		compiled := compiledTrigger{trigger: trigger}
+		var problem string
+		if trigger.Kind == data.TriggerKindRegex {
+			compiled.re, problem = cmdvalidate.Regexp(trigger.Pattern)
+		} else {
+			compiled.phrase = strings.ToLower(trigger.Pattern)
+			problem = cmdvalidate.Phrase(trigger.Pattern)
+		}
+		if problem != "" {
+			s.logger.WarnContext(ctx, "skipping invalid trigger pattern", "trigger", trigger.Name, "problem", problem)
+			continue
+		}
+		set = append(set, compiled)
+	}
+
+	s.sets.Set(userID, set)
+
+	return set, nil
+}
+
+func (s *TriggerService) forget(userID uuid.UUID) {
+	s.sets.Delete(userID)
+}
+
+// WatchChanges forgets the triggers of a channel as soon as they are changed
+// by any replica, or directly in the database, by listening to the
+// notifications of the triggers trigger. It blocks until ctx is done.
+func (s *TriggerService) WatchChanges(ctx context.Context, pool *pgxpool.Pool) {
+	for {
+		err := s.listenChanges(ctx, pool)
+		if ctx.Err() != nil {
+			return
+		}
+		s.logger.ErrorContext(ctx, "stopped listening to trigger changes, retrying", "err", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(triggersWatchBackoff):
+		}
+	}
+}
+
+func (s *TriggerService) listenChanges(ctx context.Context, pool *pgxpool.Pool) error {
+	pooled, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// a listening connection must not go back to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+triggersChangedChannel)
+	if err != nil {
+		return err
+	}
+
+	// changes made while nobody was listening are unknown, start over
+	s.sets.Clear()
+	s.logger.DebugContext(ctx, "listening to trigger changes")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		userID, err := uuid.Parse(notification.Payload)
+		if err != nil {
+			s.logger.WarnContext(ctx, "cannot decode trigger change", "err", err, "payload", notification.Payload)
+			continue
+		}
+
+		s.forget(userID)
+	}
+}
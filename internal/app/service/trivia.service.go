@@ -0,0 +1,745 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/storage"
+	"github.com/arnokay/arnobot-core/internal/trivia"
+)
+
+const (
+	// triviaTick is short, as rounds are timed in seconds.
+	triviaTick       = 2 * time.Second
+	triviaClaimLimit = 50
+
+	// trivia times are in seconds
+	triviaStartIn           = 5
+	triviaBreak             = 5
+	triviaDefaultAnswerTime = 30
+	minTriviaAnswerTime     = 10
+	maxTriviaAnswerTime     = 120
+
+	triviaDefaultRounds = 5
+	maxTriviaRounds     = 50
+	triviaDefaultReward = 10
+	maxTriviaReward     = 10_000
+
+	maxTriviaQuestionsPerChannel = 5000
+	maxTriviaPackLength          = 1 << 20
+	maxTriviaCategoryLength      = 50
+	maxTriviaQuestionLength      = 300
+	maxTriviaAnswerLength        = 100
+	maxTriviaAnswers             = 10
+
+	triviaTopDefaultLimit = 5
+	triviaTopMaxLimit     = 100
+)
+
+var (
+	errTriviaNotFound = apperror.New(apperror.CodeNotFound, "there was no trivia yet", nil)
+	errTriviaRunning  = apperror.New(apperror.CodeAlreadyExists, "trivia is running already", nil)
+	errTriviaStopped  = apperror.New(apperror.CodeNoAction, "trivia is over already", nil)
+)
+
+type TriviaService struct {
+	cache          jetstream.KeyValue
+	store          storage.Storager
+	tx             service.ITransactionService
+	pointsService  *PointsService
+	channelService *ChannelService
+
+	logger applog.Logger
+}
+
+func NewTriviaService(
+	cache jetstream.KeyValue,
+	store storage.Storager,
+	tx service.ITransactionService,
+	pointsService *PointsService,
+	channelService *ChannelService,
+) *TriviaService {
+	logger := applog.NewServiceLogger("trivia-service")
+
+	return &TriviaService{
+		cache:          cache,
+		store:          store,
+		tx:             tx,
+		pointsService:  pointsService,
+		channelService: channelService,
+
+		logger: logger,
+	}
+}
+
+// triviaRound is the round being played in a channel, mirrored from the
+// database so chat messages are matched to its answers without a query each.
+type triviaRound struct {
+	SessionID int64           `json:"sessionId"`
+	Round     int32           `json:"round"`
+	Rounds    int32           `json:"rounds"`
+	Reward    int32           `json:"reward"`
+	Question  trivia.Question `json:"question"`
+}
+
+func getTriviaKVKey(userID uuid.UUID, platform platform.Platform) string {
+	return "trivia." + userID.String() + "." + platform.String()
+}
+
+// Start starts a session, asking its first question shortly after.
+func (s *TriviaService) Start(ctx context.Context, arg data.TriviaStart) (data.TriviaSession, error) {
+	arg.Category = strings.ToLower(strings.TrimSpace(arg.Category))
+	if arg.Rounds == 0 {
+		arg.Rounds = triviaDefaultRounds
+	}
+	if arg.AnswerTime == 0 {
+		arg.AnswerTime = triviaDefaultAnswerTime
+	}
+	enabled := s.pointsService.Enabled(ctx, arg.UserID)
+	reward := int32(0)
+	if enabled {
+		reward = triviaDefaultReward
+	}
+	if arg.Reward != nil {
+		reward = *arg.Reward
+	}
+
+	errs := data.FieldErrors{}
+	if !arg.Platform.IsEnum() {
+		errs.Add("platform", "unknown platform")
+	}
+	if arg.Rounds < 1 || arg.Rounds > maxTriviaRounds {
+		errs.Add("rounds", "must be between 1 and "+strconv.Itoa(maxTriviaRounds))
+	}
+	if arg.AnswerTime < minTriviaAnswerTime || arg.AnswerTime > maxTriviaAnswerTime {
+		errs.Add("answerTime", "must be between "+strconv.Itoa(minTriviaAnswerTime)+" and "+strconv.Itoa(maxTriviaAnswerTime)+" seconds")
+	}
+	if reward < 0 || reward > maxTriviaReward {
+		errs.Add("reward", "must be between 0 and "+strconv.Itoa(maxTriviaReward))
+	}
+	if reward > 0 && !enabled {
+		errs.Add("reward", "needs points to be enabled")
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.TriviaSession{}, err
+	}
+
+	pool, err := s.questions(ctx, arg.UserID)
+	if err != nil {
+		return data.TriviaSession{}, err
+	}
+	if arg.Category != "" {
+		var inCategory []trivia.Question
+		for _, question := range pool {
+			if question.Category == arg.Category {
+				inCategory = append(inCategory, question)
+			}
+		}
+		pool = inCategory
+	}
+	if len(pool) == 0 {
+		return data.TriviaSession{}, apperror.New(apperror.CodeNotFound, "there are no questions in "+arg.Category, nil)
+	}
+
+	rand.Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+	questions, _ := json.Marshal(pool[:min(int(arg.Rounds), len(pool))])
+
+	fromDB, err := s.store.Query(ctx).CoreTriviaSessionCreate(ctx, db.CoreTriviaSessionCreateParams{
+		UserID:     arg.UserID,
+		Platform:   arg.Platform,
+		Category:   arg.Category,
+		Questions:  questions,
+		AnswerTime: arg.AnswerTime,
+		Reward:     reward,
+		StartIn:    triviaStartIn,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrAlreadyExists) {
+			return data.TriviaSession{}, errTriviaRunning
+		}
+		return data.TriviaSession{}, err
+	}
+
+	session := data.NewTriviaSessionFromDB(fromDB)
+
+	s.logger.InfoContext(ctx, "trivia started",
+		"userID", session.UserID,
+		"sessionID", session.ID,
+		"category", session.Category,
+		"rounds", session.Rounds,
+		"startedBy", appctx.GetActor(ctx),
+	)
+
+	return session, nil
+}
+
+// Get returns the last session of the channel with its scores.
+func (s *TriviaService) Get(ctx context.Context, arg data.TriviaGet) (data.TriviaSession, error) {
+	fromDB, err := s.store.Query(ctx).CoreTriviaSessionGetLatest(ctx, db.CoreTriviaSessionGetLatestParams{
+		UserID:   arg.UserID,
+		Platform: arg.Platform,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.TriviaSession{}, errTriviaNotFound
+		}
+		return data.TriviaSession{}, err
+	}
+
+	return s.withScores(ctx, data.NewTriviaSessionFromDB(fromDB))
+}
+
+// Stop ends the running session of the channel and posts its scores to chat.
+func (s *TriviaService) Stop(ctx context.Context, arg data.TriviaStop) (data.TriviaSession, error) {
+	session, err := s.Get(ctx, data.TriviaGet{
+		UserID:   arg.UserID,
+		Platform: arg.Platform,
+	})
+	if err != nil {
+		return data.TriviaSession{}, err
+	}
+	if session.ClosedAt != nil {
+		return data.TriviaSession{}, errTriviaStopped
+	}
+
+	fromDB, err := s.store.Query(ctx).CoreTriviaSessionClose(ctx, session.ID)
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.TriviaSession{}, errTriviaStopped
+		}
+		return data.TriviaSession{}, err
+	}
+	s.unmirror(ctx, session)
+
+	s.logger.InfoContext(ctx, "trivia stopped",
+		"userID", session.UserID,
+		"sessionID", session.ID,
+		"stoppedBy", appctx.GetActor(ctx),
+	)
+
+	session, err = s.withScores(ctx, data.NewTriviaSessionFromDB(fromDB))
+	if err != nil {
+		return data.TriviaSession{}, err
+	}
+	s.announce(ctx, session, triviaResults(session))
+
+	return session, nil
+}
+
+// Top lists the chatters of the channel with the most correct answers.
+func (s *TriviaService) Top(ctx context.Context, arg data.TriviaTop) ([]data.TriviaScore, error) {
+	limit := arg.Limit
+	if limit <= 0 {
+		limit = triviaTopDefaultLimit
+	}
+	limit = min(limit, triviaTopMaxLimit)
+
+	fromDBs, err := s.store.Query(ctx).CoreTriviaScoreTop(ctx, db.CoreTriviaScoreTopParams{
+		UserID:   arg.UserID,
+		Platform: arg.Platform,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	scores := make([]data.TriviaScore, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		scores = append(scores, data.NewTriviaScoreFromDB(fromDB))
+	}
+
+	return scores, nil
+}
+
+// Import adds the questions of a pack to the channel, all or none of them.
+func (s *TriviaService) Import(ctx context.Context, arg data.TriviaImport) (data.TriviaImportResult, error) {
+	errs := data.FieldErrors{}
+	if !arg.Format.IsEnum() {
+		errs.Add("format", "must be json or csv")
+	}
+	if arg.Pack == "" || len(arg.Pack) > maxTriviaPackLength {
+		errs.Add("pack", "must be between 1 byte and 1 MiB")
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.TriviaImportResult{}, err
+	}
+
+	var (
+		questions []trivia.Question
+		err       error
+	)
+	switch arg.Format {
+	case data.TriviaPackFormatJSON:
+		questions, err = trivia.ParseJSON(strings.NewReader(arg.Pack))
+	case data.TriviaPackFormatCSV:
+		questions, err = trivia.ParseCSV(strings.NewReader(arg.Pack))
+	}
+	if err != nil {
+		return data.TriviaImportResult{}, apperror.New(apperror.CodeInvalidInput, "cannot read pack: "+err.Error(), err)
+	}
+
+	for i, question := range questions {
+		errs.Add("pack", checkTriviaQuestion(i, question))
+	}
+	if err := errs.Err(); err != nil {
+		return data.TriviaImportResult{}, err
+	}
+
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return data.TriviaImportResult{}, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	for _, question := range questions {
+		_, err = s.store.Query(txCtx).CoreTriviaQuestionUpsert(txCtx, db.CoreTriviaQuestionUpsertParams{
+			UserID:   arg.UserID,
+			Category: question.Category,
+			Question: question.Question,
+			Answers:  question.Answers,
+		})
+		if err != nil {
+			return data.TriviaImportResult{}, s.store.HandleErr(txCtx, err)
+		}
+	}
+
+	count, err := s.store.Query(txCtx).CoreTriviaQuestionCount(txCtx, arg.UserID)
+	if err != nil {
+		return data.TriviaImportResult{}, s.store.HandleErr(txCtx, err)
+	}
+	if count > maxTriviaQuestionsPerChannel {
+		return data.TriviaImportResult{}, apperror.New(apperror.CodeInvalidInput, "a channel can have at most "+strconv.Itoa(maxTriviaQuestionsPerChannel)+" questions", nil)
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return data.TriviaImportResult{}, err
+	}
+
+	s.logger.InfoContext(ctx, "trivia pack imported",
+		"userID", arg.UserID,
+		"questions", len(questions),
+		"importedBy", appctx.GetActor(ctx),
+	)
+
+	return data.TriviaImportResult{Imported: len(questions)}, nil
+}
+
+// checkTriviaQuestion names the question by its place in the pack, as that
+// is how its author finds it.
+func checkTriviaQuestion(i int, question trivia.Question) string {
+	prefix := "question " + strconv.Itoa(i+1) + ": "
+	switch {
+	case len(question.Category) > maxTriviaCategoryLength:
+		return prefix + "category must be at most " + strconv.Itoa(maxTriviaCategoryLength) + " bytes"
+	case len(question.Question) > maxTriviaQuestionLength:
+		return prefix + "question must be at most " + strconv.Itoa(maxTriviaQuestionLength) + " bytes"
+	case len(question.Answers) > maxTriviaAnswers:
+		return prefix + "must have at most " + strconv.Itoa(maxTriviaAnswers) + " answers"
+	}
+	for _, answer := range question.Answers {
+		if len(answer) > maxTriviaAnswerLength {
+			return prefix + "answers must be at most " + strconv.Itoa(maxTriviaAnswerLength) + " bytes"
+		}
+	}
+	return ""
+}
+
+// Categories lists the categories the channel can play, its own and the ones
+// of the default pack.
+func (s *TriviaService) Categories(ctx context.Context, arg data.TriviaCategoryList) ([]data.TriviaCategory, error) {
+	fromDBs, err := s.store.Query(ctx).CoreTriviaQuestionGetByUserID(ctx, arg.UserID)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	counts := make(map[string]*data.TriviaCategory)
+	count := func(name string) *data.TriviaCategory {
+		category, ok := counts[name]
+		if !ok {
+			category = &data.TriviaCategory{Name: name}
+			counts[name] = category
+		}
+		category.Questions++
+		return category
+	}
+	for _, question := range trivia.Default() {
+		count(question.Category).BuiltIn++
+	}
+	for _, fromDB := range fromDBs {
+		count(fromDB.Category)
+	}
+
+	categories := make([]data.TriviaCategory, 0, len(counts))
+	for _, category := range counts {
+		categories = append(categories, *category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+
+	return categories, nil
+}
+
+// DeleteCategory deletes the questions the channel imported in a category.
+func (s *TriviaService) DeleteCategory(ctx context.Context, arg data.TriviaCategoryDelete) (data.TriviaCategory, error) {
+	category := strings.ToLower(strings.TrimSpace(arg.Category))
+
+	deleted, err := s.store.Query(ctx).CoreTriviaQuestionDeleteByCategory(ctx, db.CoreTriviaQuestionDeleteByCategoryParams{
+		UserID:   arg.UserID,
+		Category: category,
+	})
+	if err != nil {
+		return data.TriviaCategory{}, s.store.HandleErr(ctx, err)
+	}
+	if deleted == 0 {
+		return data.TriviaCategory{}, apperror.New(apperror.CodeNotFound, "there are no imported questions in "+category, nil)
+	}
+
+	s.logger.InfoContext(ctx, "trivia category deleted",
+		"userID", arg.UserID,
+		"category", category,
+		"questions", deleted,
+		"deletedBy", appctx.GetActor(ctx),
+	)
+
+	return data.TriviaCategory{Name: category, Questions: int(deleted)}, nil
+}
+
+// questions returns the questions the channel imported and the default pack.
+func (s *TriviaService) questions(ctx context.Context, userID uuid.UUID) ([]trivia.Question, error) {
+	fromDBs, err := s.store.Query(ctx).CoreTriviaQuestionGetByUserID(ctx, userID)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	questions := trivia.Default()
+	for _, fromDB := range fromDBs {
+		questions = append(questions, trivia.Question{
+			Category: fromDB.Category,
+			Question: fromDB.Question,
+			Answers:  fromDB.Answers,
+		})
+	}
+
+	return questions, nil
+}
+
+// Observe ends the round for the first chatter whose message answers its
+// question, scoring them and paying the reward.
+func (s *TriviaService) Observe(ctx context.Context, message ChatMessage) {
+	event := message.Event
+
+	if message.Private || event.ChatterID == event.BotID || strings.HasPrefix(event.Message, "!") {
+		return
+	}
+
+	entry, err := s.cache.Get(ctx, getTriviaKVKey(event.UserID, event.Platform))
+	if err != nil {
+		if !errors.Is(err, jetstream.ErrKeyNotFound) {
+			s.logger.ErrorContext(ctx, "cannot get trivia round from cache", "err", err)
+		}
+		return
+	}
+
+	var round triviaRound
+	err = json.Unmarshal(entry.Value(), &round)
+	if err != nil {
+		s.logger.WarnContext(ctx, "cannot decode trivia round", "err", err)
+		return
+	}
+
+	if !round.Question.Match(event.Message) {
+		return
+	}
+
+	session, paid, err := s.answer(ctx, round, data.PointsChatter{
+		UserID:       event.UserID,
+		Platform:     event.Platform,
+		ChatterID:    event.ChatterID,
+		ChatterLogin: event.ChatterLogin,
+	})
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			s.logger.ErrorContext(ctx, "cannot answer trivia", "err", err, "sessionID", round.SessionID)
+		}
+		// someone was first
+		return
+	}
+	s.unmirror(ctx, session)
+
+	text := "✅ @" + normalizeLogin(event.ChatterLogin) + " got it! the answer was " + round.Question.Answers[0]
+	if paid {
+		text += " (+" + strconv.Itoa(int(round.Reward)) + " points)"
+	}
+	s.announce(ctx, session, text)
+	if session.ClosedAt != nil {
+		s.announce(ctx, session, triviaResults(session))
+	}
+}
+
+// answer scores the chatter and pays them, when their answer is the first
+// correct one of the round, and closes the session after its last round.
+// It returns whether the reward was paid.
+func (s *TriviaService) answer(ctx context.Context, round triviaRound, chatter data.PointsChatter) (data.TriviaSession, bool, error) {
+	chatter.ChatterLogin = normalizeLogin(chatter.ChatterLogin)
+
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return data.TriviaSession{}, false, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	fromDB, err := s.store.Query(txCtx).CoreTriviaSessionAnswer(txCtx, db.CoreTriviaSessionAnswerParams{
+		ID:          round.SessionID,
+		Round:       round.Round,
+		NextRoundIn: triviaBreak,
+	})
+	if err != nil {
+		return data.TriviaSession{}, false, s.store.HandleErr(txCtx, err)
+	}
+
+	_, err = s.store.Query(txCtx).CoreTriviaSessionScoreAdd(txCtx, db.CoreTriviaSessionScoreAddParams{
+		SessionID:    fromDB.ID,
+		ChatterID:    chatter.ChatterID,
+		ChatterLogin: chatter.ChatterLogin,
+	})
+	if err != nil {
+		return data.TriviaSession{}, false, s.store.HandleErr(txCtx, err)
+	}
+	_, err = s.store.Query(txCtx).CoreTriviaScoreAdd(txCtx, db.CoreTriviaScoreAddParams{
+		UserID:       chatter.UserID,
+		Platform:     chatter.Platform,
+		ChatterID:    chatter.ChatterID,
+		ChatterLogin: chatter.ChatterLogin,
+	})
+	if err != nil {
+		return data.TriviaSession{}, false, s.store.HandleErr(txCtx, err)
+	}
+
+	paid := round.Reward > 0 && s.pointsService.Enabled(txCtx, chatter.UserID)
+	if paid {
+		key := "trivia:" + strconv.FormatInt(fromDB.ID, 10) + ":" + strconv.Itoa(int(round.Round))
+		_, err = s.pointsService.apply(txCtx, chatter, int64(round.Reward), data.PointsReasonTrivia, key)
+		if err != nil {
+			return data.TriviaSession{}, false, err
+		}
+	}
+
+	if round.Round >= round.Rounds {
+		fromDB, err = s.store.Query(txCtx).CoreTriviaSessionClose(txCtx, fromDB.ID)
+		if err != nil {
+			return data.TriviaSession{}, false, s.store.HandleErr(txCtx, err)
+		}
+	}
+
+	session, err := s.withScores(txCtx, data.NewTriviaSessionFromDB(fromDB))
+	if err != nil {
+		return data.TriviaSession{}, false, err
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return data.TriviaSession{}, false, err
+	}
+
+	return session, paid, nil
+}
+
+// Run asks the questions of running sessions and reveals the answers no one
+// found in time, until ctx is done. Sessions are claimed in the database, so
+// any number of replicas can run it.
+func (s *TriviaService) Run(ctx context.Context) {
+	ticker := time.NewTicker(triviaTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.runDue(ctx)
+			if err != nil {
+				s.logger.ErrorContext(ctx, "cannot run due trivia", "err", err)
+			}
+		}
+	}
+}
+
+func (s *TriviaService) runDue(ctx context.Context) error {
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	sessions, err := s.store.Query(txCtx).CoreTriviaSessionClaimDue(txCtx, triviaClaimLimit)
+	if err != nil {
+		return s.store.HandleErr(ctx, err)
+	}
+
+	type announcement struct {
+		session  data.TriviaSession
+		messages []string
+	}
+	var announcements []announcement
+
+	for _, fromDB := range sessions {
+		session := data.NewTriviaSessionFromDB(fromDB)
+		var messages []string
+
+		if session.RoundEndsAt != nil {
+			question, _ := session.Question()
+			messages = append(messages, "⏰ time's up! the answer was "+question.Answers[0])
+
+			if session.Round < session.Rounds {
+				fromDB, err = s.store.Query(txCtx).CoreTriviaSessionEndRound(txCtx, db.CoreTriviaSessionEndRoundParams{
+					ID:          session.ID,
+					NextRoundIn: triviaBreak,
+				})
+				if err != nil {
+					return s.store.HandleErr(ctx, err)
+				}
+				session = data.NewTriviaSessionFromDB(fromDB)
+				announcements = append(announcements, announcement{session, messages})
+				continue
+			}
+		}
+
+		if session.Round >= session.Rounds {
+			fromDB, err = s.store.Query(txCtx).CoreTriviaSessionClose(txCtx, session.ID)
+			if err != nil {
+				return s.store.HandleErr(ctx, err)
+			}
+			session, err = s.withScores(txCtx, data.NewTriviaSessionFromDB(fromDB))
+			if err != nil {
+				return err
+			}
+			messages = append(messages, triviaResults(session))
+			announcements = append(announcements, announcement{session, messages})
+			continue
+		}
+
+		fromDB, err = s.store.Query(txCtx).CoreTriviaSessionAsk(txCtx, db.CoreTriviaSessionAskParams{
+			ID:    session.ID,
+			Round: session.Round + 1,
+		})
+		if err != nil {
+			return s.store.HandleErr(ctx, err)
+		}
+		session = data.NewTriviaSessionFromDB(fromDB)
+		question, _ := session.Question()
+		messages = append(messages, "❓ ["+strconv.Itoa(int(session.Round))+"/"+strconv.Itoa(int(session.Rounds))+", "+
+			question.Category+"] "+question.Question+" ("+strconv.Itoa(int(session.AnswerTime))+"s)")
+		announcements = append(announcements, announcement{session, messages})
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return err
+	}
+
+	// sent only once the claim is committed, so no other replica sends it too
+	for _, a := range announcements {
+		if a.session.RoundEndsAt != nil {
+			s.mirror(ctx, a.session)
+		} else {
+			s.unmirror(ctx, a.session)
+		}
+		for _, message := range a.messages {
+			s.announce(ctx, a.session, message)
+		}
+	}
+
+	return nil
+}
+
+// withScores adds the scores of the session to it.
+func (s *TriviaService) withScores(ctx context.Context, session data.TriviaSession) (data.TriviaSession, error) {
+	fromDBs, err := s.store.Query(ctx).CoreTriviaSessionScoreTop(ctx, db.CoreTriviaSessionScoreTopParams{
+		SessionID: session.ID,
+		Limit:     triviaTopMaxLimit,
+	})
+	if err != nil {
+		return data.TriviaSession{}, s.store.HandleErr(ctx, err)
+	}
+
+	session.Scores = make([]data.TriviaScore, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		session.Scores = append(session.Scores, data.NewTriviaSessionScoreFromDB(fromDB))
+	}
+
+	return session, nil
+}
+
+// triviaResults lists the best scores of the session, as in
+// "🏁 trivia is over! @a 3, @b 1".
+func triviaResults(session data.TriviaSession) string {
+	if len(session.Scores) == 0 {
+		return "🏁 trivia is over, no one scored"
+	}
+
+	scores := make([]string, 0, triviaTopDefaultLimit)
+	for _, score := range session.Scores[:min(len(session.Scores), triviaTopDefaultLimit)] {
+		scores = append(scores, "@"+score.ChatterLogin+" "+strconv.Itoa(int(score.Wins)))
+	}
+	return "🏁 trivia is over! " + strings.Join(scores, ", ")
+}
+
+func (s *TriviaService) announce(ctx context.Context, session data.TriviaSession, message string) {
+	_, err := s.channelService.Send(ctx, session.UserID, session.Platform, message)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot send trivia message", "err", err, "sessionID", session.ID)
+	}
+}
+
+// mirror caches the round being played for Observe, until its time is up.
+func (s *TriviaService) mirror(ctx context.Context, session data.TriviaSession) {
+	question, ok := session.Question()
+	if !ok || session.RoundEndsAt == nil {
+		return
+	}
+
+	round := triviaRound{
+		SessionID: session.ID,
+		Round:     session.Round,
+		Rounds:    session.Rounds,
+		Reward:    session.Reward,
+		Question:  question,
+	}
+
+	err := mirrorWithTTL(ctx, s.cache, getTriviaKVKey(session.UserID, session.Platform), round, time.Until(*session.RoundEndsAt))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot cache trivia round", "err", err, "sessionID", session.ID)
+	}
+}
+
+func (s *TriviaService) unmirror(ctx context.Context, session data.TriviaSession) {
+	err := s.cache.Purge(ctx, getTriviaKVKey(session.UserID, session.Platform))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot uncache trivia round", "err", err, "sessionID", session.ID)
+	}
+}
@@ -3,17 +3,21 @@ package service
 import (
 	"context"
 	"errors"
-	
+	"math/rand/v2"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/arnokay/arnobot-shared/apperror"
 	"github.com/arnokay/arnobot-shared/applog"
-	"github.com/arnokay/arnobot-shared/data"
 	"github.com/arnokay/arnobot-shared/events"
 	"github.com/arnokay/arnobot-shared/platform"
 	"github.com/google/uuid"
 	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/cmdvalidate"
+	"github.com/arnokay/arnobot-core/internal/cmdvars"
+	"github.com/arnokay/arnobot-core/internal/data"
 )
 
 type UserCmdManagerService struct {
@@ -49,17 +53,32 @@ func (s *UserCmdManagerService) getCommandCooldownKVKey(platform platform.Platfo
 	return key
 }
 
-func (s *UserCmdManagerService) parseCommand(message string) string {
-	cmd, _, _ := strings.Cut(message, " ")
-	return cmd
+func (s *UserCmdManagerService) getCommandSequenceKVKey(cmd data.UserCommand) string {
+	return "ucs.seq." + cmd.UserID.String() + "." + cmd.Name
 }
 
-func (s *UserCmdManagerService) IsCommandEvent(ctx context.Context, event events.Message) bool {
-	_, err := s.userCommandService.GetOne(ctx, data.UserCommandGetOne{
-		UserID: event.UserID,
-		Name:   s.parseCommand(event.Message),
+// Resolve matches the first word of the message against the enabled user
+// commands of the channel.
+func (s *UserCmdManagerService) Resolve(ctx context.Context, message ChatMessage) (ResolvedCommand, error) {
+	userCommand, err := s.userCommandService.GetOne(ctx, data.UserCommandGetOne{
+		UserID: message.Event.UserID,
+		Name:   message.Word(),
 	})
-	return err == nil
+	if err != nil || !userCommand.Enabled {
+		return nil, nil
+	}
+
+	return resolvedUserCommand{manager: s, cmd: userCommand, message: message}, nil
+}
+
+type resolvedUserCommand struct {
+	manager *UserCmdManagerService
+	cmd     data.UserCommand
+	message ChatMessage
+}
+
+func (r resolvedUserCommand) Execute(ctx context.Context) (*events.MessageSend, error) {
+	return r.manager.execute(ctx, r.message, r.cmd)
 }
 
 func (s *UserCmdManagerService) setBroadcasterCommandCooldown(ctx context.Context, platform platform.Platform, broadcasterID string, cmd data.UserCommand) error {
@@ -67,7 +86,7 @@ func (s *UserCmdManagerService) setBroadcasterCommandCooldown(ctx context.Contex
 		ctx,
 		s.getCommandCooldownKVKey(platform, broadcasterID, cmd),
 		[]byte{},
-		jetstream.KeyTTL(time.Second*10),
+		jetstream.KeyTTL(time.Second*time.Duration(cmd.Cooldown)),
 	)
 	if err != nil {
 		if errors.Is(err, jetstream.ErrKeyExists) {
@@ -112,37 +131,57 @@ func (s *UserCmdManagerService) isBroadcasterCommandInCooldown(ctx context.Conte
 	return true
 }
 
-func (s *UserCmdManagerService) Execute(ctx context.Context, event events.Message) (*events.MessageSend, error) {
-	userCommand, err := s.userCommandService.GetOne(ctx, data.UserCommandGetOne{
-		UserID: event.UserID,
-		Name:   s.parseCommand(event.Message),
-	})
-	if err != nil {
-		return nil, err
-	}
+func (s *UserCmdManagerService) execute(ctx context.Context, message ChatMessage, userCommand data.UserCommand) (*events.MessageSend, error) {
+	event := message.Event
 
-	if s.isBroadcasterCommandInCooldown(ctx, event.Platform, event.BroadcasterID, userCommand) {
+	if !userCommand.UserLevel.Allows(event.ChatterRole) {
 		s.logger.DebugContext(
 			ctx,
-			"command in cooldown",
-			"platform", event.Platform,
-			"broadcasterID", event.BroadcasterID,
+			"chatter role is below command user level",
+			"role", event.ChatterRole,
 			"cmd", userCommand,
 		)
-		return nil, apperror.ErrForbidden
+		return nil, apperror.ErrNoAction
 	}
-	err = s.setBroadcasterCommandCooldown(ctx, event.Platform, event.BroadcasterID, userCommand)
-	if err != nil {
-		s.logger.DebugContext(
-			ctx,
-			"cannot set cmd cooldown or cache error",
-			"err", err,
-			"cmd", userCommand,
-		)
+
+	if userCommand.Cooldown > 0 {
+		if s.isBroadcasterCommandInCooldown(ctx, event.Platform, event.BroadcasterID, userCommand) {
+			s.logger.DebugContext(
+				ctx,
+				"command in cooldown",
+				"platform", event.Platform,
+				"broadcasterID", event.BroadcasterID,
+				"cmd", userCommand,
+			)
+			return nil, apperror.ErrForbidden
+		}
+		err := s.setBroadcasterCommandCooldown(ctx, event.Platform, event.BroadcasterID, userCommand)
+		if err != nil {
+			s.logger.DebugContext(
+				ctx,
+				"cannot set cmd cooldown or cache error",
+				"err", err,
+				"cmd", userCommand,
+			)
+		}
+	}
+
+	variant := s.pickResponse(ctx, userCommand)
+	text := variant.Text
+	if variant.Variables {
+		text = cmdvars.Render(text, cmdvars.Values{
+			User:    event.ChatterName,
+			Channel: event.BroadcasterName,
+			Args:    strings.TrimSpace(message.Command.Args),
+		})
+	}
+	if cmdvalidate.IsPlatformCommand(text) {
+		s.logger.WarnContext(ctx, "user command rendered to a platform command, not sending", "cmd", userCommand.Name)
+		return nil, apperror.ErrNoAction
 	}
 
 	resp := events.MessageSend{
-		Message: userCommand.Text,
+		Message: text,
 	}
 
 	if userCommand.Reply {
@@ -154,9 +193,65 @@ func (s *UserCmdManagerService) Execute(ctx context.Context, event events.Messag
 	response.BroadcasterID = event.BroadcasterID
 	response.BotID = event.BotID
 	response.Platform = event.Platform
-	response.Message = userCommand.Text
+	response.Message = text
 	if userCommand.Reply {
 		response.ReplyTo = event.MessageID
 	}
 	return &response, nil
 }
+
+func (s *UserCmdManagerService) pickResponse(ctx context.Context, cmd data.UserCommand) data.UserCommandResponse {
+	variants := cmd.Variants()
+	if len(variants) == 1 {
+		return variants[0]
+	}
+
+	switch cmd.ResponseMode {
+	case data.ResponseModeWeighted:
+		var total int
+		for _, variant := range variants {
+			total += int(max(variant.Weight, 1))
+		}
+		n := rand.IntN(total)
+		for _, variant := range variants {
+			n -= int(max(variant.Weight, 1))
+			if n < 0 {
+				return variant
+			}
+		}
+		return variants[len(variants)-1]
+	case data.ResponseModeSequential:
+		return variants[s.nextSequence(ctx, cmd)%len(variants)]
+	default:
+		return variants[rand.IntN(len(variants))]
+	}
+}
+
+// nextSequence returns the round-robin position of the command, shared between
+// replicas through an optimistic counter in the cache.
+func (s *UserCmdManagerService) nextSequence(ctx context.Context, cmd data.UserCommand) int {
+	key := s.getCommandSequenceKVKey(cmd)
+
+	for range 3 {
+		entry, err := s.cache.Get(ctx, key)
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			_, err = s.cache.Create(ctx, key, []byte("1"))
+			if err == nil {
+				return 0
+			}
+			continue
+		}
+		if err != nil {
+			break
+		}
+
+		n, _ := strconv.Atoi(string(entry.Value()))
+		_, err = s.cache.Update(ctx, key, []byte(strconv.Itoa(n+1)), entry.Revision())
+		if err == nil {
+			return n
+		}
+	}
+
+	s.logger.WarnContext(ctx, "cannot advance user command sequence, falling back to random", "cmd", cmd.Name)
+	return rand.IntN(len(cmd.Variants()))
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	// userCommandCacheVersion has to be bumped whenever the cached JSON of
+	// data.UserCommand changes, so entries of older builds are ignored.
+	userCommandCacheVersion = 2
+	// userCommandCacheTTL is the safety net for changes that were never
+	// notified, such as the ones made while no replica was listening.
+	userCommandCacheTTL = 10 * time.Minute
+
+	userCommandChangedChannel = "core_user_command_changed"
+	userCommandWatchBackoff   = 5 * time.Second
+)
+
+type userCommandCacheEntry struct {
+	Version  int              `json:"v"`
+	CachedAt time.Time        `json:"cachedAt"`
+	Command  data.UserCommand `json:"command"`
+}
+
+// userCommandChangeEvent is the payload of the notifications sent by the
+// database triggers on user commands and their responses.
+type userCommandChangeEvent struct {
+	UserID uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+}
+
+func (s *UserCommandService) cacheGet(ctx context.Context, userID uuid.UUID, name string) (data.UserCommand, bool) {
+	val, err := s.cache.Get(ctx, getCommandKVKey(userID, name))
+	if err != nil {
+		s.logger.DebugContext(ctx, "missing cache for get user command, making db call", "err", err)
+		return data.UserCommand{}, false
+	}
+
+	var entry userCommandCacheEntry
+	err = json.Unmarshal(val.Value(), &entry)
+	if err != nil {
+		s.logger.WarnContext(ctx, "cannot decode cached user command, making db call", "err", err)
+		return data.UserCommand{}, false
+	}
+
+	if entry.Version != userCommandCacheVersion || time.Since(entry.CachedAt) > userCommandCacheTTL {
+		s.logger.DebugContext(ctx, "outdated cache for get user command, making db call", "version", entry.Version)
+		return data.UserCommand{}, false
+	}
+
+	return entry.Command, true
+}
+
+func (s *UserCommandService) cachePut(ctx context.Context, userCommand data.UserCommand) {
+	entry := userCommandCacheEntry{
+		Version:  userCommandCacheVersion,
+		CachedAt: time.Now(),
+		Command:  userCommand,
+	}
+
+	err := mirrorWithTTL(ctx, s.cache, getCommandKVKey(userCommand.UserID, userCommand.Name), entry, userCommandCacheTTL)
+	if err != nil && !errors.Is(err, jetstream.ErrKeyExists) {
+		s.logger.WarnContext(ctx, "cannot cache put user command", "err", err)
+	}
+}
+
+func (s *UserCommandService) cachePurge(ctx context.Context, userID uuid.UUID, name string) {
+	err := s.cache.Purge(ctx, getCommandKVKey(userID, name))
+	if err != nil {
+		s.logger.WarnContext(ctx, "cannot purge cache user command", "err", err)
+	}
+}
+
+// WatchChanges keeps the cache and the name index coherent with changes made
+// by any replica, or directly in the database, by listening to the
+// notifications of the user command triggers. It blocks until ctx is done.
+func (s *UserCommandService) WatchChanges(ctx context.Context, pool *pgxpool.Pool) {
+	for {
+		err := s.listenChanges(ctx, pool)
+		if ctx.Err() != nil {
+			return
+		}
+		s.logger.ErrorContext(ctx, "stopped listening to user command changes, retrying", "err", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(userCommandWatchBackoff):
+		}
+	}
+}
+
+func (s *UserCommandService) listenChanges(ctx context.Context, pool *pgxpool.Pool) error {
+	pooled, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// a listening connection must not go back to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+userCommandChangedChannel)
+	if err != nil {
+		return err
+	}
+
+	// changes made while nobody was listening are unknown, start over
+	s.index.reset()
+	s.logger.DebugContext(ctx, "listening to user command changes")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event userCommandChangeEvent
+		err = json.Unmarshal([]byte(notification.Payload), &event)
+		if err != nil {
+			s.logger.WarnContext(ctx, "cannot decode user command change", "err", err, "payload", notification.Payload)
+			continue
+		}
+
+		s.index.forget(event.UserID)
+		s.cachePurge(ctx, event.UserID, event.Name)
+	}
+}
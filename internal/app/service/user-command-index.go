@@ -0,0 +1,126 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/expiring"
+)
+
+// userCommandIndexTTL bounds how long a replica trusts its index of a channel,
+// in case a change notification got lost.
+const userCommandIndexTTL = 5 * time.Minute
+
+// userCommandIndex keeps the names of the user commands of every channel seen
+// by this replica. A name missing from a loaded entry is a negative cache hit,
+// which spares the database the lookup for ordinary chat messages.
+type userCommandIndex struct {
+	// mu guards the name sets held by entries, which rename edits in place.
+	mu      sync.RWMutex
+	entries *expiring.Cache[uuid.UUID, map[string]struct{}]
+	// generations is set from next on every invalidation, so a load racing
+	// with a change does not store names read before the change. It only has
+	// to outlive a load, and never repeats a value once it expired.
+	generations *expiring.Cache[uuid.UUID, uint64]
+	next        uint64
+	// resetAt is the generation of every user after a reset.
+	resetAt uint64
+}
+
+func newUserCommandIndex() *userCommandIndex {
+	return &userCommandIndex{
+		entries:     expiring.New[uuid.UUID, map[string]struct{}](userCommandIndexTTL),
+		generations: expiring.New[uuid.UUID, uint64](userCommandIndexTTL),
+	}
+}
+
+// lookup reports whether name exists for the user, and whether the index knows
+// the answer at all.
+func (i *userCommandIndex) lookup(userID uuid.UUID, name string) (exists bool, known bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	names, ok := i.entries.Get(userID)
+	if !ok {
+		return false, false
+	}
+
+	_, exists = names[name]
+	return exists, true
+}
+
+// generation has to be taken before reading the names that are passed to store.
+func (i *userCommandIndex) generation(userID uuid.UUID) uint64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.current(userID)
+}
+
+// current is the generation of the user, i.mu must be held.
+func (i *userCommandIndex) current(userID uuid.UUID) uint64 {
+	generation, _ := i.generations.Get(userID)
+	return max(generation, i.resetAt)
+}
+
+func (i *userCommandIndex) store(userID uuid.UUID, generation uint64, names []string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.current(userID) != generation {
+		return
+	}
+
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	i.entries.Set(userID, set)
+}
+
+// bump invalidates the loads of the user in flight, i.mu must be held.
+func (i *userCommandIndex) bump(userID uuid.UUID) {
+	i.next++
+	i.generations.Set(userID, i.next)
+}
+
+// rename moves a command in an already loaded entry. An empty from adds the
+// name, an empty to removes it.
+func (i *userCommandIndex) rename(userID uuid.UUID, from string, to string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.bump(userID)
+
+	names, ok := i.entries.Get(userID)
+	if !ok {
+		return
+	}
+	if from != "" {
+		delete(names, from)
+	}
+	if to != "" {
+		names[to] = struct{}{}
+	}
+}
+
+// forget drops the entry of the user, it is loaded again on the next lookup.
+func (i *userCommandIndex) forget(userID uuid.UUID) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.bump(userID)
+	i.entries.Delete(userID)
+}
+
+func (i *userCommandIndex) reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.next++
+	i.resetAt = i.next
+	i.generations.Clear()
+	i.entries.Clear()
+}
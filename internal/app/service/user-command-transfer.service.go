@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+
+	"github.com/arnokay/arnobot-core/internal/cmdtransfer"
+	"github.com/arnokay/arnobot-core/internal/cmdvalidate"
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+type UserCommandTransferService struct {
+	userCommandService *UserCommandService
+	cmdManagerService  *CmdManagerService
+
+	logger applog.Logger
+}
+
+func NewUserCommandTransferService(
+	userCommandService *UserCommandService,
+	commandManager *CmdManagerService,
+) *UserCommandTransferService {
+	logger := applog.NewServiceLogger("user-command-transfer-service")
+
+	return &UserCommandTransferService{
+		userCommandService: userCommandService,
+		cmdManagerService:  commandManager,
+
+		logger: logger,
+	}
+}
+
+func (s *UserCommandTransferService) Export(ctx context.Context, arg data.UserCommandExport) (data.UserCommandExportResult, error) {
+	userCommands, err := s.userCommandService.GetByUserID(ctx, arg.UserID)
+	if err != nil {
+		return data.UserCommandExportResult{}, err
+	}
+
+	content, warnings, err := cmdtransfer.Encode(arg.Format, userCommands)
+	if err != nil {
+		if errors.Is(err, cmdtransfer.ErrUnknownFormat) {
+			return data.UserCommandExportResult{}, apperror.New(apperror.CodeInvalidInput, err.Error(), err)
+		}
+		s.logger.ErrorContext(ctx, "cannot encode user commands", "err", err, "format", arg.Format)
+		return data.UserCommandExportResult{}, apperror.ErrInternal
+	}
+
+	return data.UserCommandExportResult{
+		Format:   arg.Format,
+		Content:  content,
+		Warnings: warnings,
+	}, nil
+}
+
+// Import plans what happens to every command of the export and, unless it is a
+// dry run, applies the plan. Each command is applied in a transaction of its
+// own, recorded as a single revision, so a failing command is reported on its
+// item without undoing the others.
+func (s *UserCommandTransferService) Import(ctx context.Context, arg data.UserCommandImport) (data.UserCommandImportResult, error) {
+	strategy := arg.Strategy
+	switch strategy {
+	case "":
+		strategy = data.ConflictSkip
+	case data.ConflictSkip, data.ConflictOverwrite, data.ConflictRename:
+	default:
+		return data.UserCommandImportResult{}, apperror.New(apperror.CodeInvalidInput, "unknown conflict strategy", nil)
+	}
+
+	commands, err := cmdtransfer.Decode(arg.Format, arg.Content)
+	if err != nil {
+		if errors.Is(err, cmdtransfer.ErrUnknownFormat) {
+			return data.UserCommandImportResult{}, apperror.New(apperror.CodeInvalidInput, err.Error(), err)
+		}
+		return data.UserCommandImportResult{}, apperror.New(apperror.CodeInvalidInput, "cannot parse import: "+err.Error(), err)
+	}
+
+	existing, err := s.userCommandService.GetByUserID(ctx, arg.UserID)
+	if err != nil {
+		return data.UserCommandImportResult{}, err
+	}
+
+	current := make(map[string]data.UserCommand, len(existing))
+	for _, userCommand := range existing {
+		current[userCommand.Name] = userCommand
+	}
+	imported := map[string]bool{}
+
+	result := data.UserCommandImportResult{
+		DryRun: arg.DryRun,
+		Items:  make([]data.UserCommandImportItem, 0, len(commands)),
+	}
+
+	for _, command := range commands {
+		command.Name = cmdvalidate.NormalizeName(command.Name)
+		command.Text = strings.TrimSpace(command.Text)
+
+		item := data.UserCommandImportItem{
+			Name:      command.Name,
+			FinalName: command.Name,
+			Text:      command.Text,
+			Warnings:  command.Warnings,
+		}
+
+		errs := data.FieldErrors{}
+		errs.Add("name", cmdvalidate.Name(command.Name))
+		errs.Add("text", cmdvalidate.Text(command.Text))
+		if command.Cooldown != nil {
+			errs.Add("cooldown", cmdvalidate.Cooldown(*command.Cooldown))
+		}
+		if command.ResponseMode != "" && !command.ResponseMode.IsEnum() {
+			errs.Add("responseMode", "unknown response mode")
+		}
+		if command.UserLevel != nil && !command.UserLevel.IsEnum() {
+			errs.Add("userLevel", "unknown user level")
+		}
+		for i := range command.Responses {
+			command.Responses[i].Text = strings.TrimSpace(command.Responses[i].Text)
+			errs.Add("responses."+strconv.Itoa(i), cmdvalidate.Text(command.Responses[i].Text))
+		}
+		if len(errs) > 0 {
+			item.Action = data.ImportActionFail
+			item.Error = errs.Error()
+			result.Items = append(result.Items, item)
+			continue
+		}
+
+		existingCommand, exists := current[command.Name]
+		conflict := exists || imported[command.Name] || s.cmdManagerService.IsCommand(command.Name)
+
+		switch {
+		case !conflict:
+			item.Action = data.ImportActionCreate
+		case strategy == data.ConflictRename:
+			item.Action = data.ImportActionRename
+			item.FinalName = s.freeName(command.Name, current, imported)
+		case strategy == data.ConflictOverwrite && exists && !imported[command.Name]:
+			item.Action = data.ImportActionOverwrite
+			item.CurrentText = &existingCommand.Text
+		default:
+			item.Action = data.ImportActionSkip
+		}
+
+		if item.Action != data.ImportActionSkip {
+			imported[item.FinalName] = true
+		}
+
+		if !arg.DryRun {
+			err = s.apply(ctx, arg, item, command, existingCommand)
+			if err != nil {
+				item.Action = data.ImportActionFail
+				item.Error = err.Error()
+			}
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
+
+// freeName numbers name until it is taken by nothing, cutting it short when
+// the number would make it too long.
+func (s *UserCommandTransferService) freeName(name string, current map[string]data.UserCommand, imported map[string]bool) string {
+	runes := []rune(name)
+	for n := 2; ; n++ {
+		suffix := strconv.Itoa(n)
+		candidate := string(runes[:min(len(runes), cmdvalidate.MaxNameLength-len(suffix))]) + suffix
+		if _, ok := current[candidate]; ok || imported[candidate] || s.cmdManagerService.IsCommand(candidate) {
+			continue
+		}
+		return candidate
+	}
+}
+
+// apply carries out the action planned for command. An overwritten command
+// keeps the settings the import does not set.
+func (s *UserCommandTransferService) apply(
+	ctx context.Context,
+	arg data.UserCommandImport,
+	item data.UserCommandImportItem,
+	command cmdtransfer.Command,
+	existing data.UserCommand,
+) error {
+	target := data.UserCommand{
+		UserID:       arg.UserID,
+		Name:         item.FinalName,
+		Text:         command.Text,
+		Reply:        command.Reply,
+		Enabled:      command.Enabled,
+		ResponseMode: data.ResponseModeRandom,
+		Cooldown:     userCommandDefaultCooldown,
+		UserLevel:    data.UserLevelEveryone,
+		Variables:    true,
+	}
+
+	switch item.Action {
+	case data.ImportActionCreate, data.ImportActionRename:
+	case data.ImportActionOverwrite:
+		target.ResponseMode = existing.ResponseMode
+		target.Cooldown = existing.Cooldown
+		target.UserLevel = existing.UserLevel
+	default:
+		return nil
+	}
+
+	if command.ResponseMode != "" {
+		target.ResponseMode = command.ResponseMode
+	}
+	if command.Cooldown != nil {
+		target.Cooldown = *command.Cooldown
+	}
+	if command.UserLevel != nil {
+		target.UserLevel = *command.UserLevel
+	}
+	for _, response := range command.Responses {
+		target.Responses = append(target.Responses, data.UserCommandResponse{
+			Text:      response.Text,
+			Weight:    response.Weight,
+			Variables: true,
+		})
+	}
+
+	_, err := s.userCommandService.put(ctx, target, item.Action == data.ImportActionOverwrite)
+	return err
+}
@@ -2,22 +2,32 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
-	
+	"errors"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/arnokay/arnobot-shared/apperror"
 	"github.com/arnokay/arnobot-shared/applog"
-	"github.com/arnokay/arnobot-shared/data"
-	"github.com/arnokay/arnobot-shared/db"
-	"github.com/arnokay/arnobot-shared/storage"
+	"github.com/arnokay/arnobot-shared/service"
 	"github.com/google/uuid"
 	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/cmdvalidate"
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/storage"
 )
 
 type UserCommandService struct {
 	cache             jetstream.KeyValue
 	store             storage.Storager
+	tx                service.ITransactionService
 	cmdManagerService *CmdManagerService
+	index             *userCommandIndex
 
 	logger applog.Logger
 }
@@ -25,6 +35,7 @@ type UserCommandService struct {
 func NewUserCommandService(
 	cache jetstream.KeyValue,
 	store storage.Storager,
+	tx service.ITransactionService,
 	commandManager *CmdManagerService,
 ) *UserCommandService {
 	logger := applog.NewServiceLogger("user-command-service")
@@ -32,7 +43,9 @@ func NewUserCommandService(
 	return &UserCommandService{
 		cache:             cache,
 		store:             store,
+		tx:                tx,
 		cmdManagerService: commandManager,
+		index:             newUserCommandIndex(),
 
 		logger: logger,
 	}
@@ -43,12 +56,12 @@ func getCommandKVKey(userID uuid.UUID, name string) string {
 }
 
 func (s *UserCommandService) GetOne(ctx context.Context, arg data.UserCommandGetOne) (data.UserCommand, error) {
-	if val, err := s.cache.Get(ctx, getCommandKVKey(arg.UserID, arg.Name)); err == nil {
-		var userCommand data.UserCommand
-		json.Unmarshal(val.Value(), &userCommand)
+	if !s.mightExist(ctx, arg.UserID, arg.Name) {
+		return data.UserCommand{}, apperror.ErrNotFound
+	}
+
+	if userCommand, ok := s.cacheGet(ctx, arg.UserID, arg.Name); ok {
 		return userCommand, nil
-	} else {
-		s.logger.DebugContext(ctx, "missing cache for get user command, making db call", "err", err)
 	}
 
 	fromDB, err := s.store.Query(ctx).CoreUserCommandGetOne(ctx, db.CoreUserCommandGetOneParams{
@@ -59,99 +72,848 @@ func (s *UserCommandService) GetOne(ctx context.Context, arg data.UserCommandGet
 		return data.UserCommand{}, s.store.HandleErr(ctx, err)
 	}
 
-	userCommand := data.NewUserCommandFromDB(fromDB)
-	b, _ := json.Marshal(userCommand)
-
-	_, err = s.cache.Put(ctx, getCommandKVKey(userCommand.UserID, userCommand.Name), b)
+	userCommand, err := s.withResponses(ctx, fromDB)
 	if err != nil {
-		s.logger.WarnContext(ctx, "cannot cache put user command", "err", err)
+		return data.UserCommand{}, err
 	}
 
+	s.cachePut(ctx, userCommand)
+
 	return userCommand, nil
 }
 
+// mightExist answers from the name index, loading it for the user when needed.
+// It only returns false when the command surely does not exist.
+func (s *UserCommandService) mightExist(ctx context.Context, userID uuid.UUID, name string) bool {
+	exists, known := s.index.lookup(userID, name)
+	if known {
+		return exists
+	}
+
+	generation := s.index.generation(userID)
+	names, err := s.store.Query(ctx).CoreUserCommandGetNamesByUserID(ctx, userID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "cannot load user command names", "err", s.store.HandleErr(ctx, err))
+		return true
+	}
+	s.index.store(userID, generation, names)
+
+	return slices.Contains(names, name)
+}
+
 func (s *UserCommandService) GetByUserID(ctx context.Context, userID uuid.UUID) ([]data.UserCommand, error) {
 	fromDBs, err := s.store.Query(ctx).CoreUserCommandGetByUserID(ctx, userID)
 	if err != nil {
 		return nil, s.store.HandleErr(ctx, err)
 	}
 
+	responses, err := s.store.Query(ctx).CoreUserCommandResponseGetByUserID(ctx, userID)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	byCommand := map[string][]data.UserCommandResponse{}
+	for _, response := range responses {
+		byCommand[response.CommandName] = append(
+			byCommand[response.CommandName],
+			data.NewUserCommandResponseFromDB(response),
+		)
+	}
+
 	var userCommands []data.UserCommand
 
 	for _, fromDB := range fromDBs {
-		userCommands = append(userCommands, data.NewUserCommandFromDB(fromDB))
+		userCommand := data.NewUserCommandFromDB(fromDB)
+		userCommand.Responses = byCommand[userCommand.Name]
+		userCommands = append(userCommands, userCommand)
 	}
 
 	return userCommands, nil
 }
 
-func (s *UserCommandService) Create(ctx context.Context, arg data.UserCommandCreate) (data.UserCommand, error) {
-	if s.cmdManagerService.IsCommand(arg.Name) {
-		return data.UserCommand{}, apperror.New(apperror.CodeInvalidInput, "default command has this name", nil)
+const (
+	userCommandListDefaultLimit = 50
+	userCommandListMaxLimit     = 100
+)
+
+type userCommandCursor struct {
+	Name  string    `json:"n"`
+	Value time.Time `json:"v,omitzero"`
+}
+
+var userCommandSortColumns = map[data.UserCommandSort]string{
+	data.UserCommandSortName:      "name",
+	data.UserCommandSortCreatedAt: "created_at",
+	data.UserCommandSortUpdatedAt: "updated_at",
+}
+
+// List returns one page of the user commands, optionally filtered by a search
+// over name and text. NextCursor is empty on the last page.
+func (s *UserCommandService) List(ctx context.Context, arg data.UserCommandList) (data.UserCommandPage, error) {
+	sort := arg.Sort
+	if sort == "" {
+		sort = data.UserCommandSortUpdatedAt
+	}
+	sortColumn, ok := userCommandSortColumns[sort]
+	if !ok {
+		return data.UserCommandPage{}, apperror.New(apperror.CodeInvalidInput, "unknown sort", nil)
 	}
 
-	fromDB, err := s.store.Query(ctx).CoreUserCommandCreate(ctx, db.CoreUserCommandCreateParams{
-		UserID: arg.UserID,
-		Name:   arg.Name,
-		Text:   arg.Text,
-		Reply:  arg.Reply,
+	limit := arg.Limit
+	if limit <= 0 {
+		limit = userCommandListDefaultLimit
+	}
+	limit = min(limit, userCommandListMaxLimit)
+
+	params := db.CoreUserCommandListParams{
+		UserID:     arg.UserID,
+		SortColumn: sortColumn,
+		Descending: arg.Desc,
+		Limit:      limit + 1,
+	}
+
+	if search := strings.TrimSpace(arg.Search); search != "" {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		params.Search = &pattern
+	}
+
+	if arg.Cursor != "" {
+		cursor, err := decodeUserCommandCursor(arg.Cursor)
+		if err != nil {
+			return data.UserCommandPage{}, apperror.New(apperror.CodeInvalidInput, "invalid cursor", err)
+		}
+		params.AfterName = &cursor.Name
+		params.AfterValue = cursor.Value
+	}
+
+	fromDBs, err := s.store.Query(ctx).CoreUserCommandList(ctx, params)
+	if err != nil {
+		return data.UserCommandPage{}, s.store.HandleErr(ctx, err)
+	}
+
+	var page data.UserCommandPage
+
+	if len(fromDBs) > int(limit) {
+		fromDBs = fromDBs[:limit]
+		last := fromDBs[len(fromDBs)-1]
+		cursor := userCommandCursor{Name: last.Name}
+		switch sort {
+		case data.UserCommandSortCreatedAt:
+			cursor.Value = last.CreatedAt
+		case data.UserCommandSortUpdatedAt:
+			cursor.Value = last.UpdatedAt
+		}
+		page.NextCursor = encodeUserCommandCursor(cursor)
+	}
+
+	names := make([]string, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		names = append(names, fromDB.Name)
+	}
+
+	responses, err := s.store.Query(ctx).CoreUserCommandResponseGetByCommands(ctx, db.CoreUserCommandResponseGetByCommandsParams{
+		UserID:       arg.UserID,
+		CommandNames: names,
 	})
 	if err != nil {
-		return data.UserCommand{}, s.store.HandleErr(ctx, err)
+		return data.UserCommandPage{}, s.store.HandleErr(ctx, err)
 	}
 
-	userCommand := data.NewUserCommandFromDB(fromDB)
-	b, _ := json.Marshal(userCommand)
+	byCommand := map[string][]data.UserCommandResponse{}
+	for _, response := range responses {
+		byCommand[response.CommandName] = append(
+			byCommand[response.CommandName],
+			data.NewUserCommandResponseFromDB(response),
+		)
+	}
+
+	page.Items = make([]data.UserCommand, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		userCommand := data.NewUserCommandFromDB(fromDB)
+		userCommand.Responses = byCommand[userCommand.Name]
+		page.Items = append(page.Items, userCommand)
+	}
 
-	_, err = s.cache.Create(ctx, getCommandKVKey(userCommand.UserID, userCommand.Name), b)
+	return page, nil
+}
+
+// Count returns how many commands the user has, not counting deleted ones.
+func (s *UserCommandService) Count(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := s.store.Query(ctx).CoreUserCommandCount(ctx, userID)
 	if err != nil {
-		s.logger.WarnContext(ctx, "cannot cache create user command", "err", err)
+		return 0, s.store.HandleErr(ctx, err)
 	}
+	return int(count), nil
+}
 
-	return userCommand, nil
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func encodeUserCommandCursor(cursor userCommandCursor) string {
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
 }
 
-func (s *UserCommandService) Update(ctx context.Context, arg data.UserCommandUpdate) (data.UserCommand, error) {
-	if arg.NewName != nil && s.cmdManagerService.IsCommand(*arg.NewName) {
-		return data.UserCommand{}, apperror.New(apperror.CodeInvalidInput, "default command has this name", nil)
+func decodeUserCommandCursor(raw string) (userCommandCursor, error) {
+	var cursor userCommandCursor
+
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return cursor, err
 	}
 
-	fromDB, err := s.store.Query(ctx).CoreUserCommandUpdate(ctx, db.CoreUserCommandUpdateParams{
-		UserID:  arg.UserID,
-		Name:    arg.Name,
-		NewName: arg.NewName,
-		Text:    arg.Text,
-		Reply:   arg.Reply,
+	err = json.Unmarshal(b, &cursor)
+	return cursor, err
+}
+
+// checkName returns what is wrong with a normalized command name, or an empty
+// string.
+func (s *UserCommandService) checkName(name string) string {
+	if problem := cmdvalidate.Name(name); problem != "" {
+		return problem
+	}
+	if s.cmdManagerService.IsCommand(name) {
+		return "default command has this name"
+	}
+	return ""
+}
+
+// userCommandDefaultCooldown is in seconds.
+const userCommandDefaultCooldown = 10
+
+func (s *UserCommandService) Create(ctx context.Context, arg data.UserCommandCreate) (data.UserCommand, error) {
+	arg.Name = cmdvalidate.NormalizeName(arg.Name)
+	arg.Text = strings.TrimSpace(arg.Text)
+
+	errs := data.FieldErrors{}
+	errs.Add("name", s.checkName(arg.Name))
+	errs.Add("text", cmdvalidate.Text(arg.Text))
+
+	responseMode := data.ResponseModeRandom
+	if arg.ResponseMode != nil {
+		if arg.ResponseMode.IsEnum() {
+			responseMode = *arg.ResponseMode
+		} else {
+			errs.Add("responseMode", "unknown response mode")
+		}
+	}
+
+	cooldown := int32(userCommandDefaultCooldown)
+	if arg.Cooldown != nil {
+		cooldown = *arg.Cooldown
+		errs.Add("cooldown", cmdvalidate.Cooldown(cooldown))
+	}
+
+	userLevel := data.UserLevelEveryone
+	if arg.UserLevel != nil {
+		if arg.UserLevel.IsEnum() {
+			userLevel = *arg.UserLevel
+		} else {
+			errs.Add("userLevel", "unknown user level")
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.UserCommand{}, err
+	}
+
+	_, after, err := s.commit(ctx, data.RevisionActionCreate, func(ctx context.Context) (*data.UserCommand, *data.UserCommand, error) {
+		err := s.archiveDeleted(ctx, arg.UserID, arg.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fromDB, err := s.store.Query(ctx).CoreUserCommandCreate(ctx, db.CoreUserCommandCreateParams{
+			UserID:       arg.UserID,
+			Name:         arg.Name,
+			Text:         arg.Text,
+			Reply:        arg.Reply,
+			Enabled:      arg.Enabled == nil || *arg.Enabled,
+			ResponseMode: responseMode.String(),
+			Cooldown:     cooldown,
+			UserLevel:    userLevel.String(),
+		})
+		if err != nil {
+			return nil, nil, s.store.HandleErr(ctx, err)
+		}
+
+		userCommand := data.NewUserCommandFromDB(fromDB)
+		return nil, &userCommand, nil
 	})
 	if err != nil {
-		return data.UserCommand{}, s.store.HandleErr(ctx, err)
+		return data.UserCommand{}, err
 	}
 
-	userCommand := data.NewUserCommandFromDB(fromDB)
-	b, _ := json.Marshal(userCommand)
+	return *after, nil
+}
+
+func (s *UserCommandService) Update(ctx context.Context, arg data.UserCommandUpdate) (data.UserCommand, error) {
+	arg.Name = cmdvalidate.NormalizeName(arg.Name)
+
+	errs := data.FieldErrors{}
+
+	if arg.NewName != nil {
+		newName := cmdvalidate.NormalizeName(*arg.NewName)
+		arg.NewName = &newName
+		errs.Add("newName", s.checkName(newName))
+	}
+
+	if arg.Text != nil {
+		text := strings.TrimSpace(*arg.Text)
+		arg.Text = &text
+		errs.Add("text", cmdvalidate.Text(text))
+	}
+
+	var responseMode *string
+	if arg.ResponseMode != nil {
+		if arg.ResponseMode.IsEnum() {
+			mode := arg.ResponseMode.String()
+			responseMode = &mode
+		} else {
+			errs.Add("responseMode", "unknown response mode")
+		}
+	}
+
+	if arg.Cooldown != nil {
+		errs.Add("cooldown", cmdvalidate.Cooldown(*arg.Cooldown))
+	}
 
-	_, err = s.cache.Put(ctx, getCommandKVKey(userCommand.UserID, userCommand.Name), b)
+	var userLevel *string
+	if arg.UserLevel != nil {
+		if arg.UserLevel.IsEnum() {
+			level := arg.UserLevel.String()
+			userLevel = &level
+		} else {
+			errs.Add("userLevel", "unknown user level")
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		return data.UserCommand{}, err
+	}
+
+	_, after, err := s.commit(ctx, data.RevisionActionUpdate, func(ctx context.Context) (*data.UserCommand, *data.UserCommand, error) {
+		before, err := s.getFromDB(ctx, arg.UserID, arg.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if arg.NewName != nil && *arg.NewName != arg.Name {
+			err = s.archiveDeleted(ctx, arg.UserID, *arg.NewName)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		fromDB, err := s.store.Query(ctx).CoreUserCommandUpdate(ctx, db.CoreUserCommandUpdateParams{
+			UserID:       arg.UserID,
+			Name:         arg.Name,
+			NewName:      arg.NewName,
+			Text:         arg.Text,
+			Reply:        arg.Reply,
+			Enabled:      arg.Enabled,
+			ResponseMode: responseMode,
+			Cooldown:     arg.Cooldown,
+			UserLevel:    userLevel,
+		})
+		if err != nil {
+			return nil, nil, s.store.HandleErr(ctx, err)
+		}
+
+		after, err := s.withResponses(ctx, fromDB)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &before, &after, nil
+	})
 	if err != nil {
-		s.logger.WarnContext(ctx, "cannot cache updated user command", "err", err)
+		return data.UserCommand{}, err
 	}
 
-	return userCommand, nil
+	return *after, nil
 }
 
+// Delete soft deletes the command, it can be brought back with Restore.
 func (s *UserCommandService) Delete(ctx context.Context, arg data.UserCommandDelete) (data.UserCommand, error) {
-	fromDB, err := s.store.Query(ctx).CoreUserCommandDelete(ctx, db.CoreUserCommandDeleteParams{
+	arg.Name = cmdvalidate.NormalizeName(arg.Name)
+
+	before, _, err := s.commit(ctx, data.RevisionActionDelete, s.deleteChange(arg.UserID, arg.Name))
+	if err != nil {
+		return data.UserCommand{}, err
+	}
+
+	return *before, nil
+}
+
+func (s *UserCommandService) Restore(ctx context.Context, arg data.UserCommandRestore) (data.UserCommand, error) {
+	arg.Name = cmdvalidate.NormalizeName(arg.Name)
+
+	_, after, err := s.commit(ctx, data.RevisionActionRestore, s.restoreChange(arg.UserID, arg.Name))
+	if err != nil {
+		return data.UserCommand{}, err
+	}
+
+	return *after, nil
+}
+
+// Undo reverts the last recorded change of the command. The revert is recorded
+// as a change of its own, so undoing twice redoes the change.
+func (s *UserCommandService) Undo(ctx context.Context, arg data.UserCommandUndo) (data.UserCommand, error) {
+	arg.Name = cmdvalidate.NormalizeName(arg.Name)
+
+	fromDB, err := s.store.Query(ctx).CoreUserCommandRevisionGetLast(ctx, db.CoreUserCommandRevisionGetLastParams{
+		UserID:      arg.UserID,
+		CommandName: arg.Name,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return data.UserCommand{}, apperror.New(apperror.CodeNotFound, "nothing to undo", nil)
+		}
+		return data.UserCommand{}, err
+	}
+
+	revision, err := data.NewUserCommandRevisionFromDB(fromDB)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot decode user command revision", "err", err, "revisionID", fromDB.ID)
+		return data.UserCommand{}, apperror.ErrInternal
+	}
+
+	var action data.RevisionAction
+	var change userCommandChange
+
+	switch revision.Action {
+	case data.RevisionActionCreate, data.RevisionActionRestore:
+		action = data.RevisionActionDelete
+		change = s.deleteChange(arg.UserID, arg.Name)
+	case data.RevisionActionDelete:
+		action = data.RevisionActionRestore
+		change = s.restoreChange(arg.UserID, arg.Name)
+	case data.RevisionActionUpdate:
+		if revision.Before == nil {
+			s.logger.ErrorContext(ctx, "update revision has no previous state", "revisionID", revision.ID)
+			return data.UserCommand{}, apperror.ErrInternal
+		}
+		action = data.RevisionActionUpdate
+		change = s.revertChange(arg.UserID, arg.Name, *revision.Before)
+	default:
+		s.logger.ErrorContext(ctx, "unknown user command revision action", "revisionID", revision.ID, "action", revision.Action)
+		return data.UserCommand{}, apperror.ErrInternal
+	}
+
+	before, after, err := s.commit(ctx, action, change)
+	if err != nil {
+		return data.UserCommand{}, err
+	}
+
+	if after != nil {
+		return *after, nil
+	}
+	return *before, nil
+}
+
+const (
+	revisionListDefaultLimit = 20
+	revisionListMaxLimit     = 100
+)
+
+func (s *UserCommandService) Revisions(ctx context.Context, arg data.UserCommandRevisionList) ([]data.UserCommandRevision, error) {
+	limit := arg.Limit
+	if limit <= 0 {
+		limit = revisionListDefaultLimit
+	}
+	limit = min(limit, revisionListMaxLimit)
+
+	params := db.CoreUserCommandRevisionListParams{
 		UserID: arg.UserID,
-		Name:   arg.Name,
+		Limit:  limit,
+	}
+	if arg.Name != "" {
+		params.CommandName = &arg.Name
+	}
+	if arg.BeforeID > 0 {
+		params.BeforeID = &arg.BeforeID
+	}
+
+	fromDBs, err := s.store.Query(ctx).CoreUserCommandRevisionList(ctx, params)
+	if err != nil {
+		return nil, s.store.HandleErr(ctx, err)
+	}
+
+	revisions := make([]data.UserCommandRevision, 0, len(fromDBs))
+	for _, fromDB := range fromDBs {
+		revision, err := data.NewUserCommandRevisionFromDB(fromDB)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "cannot decode user command revision", "err", err, "revisionID", fromDB.ID)
+			return nil, apperror.ErrInternal
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+func (s *UserCommandService) AddResponse(ctx context.Context, arg data.UserCommandResponseAdd) (data.UserCommand, error) {
+	arg.Name = cmdvalidate.NormalizeName(arg.Name)
+	arg.Text = strings.TrimSpace(arg.Text)
+
+	errs := data.FieldErrors{}
+	errs.Add("text", cmdvalidate.Text(arg.Text))
+	if err := errs.Err(); err != nil {
+		return data.UserCommand{}, err
+	}
+
+	weight := arg.Weight
+	if weight <= 0 {
+		weight = 1
+	}
+
+	_, after, err := s.commit(ctx, data.RevisionActionUpdate, func(ctx context.Context) (*data.UserCommand, *data.UserCommand, error) {
+		before, err := s.getFromDB(ctx, arg.UserID, arg.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		_, err = s.store.Query(ctx).CoreUserCommandResponseCreate(ctx, db.CoreUserCommandResponseCreateParams{
+			UserID:      arg.UserID,
+			CommandName: arg.Name,
+			Text:        arg.Text,
+			Weight:      weight,
+			Variables:   true,
+		})
+		if err != nil {
+			return nil, nil, s.store.HandleErr(ctx, err)
+		}
+
+		after, err := s.getFromDB(ctx, arg.UserID, arg.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &before, &after, nil
+	})
+	if err != nil {
+		return data.UserCommand{}, err
+	}
+
+	return *after, nil
+}
+
+func (s *UserCommandService) DeleteResponse(ctx context.Context, arg data.UserCommandResponseDelete) (data.UserCommand, error) {
+	arg.Name = cmdvalidate.NormalizeName(arg.Name)
+
+	_, after, err := s.commit(ctx, data.RevisionActionUpdate, func(ctx context.Context) (*data.UserCommand, *data.UserCommand, error) {
+		before, err := s.getFromDB(ctx, arg.UserID, arg.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		_, err = s.store.Query(ctx).CoreUserCommandResponseDelete(ctx, db.CoreUserCommandResponseDeleteParams{
+			UserID:      arg.UserID,
+			CommandName: arg.Name,
+			ID:          arg.ResponseID,
+		})
+		if err != nil {
+			return nil, nil, s.store.HandleErr(ctx, err)
+		}
+
+		after, err := s.getFromDB(ctx, arg.UserID, arg.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &before, &after, nil
+	})
+	if err != nil {
+		return data.UserCommand{}, err
+	}
+
+	return *after, nil
+}
+
+// put creates target, or replaces the command of the same name with it when
+// replace is set, responses included, as a single change with one revision.
+// target is not validated, its caller does that.
+func (s *UserCommandService) put(ctx context.Context, target data.UserCommand, replace bool) (data.UserCommand, error) {
+	if replace {
+		_, after, err := s.commit(ctx, data.RevisionActionUpdate, s.revertChange(target.UserID, target.Name, target))
+		if err != nil {
+			return data.UserCommand{}, err
+		}
+		return *after, nil
+	}
+
+	_, after, err := s.commit(ctx, data.RevisionActionCreate, func(ctx context.Context) (*data.UserCommand, *data.UserCommand, error) {
+		err := s.archiveDeleted(ctx, target.UserID, target.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		_, err = s.store.Query(ctx).CoreUserCommandCreate(ctx, db.CoreUserCommandCreateParams{
+			UserID:       target.UserID,
+			Name:         target.Name,
+			Text:         target.Text,
+			Reply:        target.Reply,
+			Enabled:      target.Enabled,
+			ResponseMode: target.ResponseMode.String(),
+			Cooldown:     target.Cooldown,
+			UserLevel:    target.UserLevel.String(),
+		})
+		if err != nil {
+			return nil, nil, s.store.HandleErr(ctx, err)
+		}
+
+		for _, response := range target.Responses {
+			_, err = s.store.Query(ctx).CoreUserCommandResponseCreate(ctx, db.CoreUserCommandResponseCreateParams{
+				UserID:      target.UserID,
+				CommandName: target.Name,
+				Text:        response.Text,
+				Weight:      max(response.Weight, 1),
+				Variables:   response.Variables,
+			})
+			if err != nil {
+				return nil, nil, s.store.HandleErr(ctx, err)
+			}
+		}
+
+		after, err := s.getFromDB(ctx, target.UserID, target.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, &after, nil
+	})
+	if err != nil {
+		return data.UserCommand{}, err
+	}
+
+	return *after, nil
+}
+
+// userCommandChange mutates a user command inside a transaction and returns
+// its state before and after the change, either of which can be nil.
+type userCommandChange func(ctx context.Context) (before *data.UserCommand, after *data.UserCommand, err error)
+
+// commit runs change in a transaction together with recording its revision,
+// then brings the cache in line with the result.
+func (s *UserCommandService) commit(
+	ctx context.Context,
+	action data.RevisionAction,
+	change userCommandChange,
+) (*data.UserCommand, *data.UserCommand, error) {
+	txCtx, err := s.tx.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer s.tx.Rollback(txCtx)
+
+	before, after, err := change(txCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.recordRevision(txCtx, action, before, after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.tx.Commit(txCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case before == nil:
+		s.index.rename(after.UserID, "", after.Name)
+	case after == nil:
+		s.index.rename(before.UserID, before.Name, "")
+	case before.Name != after.Name:
+		s.index.rename(after.UserID, before.Name, after.Name)
+	}
+
+	// other replicas purge these too once the change notification reaches them
+	if before != nil {
+		s.cachePurge(ctx, before.UserID, before.Name)
+	}
+	if after != nil && (before == nil || after.Name != before.Name) {
+		s.cachePurge(ctx, after.UserID, after.Name)
+	}
+
+	return before, after, nil
+}
+
+func (s *UserCommandService) recordRevision(
+	ctx context.Context,
+	action data.RevisionAction,
+	before *data.UserCommand,
+	after *data.UserCommand,
+) error {
+	params := db.CoreUserCommandRevisionCreateParams{
+		Action: string(action),
+	}
+
+	if before != nil {
+		params.UserID = before.UserID
+		params.CommandName = before.Name
+		params.Before, _ = json.Marshal(before)
+	}
+	if after != nil {
+		params.UserID = after.UserID
+		params.CommandName = after.Name
+		params.After, _ = json.Marshal(after)
+	}
+	if actor := appctx.GetActor(ctx); actor != nil {
+		params.Actor, _ = json.Marshal(actor)
+	}
+
+	_, err := s.store.Query(ctx).CoreUserCommandRevisionCreate(ctx, params)
+	if err != nil {
+		return s.store.HandleErr(ctx, err)
+	}
+
+	return nil
+}
+
+func (s *UserCommandService) deleteChange(userID uuid.UUID, name string) userCommandChange {
+	return func(ctx context.Context) (*data.UserCommand, *data.UserCommand, error) {
+		before, err := s.getFromDB(ctx, userID, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		_, err = s.store.Query(ctx).CoreUserCommandDelete(ctx, db.CoreUserCommandDeleteParams{
+			UserID: userID,
+			Name:   name,
+		})
+		if err != nil {
+			return nil, nil, s.store.HandleErr(ctx, err)
+		}
+
+		return &before, nil, nil
+	}
+}
+
+func (s *UserCommandService) restoreChange(userID uuid.UUID, name string) userCommandChange {
+	return func(ctx context.Context) (*data.UserCommand, *data.UserCommand, error) {
+		fromDB, err := s.store.Query(ctx).CoreUserCommandRestore(ctx, db.CoreUserCommandRestoreParams{
+			UserID: userID,
+			Name:   name,
+		})
+		if err != nil {
+			return nil, nil, s.store.HandleErr(ctx, err)
+		}
+
+		after, err := s.withResponses(ctx, fromDB)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, &after, nil
+	}
+}
+
+// revertChange brings the command called name back to target, including its
+// name and responses.
+func (s *UserCommandService) revertChange(userID uuid.UUID, name string, target data.UserCommand) userCommandChange {
+	return func(ctx context.Context) (*data.UserCommand, *data.UserCommand, error) {
+		before, err := s.getFromDB(ctx, userID, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if target.Name != name {
+			err = s.archiveDeleted(ctx, userID, target.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		responseMode := target.ResponseMode.String()
+		userLevel := target.UserLevel.String()
+		_, err = s.store.Query(ctx).CoreUserCommandUpdate(ctx, db.CoreUserCommandUpdateParams{
+			UserID:       userID,
+			Name:         name,
+			NewName:      &target.Name,
+			Text:         &target.Text,
+			Reply:        &target.Reply,
+			Enabled:      &target.Enabled,
+			ResponseMode: &responseMode,
+			Cooldown:     &target.Cooldown,
+			UserLevel:    &userLevel,
+			Variables:    &target.Variables,
+		})
+		if err != nil {
+			return nil, nil, s.store.HandleErr(ctx, err)
+		}
+
+		_, err = s.store.Query(ctx).CoreUserCommandResponseDeleteByCommand(ctx, db.CoreUserCommandResponseDeleteByCommandParams{
+			UserID:      userID,
+			CommandName: target.Name,
+		})
+		if err != nil {
+			return nil, nil, s.store.HandleErr(ctx, err)
+		}
+
+		for _, response := range target.Responses {
+			_, err = s.store.Query(ctx).CoreUserCommandResponseCreate(ctx, db.CoreUserCommandResponseCreateParams{
+				UserID:      userID,
+				CommandName: target.Name,
+				Text:        response.Text,
+				Weight:      max(response.Weight, 1),
+				Variables:   response.Variables,
+			})
+			if err != nil {
+				return nil, nil, s.store.HandleErr(ctx, err)
+			}
+		}
+
+		after, err := s.getFromDB(ctx, userID, target.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &before, &after, nil
+	}
+}
+
+// archiveDeleted moves a soft deleted command called name out of the way of a
+// new one, keeping it and its responses.
+func (s *UserCommandService) archiveDeleted(ctx context.Context, userID uuid.UUID, name string) error {
+	_, err := s.store.Query(ctx).CoreUserCommandArchiveDeleted(ctx, db.CoreUserCommandArchiveDeletedParams{
+		UserID: userID,
+		Name:   name,
+	})
+	if err != nil {
+		return s.store.HandleErr(ctx, err)
+	}
+	return nil
+}
+
+func (s *UserCommandService) getFromDB(ctx context.Context, userID uuid.UUID, name string) (data.UserCommand, error) {
+	fromDB, err := s.store.Query(ctx).CoreUserCommandGetOne(ctx, db.CoreUserCommandGetOneParams{
+		UserID: userID,
+		Name:   name,
 	})
 	if err != nil {
 		return data.UserCommand{}, s.store.HandleErr(ctx, err)
 	}
 
+	return s.withResponses(ctx, fromDB)
+}
+
+func (s *UserCommandService) withResponses(ctx context.Context, fromDB db.CoreUserCommand) (data.UserCommand, error) {
 	userCommand := data.NewUserCommandFromDB(fromDB)
 
-	err = s.cache.Purge(ctx, getCommandKVKey(userCommand.UserID, userCommand.Name))
+	responses, err := s.store.Query(ctx).CoreUserCommandResponseGetByCommand(ctx, db.CoreUserCommandResponseGetByCommandParams{
+		UserID:      fromDB.UserID,
+		CommandName: fromDB.Name,
+	})
 	if err != nil {
-		s.logger.WarnContext(ctx, "cannot purge cache user command", "err", err)
+		return data.UserCommand{}, s.store.HandleErr(ctx, err)
+	}
+
+	for _, response := range responses {
+		userCommand.Responses = append(userCommand.Responses, data.NewUserCommandResponseFromDB(response))
 	}
 
 	return userCommand, nil
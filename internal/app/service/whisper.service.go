@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/applog"
+	sharedData "github.com/arnokay/arnobot-shared/data"
+	"github.com/arnokay/arnobot-shared/events"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/arnokay/arnobot-shared/topics"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/storage"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+// WhisperService runs the commands that allow it in whispers, in the channel
+// of the broadcaster whose bot was whispered, and whispers the response back.
+type WhisperService struct {
+	mb                *nats.Conn
+	store             storage.Storager
+	cmdManagerService *CmdManagerService
+
+	logger applog.Logger
+}
+
+func NewWhisperService(
+	mb *nats.Conn,
+	store storage.Storager,
+	cmdManagerService *CmdManagerService,
+) *WhisperService {
+	logger := applog.NewServiceLogger("whisper-service")
+
+	return &WhisperService{
+		mb:                mb,
+		store:             store,
+		cmdManagerService: cmdManagerService,
+
+		logger: logger,
+	}
+}
+
+func (s *WhisperService) HandleNewWhisper(ctx context.Context, whisper data.Whisper) error {
+	if whisper.BroadcasterID == "" {
+		s.logger.DebugContext(ctx, "whisper to a bot without a channel", "platform", whisper.Platform, "botID", whisper.BotID)
+		return nil
+	}
+
+	account, err := s.store.Query(ctx).UserPlatformAccountGet(ctx, db.UserPlatformAccountGetParams{
+		Platform:       whisper.Platform,
+		PlatformUserID: whisper.BroadcasterID,
+	})
+	if err != nil {
+		err = s.store.HandleErr(ctx, err)
+		if errors.Is(err, apperror.ErrNotFound) {
+			s.logger.DebugContext(ctx, "whisper to the bot of an unlinked channel", "platform", whisper.Platform, "broadcasterID", whisper.BroadcasterID)
+			return nil
+		}
+		return err
+	}
+
+	role := whisper.ChatterRole
+	switch {
+	case whisper.ChatterID == account.PlatformUserID:
+		role = sharedData.ChatterBroadcaster
+	case role == 0:
+		role = sharedData.ChatterPleb
+	}
+
+	message := parseChatMessage(events.Message{
+		EventCommon: events.EventCommon{
+			UserID:        account.UserID,
+			Platform:      whisper.Platform,
+			BotID:         whisper.BotID,
+			BroadcasterID: account.PlatformUserID,
+		},
+		MessageID:        whisper.MessageID,
+		Message:          whisper.Message,
+		BroadcasterLogin: account.PlatformUserLogin,
+		BroadcasterName:  account.PlatformUserName,
+		ChatterID:        whisper.ChatterID,
+		ChatterLogin:     whisper.ChatterLogin,
+		ChatterName:      whisper.ChatterName,
+		ChatterRole:      role,
+	})
+
+	resolved, err := s.cmdManagerService.ResolvePrivate(ctx, message)
+	if err != nil || resolved == nil {
+		return err
+	}
+
+	s.logger.DebugContext(ctx, "new private command", "whisper", whisper)
+	response, err := resolved.Execute(ctx)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNoAction) {
+			s.logger.DebugContext(ctx, "no action is needed")
+			return nil
+		}
+		return err
+	}
+
+	return s.send(ctx, data.WhisperSend{
+		Platform: whisper.Platform,
+		BotID:    whisper.BotID,
+		ToID:     whisper.ChatterID,
+		Message:  response.Message,
+	})
+}
+
+func (s *WhisperService) send(ctx context.Context, arg data.WhisperSend) error {
+	topic := topics.TopicBuilder(coreTopics.PlatformWhisperSend).
+		Platform(arg.Platform).
+		Build()
+
+	err := service.HandlePublish(ctx, s.mb, s.logger, topic, arg)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "cannot send whisper")
+		return err
+	}
+	return nil
+}
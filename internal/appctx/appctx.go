@@ -0,0 +1,23 @@
+package appctx
+
+import (
+	"context"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+type ctxKey string
+
+const ACTOR_KEY ctxKey = "actor"
+
+func SetActor(ctx context.Context, actor data.Actor) context.Context {
+	return context.WithValue(ctx, ACTOR_KEY, actor)
+}
+
+func GetActor(ctx context.Context) *data.Actor {
+	actor, ok := ctx.Value(ACTOR_KEY).(data.Actor)
+	if !ok {
+		return nil
+	}
+	return &actor
+}
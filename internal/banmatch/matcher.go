@@ -0,0 +1,174 @@
+// Package banmatch finds banned phrases in chat messages, however they are
+// disguised with case, accents, homoglyphs, leetspeak or punctuation.
+package banmatch
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Matcher finds which of a list of phrases a message contains, in one pass
+// over the message whatever the number of phrases.
+//
+// Phrases match whole words, unless a wildcard stands for the rest of the
+// word. Every phrase is indexed by its longest literal part in an
+// Aho–Corasick automaton, and only the phrases whose part is found are
+// checked in full.
+type Matcher struct {
+	nodes   []node
+	phrases []*regexp.Regexp
+}
+
+type node struct {
+	next map[byte]int32
+	fail int32
+	// out lists the phrases whose literal part ends here, including through
+	// the fail links.
+	out []int
+}
+
+// NormalizePattern normalizes a phrase like Normalize, keeping its wildcards.
+// Runs of wildcards are merged into one.
+func NormalizePattern(phrase string) string {
+	pattern := normalize(phrase, true)
+	for strings.Contains(pattern, "**") {
+		pattern = strings.ReplaceAll(pattern, "**", "*")
+	}
+	return pattern
+}
+
+// Literal returns the longest part of a normalized pattern that has to
+// appear as is in a matching message, or an empty string when the pattern
+// is only wildcards.
+func Literal(pattern string) string {
+	var longest string
+	for _, part := range strings.FieldsFunc(pattern, isSeparator) {
+		if len(part) > len(longest) {
+			longest = part
+		}
+	}
+	return longest
+}
+
+func isSeparator(r rune) bool {
+	return r == ' ' || r == Wildcard
+}
+
+// Compile builds the matcher of normalized patterns, skipping the ones that
+// are only wildcards. Match reports the patterns by their index in patterns.
+func Compile(patterns []string) *Matcher {
+	m := &Matcher{
+		nodes:   []node{{}},
+		phrases: make([]*regexp.Regexp, len(patterns)),
+	}
+
+	for i, pattern := range patterns {
+		literal := Literal(pattern)
+		if literal == "" {
+			continue
+		}
+		m.phrases[i] = compilePattern(pattern)
+		m.insert(literal, i)
+	}
+	m.link()
+
+	return m
+}
+
+// compilePattern turns a normalized pattern into a regexp over normalized
+// messages, where a wildcard matches the rest of a word.
+func compilePattern(pattern string) *regexp.Regexp {
+	words := strings.Split(pattern, " ")
+	for i, word := range words {
+		parts := strings.Split(word, string(Wildcard))
+		for j, part := range parts {
+			parts[j] = regexp.QuoteMeta(part)
+		}
+		words[i] = strings.Join(parts, "[^ ]*")
+	}
+	return regexp.MustCompile("(?:^| )" + strings.Join(words, " ") + "(?: |$)")
+}
+
+func (m *Matcher) insert(literal string, phrase int) {
+	current := int32(0)
+	for i := 0; i < len(literal); i++ {
+		next, ok := m.nodes[current].next[literal[i]]
+		if !ok {
+			next = int32(len(m.nodes))
+			m.nodes = append(m.nodes, node{})
+			if m.nodes[current].next == nil {
+				m.nodes[current].next = make(map[byte]int32)
+			}
+			m.nodes[current].next[literal[i]] = next
+		}
+		current = next
+	}
+	m.nodes[current].out = append(m.nodes[current].out, phrase)
+}
+
+// link sets the fail links breadth first, so the link of a node is always
+// set before the ones of its children.
+func (m *Matcher) link() {
+	queue := make([]int32, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for b, child := range m.nodes[current].next {
+			fail := m.nodes[current].fail
+			for {
+				if next, ok := m.nodes[fail].next[b]; ok {
+					m.nodes[child].fail = next
+					break
+				}
+				if fail == 0 {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			m.nodes[child].out = append(m.nodes[child].out, m.nodes[m.nodes[child].fail].out...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// Match returns the index of a pattern the message contains, or -1.
+func (m *Matcher) Match(message string) int {
+	if m == nil || len(m.nodes) == 1 {
+		return -1
+	}
+
+	text := Normalize(message)
+	checked := map[int]bool{}
+
+	current := int32(0)
+	for i := 0; i < len(text); i++ {
+		b := text[i]
+		for {
+			if next, ok := m.nodes[current].next[b]; ok {
+				current = next
+				break
+			}
+			if current == 0 {
+				break
+			}
+			current = m.nodes[current].fail
+		}
+
+		for _, phrase := range m.nodes[current].out {
+			if checked[phrase] {
+				continue
+			}
+			checked[phrase] = true
+			if m.phrases[phrase].MatchString(text) {
+				return phrase
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,127 @@
+package banmatch
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Spam", "spam"},
+		{"  spam,   SPAM!! ", "spam spam"},
+		{"späm", "spam"},
+		{"ѕраm", "spam"},
+		{"ѕρаm", "spam"},
+		{"5p4m", "spam"},
+		{"h3ll0", "heiio"},
+		{"$p@m", "spam"},
+		{"spam!", "spam"},
+		{"sp!m", "spim"},
+		{"spam !!", "spam"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.text); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePattern(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{"Spam*", "spam*"},
+		{"sp***m", "sp*m"},
+		{"*", "*"},
+		{"buy ch3ap *", "buy cheap *"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizePattern(tt.phrase); got != tt.want {
+			t.Errorf("NormalizePattern(%q) = %q, want %q", tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"spam", "spam"},
+		{"sp*mmer", "mmer"},
+		{"buy cheap followers", "followers"},
+		{"*", ""},
+		{"* *", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Literal(tt.pattern); got != tt.want {
+			t.Errorf("Literal(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func compile(phrases ...string) *Matcher {
+	patterns := make([]string, len(phrases))
+	for i, phrase := range phrases {
+		patterns[i] = NormalizePattern(phrase)
+	}
+	return Compile(patterns)
+}
+
+func TestMatcherMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		phrases []string
+		message string
+		want    int
+	}{
+		{"exact", []string{"spam"}, "spam", 0},
+		{"in a sentence", []string{"spam"}, "no spam here", 0},
+		{"case", []string{"spam"}, "SPAM", 0},
+		{"accents", []string{"spam"}, "spåm", 0},
+		{"leetspeak", []string{"spam"}, "5p4m", 0},
+		{"leet symbols", []string{"spam"}, "$p@m", 0},
+		{"cyrillic homoglyphs", []string{"spam"}, "ѕраm", 0},
+		{"greek homoglyphs", []string{"spam"}, "ѕρаm", 0},
+		{"punctuation between words", []string{"buy followers"}, "buy... followers", 0},
+		{"trailing exclamation", []string{"spam"}, "spam!", 0},
+		{"trailing exclamations", []string{"spam"}, "so much spam!!!", 0},
+		{"exclamation in word", []string{"spim"}, "sp!m", 0},
+		{"start of word", []string{"spam"}, "spammer", -1},
+		{"end of word", []string{"spam"}, "antispam", -1},
+		{"inside word", []string{"ass"}, "classic", -1},
+		{"wildcard suffix", []string{"spam*"}, "spammer", 0},
+		{"wildcard prefix", []string{"*spam"}, "antispam", 0},
+		{"wildcard middle", []string{"sp*m"}, "spoom", 0},
+		{"wildcard empty", []string{"spam*"}, "spam", 0},
+		{"wildcard stops at word", []string{"spam*"}, "spa mmer", -1},
+		{"wildcard in phrase", []string{"buy * followers"}, "buy cheap followers", 0},
+		{"wildcard is one word", []string{"buy * followers"}, "buy many cheap followers", -1},
+		{"only wildcards", []string{"*"}, "anything", -1},
+		{"second phrase", []string{"spam", "scam"}, "what a scam", 1},
+		{"shared literal", []string{"spam bot", "spam"}, "spam", 1},
+		{"no match", []string{"spam", "scam"}, "hello chat", -1},
+		{"empty message", []string{"spam"}, "", -1},
+		{"no phrases", nil, "spam", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compile(tt.phrases...).Match(tt.message); got != tt.want {
+				t.Errorf("Match(%q) with %q = %d, want %d", tt.message, tt.phrases, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcherMatchNil(t *testing.T) {
+	var m *Matcher
+	if got := m.Match("spam"); got != -1 {
+		t.Errorf("nil Matcher Match = %d, want -1", got)
+	}
+}
@@ -0,0 +1,97 @@
+package banmatch
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Wildcard in a phrase stands for any letters or digits within a word.
+const Wildcard = '*'
+
+// homoglyphs maps letters of other scripts to the latin letters they pass
+// for.
+var homoglyphs = map[rune]rune{
+	// cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h',
+	'о': 'o', 'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'ѕ': 's',
+	'і': 'i', 'ї': 'i', 'ј': 'j', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+	// greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v',
+	'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'ω': 'w',
+}
+
+// leetspeak maps the digits written for letters. The letter l is folded with
+// 1 and i, as the three stand for each other.
+var leetspeak = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '6': 'g', '7': 't',
+	'8': 'b', '9': 'g', 'l': 'i',
+}
+
+// leetSymbols are the punctuation written for letters. They are only read as
+// letters when a word goes on after them, so "spam!" is not "spami".
+var leetSymbols = map[rune]rune{
+	'@': 'a', '$': 's', '!': 'i', '|': 'i', '+': 't', '€': 'e', '£': 'e',
+}
+
+// Normalize folds case, accents, homoglyphs and leetspeak, and turns every
+// run of other characters into a single space, so a message and the phrases
+// banned in it compare equal however they are disguised.
+func Normalize(text string) string {
+	return normalize(text, false)
+}
+
+func normalize(text string, keepWildcard bool) string {
+	runes := make([]rune, 0, len(text))
+	for _, r := range norm.NFKD.String(text) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		r = unicode.ToLower(r)
+		if glyph, ok := homoglyphs[r]; ok {
+			r = glyph
+		}
+		if letter, ok := leetspeak[r]; ok {
+			r = letter
+		}
+		runes = append(runes, r)
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+
+	space := true
+	for i, r := range runes {
+		if _, ok := leetSymbols[r]; ok && continuesWord(runes[i+1:], keepWildcard) {
+			r = leetSymbols[r]
+		}
+
+		if isWordRune(r, keepWildcard) {
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		if !space {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), " ")
+}
+
+// continuesWord reports whether a word goes on after the leet symbols at the
+// start of rest.
+func continuesWord(rest []rune, keepWildcard bool) bool {
+	for _, r := range rest {
+		if _, ok := leetSymbols[r]; !ok {
+			return isWordRune(r, keepWildcard)
+		}
+	}
+	return false
+}
+
+func isWordRune(r rune, keepWildcard bool) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || (keepWildcard && r == Wildcard)
+}
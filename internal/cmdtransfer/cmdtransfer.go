@@ -0,0 +1,112 @@
+// Package cmdtransfer converts user commands from and to the export formats of
+// this bot and of other chat bots.
+package cmdtransfer
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+var ErrUnknownFormat = errors.New("unknown transfer format")
+
+// Command is a user command decoded from an export.
+type Command struct {
+	Name         string
+	Text         string
+	Reply        bool
+	Enabled      bool
+	ResponseMode data.ResponseMode
+	Responses    []data.UserCommandResponse
+	// Cooldown and UserLevel are nil when the source does not set them.
+	Cooldown  *int32
+	UserLevel *data.UserLevel
+	// Warnings describe what could not be carried over from the source.
+	Warnings []string
+}
+
+func Decode(format data.TransferFormat, content string) ([]Command, error) {
+	switch format {
+	case data.TransferFormatNative:
+		return decodeNative(content)
+	case data.TransferFormatNightbot:
+		return decodeNightbot(content)
+	case data.TransferFormatStreamElements:
+		return decodeStreamElements(content)
+	case data.TransferFormatStreamlabs:
+		return decodeStreamlabs(content)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// Encode writes commands in format, warning about every command that loses
+// something on the way.
+func Encode(format data.TransferFormat, commands []data.UserCommand) (string, []data.UserCommandExportWarning, error) {
+	var content string
+	var err error
+	var support formatSupport
+
+	switch format {
+	case data.TransferFormatNative:
+		content, err = encodeNative(commands)
+		support = formatSupport{responses: true, disabled: true, reply: true}
+	case data.TransferFormatNightbot:
+		content, err = encodeNightbot(commands)
+	case data.TransferFormatStreamElements:
+		content, err = encodeStreamElements(commands)
+		support = formatSupport{disabled: true, reply: true}
+	case data.TransferFormatStreamlabs:
+		content, err = encodeStreamlabs(commands)
+		support = formatSupport{disabled: true}
+	default:
+		return "", nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	var warnings []data.UserCommandExportWarning
+	for _, command := range commands {
+		if lost := support.lost(command); len(lost) > 0 {
+			warnings = append(warnings, data.UserCommandExportWarning{Name: command.Name, Warnings: lost})
+		}
+	}
+
+	return content, warnings, nil
+}
+
+// formatSupport tells which settings of a command an export format holds,
+// beyond the ones all of them do.
+type formatSupport struct {
+	responses bool
+	disabled  bool
+	reply     bool
+}
+
+func (f formatSupport) lost(command data.UserCommand) []string {
+	var lost []string
+	if !f.responses && len(command.Responses) > 0 {
+		lost = append(lost, strconv.Itoa(len(command.Responses))+" extra responses are not carried over")
+	}
+	if !f.disabled && !command.Enabled {
+		lost = append(lost, "it is exported enabled, as the format has no disabled commands")
+	}
+	if !f.reply && command.Reply {
+		lost = append(lost, "replying is not carried over")
+	}
+	return lost
+}
+
+// withPrefix makes sure an imported name is invoked with the command prefix,
+// as some bots store names without it.
+func withPrefix(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.HasPrefix(name, cmdtypes.CommandPrefix) {
+		return name
+	}
+	return cmdtypes.CommandPrefix + name
+}
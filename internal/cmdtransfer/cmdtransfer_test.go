@@ -0,0 +1,266 @@
+package cmdtransfer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+func TestTranslationToNative(t *testing.T) {
+	tests := []struct {
+		name        string
+		translation translation
+		text        string
+		want        string
+		warnings    int
+	}{
+		{"nightbot", nightbotVars, "hi $(user), $(touser) in $(channel)", "hi {user}, {touser} in {channel}", 0},
+		{"nightbot args", nightbotVars, "$(query) and $(1) $(9)", "{args} and {1} {9}", 0},
+		{"nightbot case", nightbotVars, "$(USER)", "{user}", 0},
+		{"nightbot unsupported", nightbotVars, "$(urlfetch https://example.com) $(0) $(10)", "$(urlfetch https://example.com) $(0) $(10)", 3},
+		{"streamelements", streamElementsVars, "${user} ${sender.name} ${touser} ${channel.name}", "{user} {user} {touser} {channel}", 0},
+		{"streamelements args", streamElementsVars, "${1:} ${0:} ${2}", "{args} {args} {2}", 0},
+		{"streamelements unsupported", streamElementsVars, "${random.pick 'a' 'b'}", "${random.pick 'a' 'b'}", 1},
+		{"streamlabs", streamlabsVars, "$username $target $streamer", "{user} {touser} {channel}", 0},
+		{"streamlabs args", streamlabsVars, "$msg $arg1 $arg9", "{args} {1} {9}", 0},
+		{"streamlabs unsupported", streamlabsVars, "$count $arg0", "$count $arg0", 2},
+		{"no variables", nightbotVars, "plain {user} text", "plain {user} text", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, warnings := tt.translation.toNative(tt.text)
+			if got != tt.want || len(warnings) != tt.warnings {
+				t.Errorf("toNative(%q) = %q, %q, want %q and %d warnings", tt.text, got, warnings, tt.want, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestTranslationFromNative(t *testing.T) {
+	tests := []struct {
+		name        string
+		translation translation
+		text        string
+		variables   bool
+		want        string
+	}{
+		{"nightbot", nightbotVars, "{user} {touser} {channel} {args} {1}", true, "$(user) $(touser) $(channel) $(query) $(1)"},
+		{"streamelements", streamElementsVars, "{user} {touser} {channel} {args} {1}", true, "${user} ${touser} ${channel} ${1:} ${1}"},
+		{"streamlabs", streamlabsVars, "{user} {touser} {channel} {args} {1}", true, "$user $touser $channel $msg $arg1"},
+		{"not a variable", nightbotVars, "{users} {0} {10}", true, "{users} {0} {10}"},
+		{"without variables", nightbotVars, "{user} {args}", false, "{user} {args}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.translation.fromNative(tt.text, tt.variables); got != tt.want {
+				t.Errorf("fromNative(%q, %v) = %q, want %q", tt.text, tt.variables, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	level := func(level data.UserLevel) *data.UserLevel { return &level }
+	cooldown := func(seconds int32) *int32 { return &seconds }
+
+	tests := []struct {
+		name    string
+		format  data.TransferFormat
+		content string
+		want    []Command
+	}{
+		{
+			name:   "nightbot",
+			format: data.TransferFormatNightbot,
+			content: `{"_total": 2, "commands": [
+				{"name": "!hi", "message": "hi $(user)", "coolDown": 5, "userLevel": "everyone"},
+				{"name": "lurk", "message": "$(user) lurks", "coolDown": 30, "userLevel": "moderator"}
+			]}`,
+			want: []Command{
+				{Name: "!hi", Text: "hi {user}", Enabled: true, Cooldown: cooldown(5), UserLevel: level(data.UserLevelEveryone)},
+				{Name: "!lurk", Text: "{user} lurks", Enabled: true, Cooldown: cooldown(30), UserLevel: level(data.UserLevelModerator)},
+			},
+		},
+		{
+			name:    "nightbot list",
+			format:  data.TransferFormatNightbot,
+			content: `[{"name": "!hi", "message": "hi", "coolDown": 5, "userLevel": "admin"}]`,
+			want: []Command{
+				{Name: "!hi", Text: "hi", Enabled: true, Cooldown: cooldown(5), Warnings: []string{"user level admin is not carried over"}},
+			},
+		},
+		{
+			name:   "streamelements",
+			format: data.TransferFormatStreamElements,
+			content: `[
+				{"command": "hi", "reply": "hi ${user}", "enabled": false, "aliases": ["hello"], "cooldown": {"user": 0, "global": 5}, "accessLevel": 250, "type": "reply"},
+				{"command": "dice", "reply": "${random.1-6}", "cooldown": {"user": 10, "global": 0}, "accessLevel": 100, "type": "say"}
+			]`,
+			want: []Command{
+				{
+					Name: "!hi", Text: "hi {user}", Reply: true, Enabled: false, Cooldown: cooldown(5), UserLevel: level(data.UserLevelSub),
+					Warnings: []string{"aliases hello are not carried over"},
+				},
+				{
+					Name: "!dice", Text: "${random.1-6}", Enabled: true, Cooldown: cooldown(0), UserLevel: level(data.UserLevelEveryone),
+					Warnings: []string{"unsupported variable ${random.1-6}", "user cooldown of 10s is not carried over"},
+				},
+			},
+		},
+		{
+			name:   "streamlabs",
+			format: data.TransferFormatStreamlabs,
+			content: "Command,Permission,Response,Cooldown,Enabled\n" +
+				"!hi,Everyone,hi $username,5,True\n" +
+				"!so,Moderator,check out $arg1,,False\n",
+			want: []Command{
+				{Name: "!hi", Text: "hi {user}", Enabled: true, Cooldown: cooldown(5), UserLevel: level(data.UserLevelEveryone)},
+				{Name: "!so", Text: "check out {1}", Enabled: false, UserLevel: level(data.UserLevelModerator)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.format, tt.content)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Decode returned %d commands, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !equalCommands(got[i], tt.want[i]) {
+					t.Errorf("command %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeStreamlabsMissingColumn(t *testing.T) {
+	_, err := Decode(data.TransferFormatStreamlabs, "Command,Permission\n!hi,Everyone\n")
+	if err == nil {
+		t.Error("Decode without a Response column did not fail")
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	commands := []data.UserCommand{
+		{Name: "!hi", Text: "hi {user}, {args}", Enabled: true, Cooldown: 5, UserLevel: data.UserLevelSub, Variables: true},
+		{Name: "!mods", Text: "{1} is a mod", Enabled: true, Cooldown: 30, UserLevel: data.UserLevelModerator, Variables: true},
+	}
+
+	for _, format := range []data.TransferFormat{
+		data.TransferFormatNative,
+		data.TransferFormatNightbot,
+		data.TransferFormatStreamElements,
+		data.TransferFormatStreamlabs,
+	} {
+		t.Run(string(format), func(t *testing.T) {
+			content, warnings, err := Encode(format, commands)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			if len(warnings) != 0 {
+				t.Errorf("Encode warnings = %+v, want none", warnings)
+			}
+
+			decoded, err := Decode(format, content)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if len(decoded) != len(commands) {
+				t.Fatalf("Decode returned %d commands, want %d", len(decoded), len(commands))
+			}
+			for i, command := range commands {
+				got := decoded[i]
+				if got.Name != command.Name || got.Text != command.Text || !got.Enabled ||
+					got.Cooldown == nil || *got.Cooldown != command.Cooldown ||
+					got.UserLevel == nil || *got.UserLevel != command.UserLevel {
+					t.Errorf("command %d = %+v, want %+v", i, got, command)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeWarnings(t *testing.T) {
+	commands := []data.UserCommand{
+		{Name: "!plain", Text: "hi", Enabled: true},
+		{
+			Name:      "!rich",
+			Text:      "hi",
+			Reply:     true,
+			Enabled:   false,
+			Responses: []data.UserCommandResponse{{Text: "hello", Weight: 1}, {Text: "hey", Weight: 1}},
+		},
+	}
+
+	tests := []struct {
+		format data.TransferFormat
+		want   []string
+	}{
+		{data.TransferFormatNative, nil},
+		{data.TransferFormatNightbot, []string{
+			"2 extra responses are not carried over",
+			"it is exported enabled, as the format has no disabled commands",
+			"replying is not carried over",
+		}},
+		{data.TransferFormatStreamElements, []string{
+			"2 extra responses are not carried over",
+		}},
+		{data.TransferFormatStreamlabs, []string{
+			"2 extra responses are not carried over",
+			"replying is not carried over",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			_, warnings, err := Encode(tt.format, commands)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			if tt.want == nil {
+				if len(warnings) != 0 {
+					t.Errorf("Encode warnings = %+v, want none", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || warnings[0].Name != "!rich" || !slices.Equal(warnings[0].Warnings, tt.want) {
+				t.Errorf("Encode warnings = %+v, want %q for !rich", warnings, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := Decode("yaml", ""); err != ErrUnknownFormat {
+		t.Errorf("Decode error = %v, want ErrUnknownFormat", err)
+	}
+	if _, _, err := Encode("yaml", nil); err != ErrUnknownFormat {
+		t.Errorf("Encode error = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func equalCommands(a, b Command) bool {
+	return a.Name == b.Name &&
+		a.Text == b.Text &&
+		a.Reply == b.Reply &&
+		a.Enabled == b.Enabled &&
+		a.ResponseMode == b.ResponseMode &&
+		equalPointers(a.Cooldown, b.Cooldown) &&
+		equalPointers(a.UserLevel, b.UserLevel) &&
+		slices.Equal(a.Warnings, b.Warnings)
+}
+
+func equalPointers[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,89 @@
+package cmdtransfer
+
+import (
+	"strings"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+// levelNames maps the user level names of another bot to ours; export picks
+// the first name listed for each of our levels.
+type levelNames []struct {
+	name  string
+	level data.UserLevel
+}
+
+var nightbotLevels = levelNames{
+	{"everyone", data.UserLevelEveryone},
+	{"subscriber", data.UserLevelSub},
+	{"twitch_vip", data.UserLevelVIP},
+	{"regular", data.UserLevelVIP},
+	{"moderator", data.UserLevelModerator},
+	{"owner", data.UserLevelBroadcaster},
+}
+
+var streamlabsLevels = levelNames{
+	{"Everyone", data.UserLevelEveryone},
+	{"Subscriber", data.UserLevelSub},
+	{"VIP", data.UserLevelVIP},
+	{"VIP Exclusive", data.UserLevelVIP},
+	{"Moderator", data.UserLevelModerator},
+	{"Editor", data.UserLevelModerator},
+	{"Caster", data.UserLevelBroadcaster},
+}
+
+// toNative returns nil for an empty name, and a warning with nil for an
+// unknown one.
+func (l levelNames) toNative(name string) (*data.UserLevel, []string) {
+	if name == "" {
+		return nil, nil
+	}
+	for _, known := range l {
+		if strings.EqualFold(known.name, name) {
+			level := known.level
+			return &level, nil
+		}
+	}
+	return nil, []string{"user level " + name + " is not carried over"}
+}
+
+func (l levelNames) fromNative(level data.UserLevel) string {
+	for _, known := range l {
+		if known.level == level {
+			return known.name
+		}
+	}
+	return l[0].name
+}
+
+// streamElementsLevels maps the lowest StreamElements accessLevel of each of
+// our levels, in ascending order.
+var streamElementsLevels = []struct {
+	accessLevel int
+	level       data.UserLevel
+}{
+	{streamElementsEveryone, data.UserLevelEveryone},
+	{250, data.UserLevelSub},
+	{400, data.UserLevelVIP},
+	{500, data.UserLevelModerator},
+	{1500, data.UserLevelBroadcaster},
+}
+
+func streamElementsLevelToNative(accessLevel int) data.UserLevel {
+	level := data.UserLevelEveryone
+	for _, known := range streamElementsLevels {
+		if accessLevel >= known.accessLevel {
+			level = known.level
+		}
+	}
+	return level
+}
+
+func streamElementsLevelFromNative(level data.UserLevel) int {
+	for _, known := range streamElementsLevels {
+		if known.level == level {
+			return known.accessLevel
+		}
+	}
+	return streamElementsEveryone
+}
@@ -0,0 +1,101 @@
+package cmdtransfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+// nativeVersion is bumped whenever nativeDocument changes incompatibly.
+const nativeVersion = 1
+
+type nativeDocument struct {
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exportedAt"`
+	Commands   []nativeCommand `json:"commands"`
+}
+
+type nativeCommand struct {
+	Name         string            `json:"name"`
+	Text         string            `json:"text"`
+	Reply        bool              `json:"reply"`
+	Enabled      *bool             `json:"enabled"`
+	ResponseMode data.ResponseMode `json:"responseMode,omitempty"`
+	Cooldown     *int32            `json:"cooldown,omitempty"`
+	UserLevel    *data.UserLevel   `json:"userLevel,omitempty"`
+	Responses    []nativeResponse  `json:"responses,omitempty"`
+}
+
+type nativeResponse struct {
+	Text   string `json:"text"`
+	Weight int32  `json:"weight"`
+}
+
+func decodeNative(content string) ([]Command, error) {
+	var document nativeDocument
+	err := json.Unmarshal([]byte(content), &document)
+	if err != nil {
+		return nil, err
+	}
+
+	if document.Version < 1 || document.Version > nativeVersion {
+		return nil, fmt.Errorf("unsupported export version %d", document.Version)
+	}
+
+	commands := make([]Command, 0, len(document.Commands))
+	for _, fromDoc := range document.Commands {
+		command := Command{
+			Name:         fromDoc.Name,
+			Text:         fromDoc.Text,
+			Reply:        fromDoc.Reply,
+			Enabled:      fromDoc.Enabled == nil || *fromDoc.Enabled,
+			ResponseMode: fromDoc.ResponseMode,
+			Cooldown:     fromDoc.Cooldown,
+			UserLevel:    fromDoc.UserLevel,
+		}
+		for _, response := range fromDoc.Responses {
+			command.Responses = append(command.Responses, data.UserCommandResponse{
+				Text:   response.Text,
+				Weight: response.Weight,
+			})
+		}
+		commands = append(commands, command)
+	}
+
+	return commands, nil
+}
+
+func encodeNative(commands []data.UserCommand) (string, error) {
+	document := nativeDocument{
+		Version:    nativeVersion,
+		ExportedAt: time.Now().UTC(),
+		Commands:   make([]nativeCommand, 0, len(commands)),
+	}
+
+	for _, command := range commands {
+		enabled := command.Enabled
+		cooldown := command.Cooldown
+		userLevel := command.UserLevel
+		toDoc := nativeCommand{
+			Name:         command.Name,
+			Text:         command.Text,
+			Reply:        command.Reply,
+			Enabled:      &enabled,
+			ResponseMode: command.ResponseMode,
+			Cooldown:     &cooldown,
+			UserLevel:    &userLevel,
+		}
+		for _, response := range command.Responses {
+			toDoc.Responses = append(toDoc.Responses, nativeResponse{
+				Text:   response.Text,
+				Weight: response.Weight,
+			})
+		}
+		document.Commands = append(document.Commands, toDoc)
+	}
+
+	b, err := json.MarshalIndent(document, "", "  ")
+	return string(b), err
+}
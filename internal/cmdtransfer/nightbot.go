@@ -0,0 +1,78 @@
+package cmdtransfer
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+// nightbotCommand mirrors a command of the Nightbot commands API, which is what
+// their dashboard exports.
+type nightbotCommand struct {
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+	CoolDown  int    `json:"coolDown"`
+	UserLevel string `json:"userLevel"`
+	Count     int    `json:"count"`
+}
+
+type nightbotDocument struct {
+	Total    int               `json:"_total"`
+	Commands []nightbotCommand `json:"commands"`
+}
+
+func decodeNightbot(content string) ([]Command, error) {
+	var fromDocs []nightbotCommand
+
+	// accept both the full API response and a bare list of commands
+	if strings.HasPrefix(strings.TrimSpace(content), "[") {
+		err := json.Unmarshal([]byte(content), &fromDocs)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var document nightbotDocument
+		err := json.Unmarshal([]byte(content), &document)
+		if err != nil {
+			return nil, err
+		}
+		fromDocs = document.Commands
+	}
+
+	commands := make([]Command, 0, len(fromDocs))
+	for _, fromDoc := range fromDocs {
+		text, warnings := nightbotVars.toNative(fromDoc.Message)
+		userLevel, levelWarnings := nightbotLevels.toNative(fromDoc.UserLevel)
+		cooldown := int32(fromDoc.CoolDown)
+		commands = append(commands, Command{
+			Name:      withPrefix(fromDoc.Name),
+			Text:      text,
+			Enabled:   true,
+			Cooldown:  &cooldown,
+			UserLevel: userLevel,
+			Warnings:  append(warnings, levelWarnings...),
+		})
+	}
+
+	return commands, nil
+}
+
+func encodeNightbot(commands []data.UserCommand) (string, error) {
+	document := nightbotDocument{
+		Total:    len(commands),
+		Commands: make([]nightbotCommand, 0, len(commands)),
+	}
+
+	for _, command := range commands {
+		document.Commands = append(document.Commands, nightbotCommand{
+			Name:      command.Name,
+			Message:   nightbotVars.fromNative(command.Text, command.Variables),
+			CoolDown:  int(command.Cooldown),
+			UserLevel: nightbotLevels.fromNative(command.UserLevel),
+		})
+	}
+
+	b, err := json.MarshalIndent(document, "", "  ")
+	return string(b), err
+}
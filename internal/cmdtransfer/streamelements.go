@@ -0,0 +1,85 @@
+package cmdtransfer
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+// streamElementsEveryone is the accessLevel StreamElements uses for commands
+// available to all chatters.
+const streamElementsEveryone = 100
+
+// streamElementsCommand mirrors a command of the StreamElements bot commands
+// API, which their export returns as a list.
+type streamElementsCommand struct {
+	Command  string   `json:"command"`
+	Reply    string   `json:"reply"`
+	Enabled  *bool    `json:"enabled"`
+	Aliases  []string `json:"aliases"`
+	Cooldown struct {
+		User   int `json:"user"`
+		Global int `json:"global"`
+	} `json:"cooldown"`
+	AccessLevel int    `json:"accessLevel"`
+	Type        string `json:"type"`
+}
+
+func decodeStreamElements(content string) ([]Command, error) {
+	var fromDocs []streamElementsCommand
+	err := json.Unmarshal([]byte(content), &fromDocs)
+	if err != nil {
+		return nil, err
+	}
+
+	commands := make([]Command, 0, len(fromDocs))
+	for _, fromDoc := range fromDocs {
+		text, warnings := streamElementsVars.toNative(fromDoc.Reply)
+		if fromDoc.Cooldown.User > 0 {
+			warnings = append(warnings, "user cooldown of "+strconv.Itoa(fromDoc.Cooldown.User)+"s is not carried over")
+		}
+		if len(fromDoc.Aliases) > 0 {
+			warnings = append(warnings, "aliases "+strings.Join(fromDoc.Aliases, ", ")+" are not carried over")
+		}
+		cooldown := int32(fromDoc.Cooldown.Global)
+		userLevel := streamElementsLevelToNative(fromDoc.AccessLevel)
+		commands = append(commands, Command{
+			Name:      withPrefix(fromDoc.Command),
+			Text:      text,
+			Reply:     fromDoc.Type == "reply",
+			Enabled:   fromDoc.Enabled == nil || *fromDoc.Enabled,
+			Cooldown:  &cooldown,
+			UserLevel: &userLevel,
+			Warnings:  warnings,
+		})
+	}
+
+	return commands, nil
+}
+
+func encodeStreamElements(commands []data.UserCommand) (string, error) {
+	toDocs := make([]streamElementsCommand, 0, len(commands))
+
+	for _, command := range commands {
+		enabled := command.Enabled
+		toDoc := streamElementsCommand{
+			Command:     strings.TrimPrefix(command.Name, cmdtypes.CommandPrefix),
+			Reply:       streamElementsVars.fromNative(command.Text, command.Variables),
+			Enabled:     &enabled,
+			Aliases:     []string{},
+			AccessLevel: streamElementsLevelFromNative(command.UserLevel),
+			Type:        "say",
+		}
+		if command.Reply {
+			toDoc.Type = "reply"
+		}
+		toDoc.Cooldown.Global = int(command.Cooldown)
+		toDocs = append(toDocs, toDoc)
+	}
+
+	b, err := json.MarshalIndent(toDocs, "", "  ")
+	return string(b), err
+}
@@ -0,0 +1,112 @@
+package cmdtransfer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/arnokay/arnobot-core/internal/data"
+)
+
+// Streamlabs Chatbot exports commands as a spreadsheet; this reads and writes
+// it saved as CSV, looking columns up by their header.
+var streamlabsHeader = []string{
+	"Command", "Permission", "Info", "Group", "Response", "Cooldown", "UserCooldown", "Cost", "Count", "Usage", "Enabled",
+}
+
+func decodeStreamlabs(content string) ([]Command, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	if _, ok := columns["command"]; !ok {
+		return nil, errors.New("missing Command column")
+	}
+	if _, ok := columns["response"]; !ok {
+		return nil, errors.New("missing Response column")
+	}
+
+	commands := make([]Command, 0, len(records)-1)
+	for _, record := range records[1:] {
+		text, warnings := streamlabsVars.toNative(field(record, "response"))
+		userLevel, levelWarnings := streamlabsLevels.toNative(field(record, "permission"))
+		warnings = append(warnings, levelWarnings...)
+		var cooldown *int32
+		if seconds, err := strconv.ParseInt(field(record, "cooldown"), 10, 32); err == nil {
+			value := int32(seconds)
+			cooldown = &value
+		}
+		enabled, err := strconv.ParseBool(field(record, "enabled"))
+		if err != nil {
+			enabled = true
+		}
+		commands = append(commands, Command{
+			Name:      withPrefix(field(record, "command")),
+			Text:      text,
+			Enabled:   enabled,
+			Cooldown:  cooldown,
+			UserLevel: userLevel,
+			Warnings:  warnings,
+		})
+	}
+
+	return commands, nil
+}
+
+func encodeStreamlabs(commands []data.UserCommand) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	err := writer.Write(streamlabsHeader)
+	if err != nil {
+		return "", err
+	}
+
+	for _, command := range commands {
+		enabled := "False"
+		if command.Enabled {
+			enabled = "True"
+		}
+		err = writer.Write([]string{
+			command.Name,
+			streamlabsLevels.fromNative(command.UserLevel),
+			"",
+			"GENERAL",
+			streamlabsVars.fromNative(command.Text, command.Variables),
+			strconv.Itoa(int(command.Cooldown)),
+			"0",
+			"0",
+			"0",
+			"Stream Chat",
+			enabled,
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return buf.String(), writer.Error()
+}
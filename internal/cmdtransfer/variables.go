@@ -0,0 +1,131 @@
+package cmdtransfer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/arnokay/arnobot-core/internal/cmdvars"
+)
+
+// translation maps the variables of another bot onto ours. Variables without
+// a counterpart are kept verbatim and reported as warnings.
+type translation struct {
+	pattern *regexp.Regexp
+	// lookup returns our variable name for the captured foreign name.
+	lookup func(name string) (string, bool)
+	// format writes one of our variable names in the foreign syntax.
+	format func(name string) string
+}
+
+func (t translation) toNative(text string) (string, []string) {
+	var warnings []string
+
+	translated := t.pattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := t.pattern.FindStringSubmatch(match)[1]
+		native, ok := t.lookup(strings.ToLower(name))
+		if !ok {
+			warnings = append(warnings, "unsupported variable "+match)
+			return match
+		}
+		return cmdvars.Var(native)
+	})
+
+	return translated, warnings
+}
+
+// fromNative keeps the text of a command without variables as is, as its
+// braces are sent verbatim.
+func (t translation) fromNative(text string, variables bool) string {
+	if !variables {
+		return text
+	}
+	return cmdvars.Pattern.ReplaceAllStringFunc(text, func(match string) string {
+		return t.format(match[1 : len(match)-1])
+	})
+}
+
+func argIndex(name, prefix string) (string, bool) {
+	n, err := strconv.Atoi(strings.TrimPrefix(name, prefix))
+	if err != nil || !strings.HasPrefix(name, prefix) || n < 1 || n > 9 {
+		return "", false
+	}
+	return strconv.Itoa(n), true
+}
+
+func isArgIndex(name string) bool {
+	return len(name) == 1 && name[0] >= '1' && name[0] <= '9'
+}
+
+var nightbotVars = translation{
+	pattern: regexp.MustCompile(`\$\(([\w.]+)[^)]*\)`),
+	lookup: func(name string) (string, bool) {
+		switch name {
+		case "user":
+			return cmdvars.User, true
+		case "touser":
+			return cmdvars.ToUser, true
+		case "channel":
+			return cmdvars.Channel, true
+		case "query":
+			return cmdvars.Args, true
+		}
+		return argIndex(name, "")
+	},
+	format: func(name string) string {
+		if name == cmdvars.Args {
+			return "$(query)"
+		}
+		return "$(" + name + ")"
+	},
+}
+
+var streamElementsVars = translation{
+	pattern: regexp.MustCompile(`\$\{([^}]+)\}`),
+	lookup: func(name string) (string, bool) {
+		switch name {
+		case "user", "sender", "user.name", "sender.name":
+			return cmdvars.User, true
+		case "touser":
+			return cmdvars.ToUser, true
+		case "channel", "channel.name":
+			return cmdvars.Channel, true
+		case "1:", "0:":
+			return cmdvars.Args, true
+		}
+		return argIndex(name, "")
+	},
+	format: func(name string) string {
+		if name == cmdvars.Args {
+			return "${1:}"
+		}
+		return "${" + name + "}"
+	},
+}
+
+var streamlabsVars = translation{
+	pattern: regexp.MustCompile(`\$(\w+)`),
+	lookup: func(name string) (string, bool) {
+		switch name {
+		case "user", "username":
+			return cmdvars.User, true
+		case "touser", "target":
+			return cmdvars.ToUser, true
+		case "channel", "streamer":
+			return cmdvars.Channel, true
+		case "msg":
+			return cmdvars.Args, true
+		}
+		return argIndex(name, "arg")
+	},
+	format: func(name string) string {
+		switch {
+		case name == cmdvars.Args:
+			return "$msg"
+		case isArgIndex(name):
+			return "$arg" + name
+		default:
+			return "$" + name
+		}
+	},
+}
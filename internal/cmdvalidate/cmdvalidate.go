@@ -0,0 +1,137 @@
+// Package cmdvalidate checks the names and texts of user commands before they
+// are stored.
+package cmdvalidate
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+)
+
+const (
+	// MaxNameLength counts the prefix. The name column is wider, to keep
+	// deleted commands under an archived name.
+	MaxNameLength = 50
+	// MaxTextLength is the longest chat message the platforms accept.
+	MaxTextLength = 500
+	// MaxCooldown is in seconds.
+	MaxCooldown = 3600
+
+	// MaxPatternLength bounds the source of a trigger pattern and
+	// MaxPatternProgram the size of its compiled regular expression, which
+	// is what the time to match a message grows with.
+	MaxPatternLength  = 200
+	MaxPatternProgram = 1000
+)
+
+// NormalizeName trims the name and makes sure it has exactly one command
+// prefix, so "x", "!x" and "!!x" all become "!x".
+func NormalizeName(name string) string {
+	name = strings.TrimSpace(name)
+	return cmdtypes.CommandPrefix + strings.TrimLeft(name, cmdtypes.CommandPrefix)
+}
+
+// Name returns what is wrong with a normalized name, or an empty string.
+func Name(name string) string {
+	bare := strings.TrimPrefix(name, cmdtypes.CommandPrefix)
+
+	if bare != "" && utf8.RuneCountInString(name) > MaxNameLength {
+		return "must be at most " + strconv.Itoa(MaxNameLength) + " characters long"
+	}
+
+	return Identifier(bare)
+}
+
+// Identifier returns what is wrong with the name of something that is not
+// invoked from chat, like a timer, or an empty string.
+func Identifier(name string) string {
+	switch {
+	case name == "":
+		return "is required"
+	case utf8.RuneCountInString(name) > MaxNameLength:
+		return "must be at most " + strconv.Itoa(MaxNameLength) + " characters long"
+	}
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return "can only contain letters, digits, _ and -"
+		}
+	}
+
+	return ""
+}
+
+// Text returns what is wrong with a trimmed response text, or an empty string.
+func Text(text string) string {
+	switch {
+	case text == "":
+		return "is required"
+	case utf8.RuneCountInString(text) > MaxTextLength:
+		return "must be at most " + strconv.Itoa(MaxTextLength) + " characters long"
+	case IsPlatformCommand(text):
+		return "cannot start with / or ."
+	}
+
+	for _, r := range text {
+		if unicode.IsControl(r) {
+			return "cannot contain control characters"
+		}
+	}
+
+	return ""
+}
+
+// Cooldown returns what is wrong with a cooldown in seconds, or an empty string.
+func Cooldown(seconds int32) string {
+	if seconds < 0 || seconds > MaxCooldown {
+		return "must be between 0 and " + strconv.Itoa(MaxCooldown) + " seconds"
+	}
+	return ""
+}
+
+// Phrase returns what is wrong with a trimmed trigger phrase, or an empty
+// string.
+func Phrase(phrase string) string {
+	switch {
+	case phrase == "":
+		return "is required"
+	case utf8.RuneCountInString(phrase) > MaxPatternLength:
+		return "must be at most " + strconv.Itoa(MaxPatternLength) + " characters long"
+	}
+	return ""
+}
+
+// Regexp compiles a trigger pattern, returning what is wrong with it instead
+// when it is invalid or too large to match chat quickly.
+func Regexp(pattern string) (*regexp.Regexp, string) {
+	if problem := Phrase(pattern); problem != "" {
+		return nil, problem
+	}
+
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, "is not a valid regular expression: " + err.Error()
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil || len(prog.Inst) > MaxPatternProgram {
+		return nil, "is too complex"
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, "is not a valid regular expression: " + err.Error()
+	}
+	return re, ""
+}
+
+// IsPlatformCommand reports whether chat would run text as a platform command,
+// such as /ban or .timeout, instead of posting it.
+func IsPlatformCommand(text string) bool {
+	text = strings.TrimLeftFunc(text, unicode.IsSpace)
+	return strings.HasPrefix(text, "/") || strings.HasPrefix(text, ".")
+}
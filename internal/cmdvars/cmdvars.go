@@ -0,0 +1,70 @@
+// Package cmdvars renders the variables available in user command responses.
+//
+// Variables are written in braces: {user}, {touser}, {channel}, {args} and
+// {1} to {9} for single arguments, or for the capture groups of a trigger.
+package cmdvars
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	User    = "user"
+	ToUser  = "touser"
+	Channel = "channel"
+	Args    = "args"
+)
+
+// Pattern matches a variable reference, capturing its name.
+var Pattern = regexp.MustCompile(`\{(user|touser|channel|args|[1-9])\}`)
+
+type Values struct {
+	User    string
+	Channel string
+	Args    string
+	// Groups replaces the single arguments when set, the first one being
+	// the whole match.
+	Groups []string
+}
+
+// Var formats name as a variable reference.
+func Var(name string) string {
+	return "{" + name + "}"
+}
+
+func Render(text string, values Values) string {
+	if !strings.Contains(text, "{") {
+		return text
+	}
+
+	args := strings.Fields(values.Args)
+
+	return Pattern.ReplaceAllStringFunc(text, func(match string) string {
+		switch name := match[1 : len(match)-1]; name {
+		case User:
+			return values.User
+		case ToUser:
+			if len(args) > 0 {
+				return strings.TrimPrefix(args[0], "@")
+			}
+			return values.User
+		case Channel:
+			return values.Channel
+		case Args:
+			return values.Args
+		default:
+			n := int(name[0] - '0')
+			if values.Groups != nil {
+				if n < len(values.Groups) {
+					return values.Groups[n]
+				}
+				return ""
+			}
+			if n <= len(args) {
+				return args[n-1]
+			}
+			return ""
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+type banwordCommand struct {
+	bannedPhraseService *service.BannedPhraseService
+}
+
+func NewBanwordCommand(
+	bannedPhraseService *service.BannedPhraseService,
+) banwordCommand {
+	return banwordCommand{
+		bannedPhraseService: bannedPhraseService,
+	}
+}
+
+func (c banwordCommand) Name() string {
+	return "banword"
+}
+
+func (c banwordCommand) Aliases() []string {
+	return []string{
+		"banword" + createOp,
+		"banword" + deleteOp,
+		"banword" + listOp,
+	}
+}
+
+func (c banwordCommand) Description() string {
+	return "example: !banword (add|del|list) phrase (* matches the rest of a word)"
+}
+
+func (c banwordCommand) OpDescription(op string) string {
+	switch op {
+	case createOp:
+		return op + " example: !banword " + op + " " + actionFlag + "timeout " + timeoutFlag + "600 scam*link (" +
+			actionFlag + "(delete|timeout), " + timeoutFlag + "seconds)"
+	case deleteOp:
+		return op + " example: !banword " + op + " scam*link"
+	case listOp:
+		return op + " example: !banword " + op
+	default:
+		return c.Description()
+	}
+}
+
+func (c banwordCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c banwordCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	var operation string
+	var rest string
+
+	if slices.Contains(c.Aliases(), ctx.Command.Command) {
+		operation = strings.TrimPrefix(ctx.Command.Command, "banword")
+		rest = ctx.Command.Args
+	} else {
+		operation, rest, _ = strings.Cut(ctx.Command.Args, " ")
+	}
+	rest = strings.TrimSpace(rest)
+
+	switch operation {
+	case createOp:
+		create, err := parseBanwordCreate(rest)
+		if err != nil {
+			response.Message = err.Error() + ", " + c.OpDescription(operation)
+			break
+		}
+		create.UserID = ctx.Channel.UserID
+		_, err = c.bannedPhraseService.Create(ctx.Context, create)
+		if err != nil {
+			response.Message = "couldnt ban phrase, got error: " + err.Error()
+			break
+		}
+		response.Message = "phrase banned!"
+	case deleteOp:
+		if rest == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		phrase, err := c.bannedPhraseService.Delete(ctx.Context, coreData.BannedPhraseDelete{
+			UserID: ctx.Channel.UserID,
+			Phrase: rest,
+		})
+		if err != nil {
+			response.Message = "couldnt unban phrase, got error: " + err.Error()
+			break
+		}
+		response.Message = "phrase unbanned: " + phrase.Phrase
+	case listOp:
+		phrases, err := c.bannedPhraseService.List(ctx.Context, coreData.BannedPhraseList{
+			UserID: ctx.Channel.UserID,
+		})
+		if err != nil {
+			response.Message = "couldnt list banned phrases, got error: " + err.Error()
+			break
+		}
+		response.Message = c.list(phrases)
+	default:
+		response.Message = c.Description()
+	}
+	return response, nil
+}
+
+func (c banwordCommand) list(phrases []coreData.BannedPhrase) string {
+	if len(phrases) == 0 {
+		return "there are no banned phrases yet"
+	}
+
+	shown := make([]string, 0, len(phrases))
+	for _, phrase := range phrases {
+		shown = append(shown, phrase.Phrase)
+	}
+
+	message := "banned phrases: " + strings.Join(shown, ", ")
+	if runes := []rune(message); len(runes) > listMessageLength {
+		message = string(runes[:listMessageLength]) + "…"
+	}
+	return message
+}
+
+// parseBanwordCreate reads the flags leading the phrase of the add operation.
+func parseBanwordCreate(args string) (coreData.BannedPhraseCreate, error) {
+	var create coreData.BannedPhraseCreate
+
+	for {
+		flag, rest, _ := strings.Cut(args, " ")
+		switch {
+		case strings.HasPrefix(flag, actionFlag):
+			action := coreData.ModerationAction(strings.ToLower(strings.TrimPrefix(flag, actionFlag)))
+			create.Action = &action
+		case strings.HasPrefix(flag, timeoutFlag):
+			seconds, err := strconv.ParseInt(strings.TrimPrefix(flag, timeoutFlag), 10, 32)
+			if err != nil {
+				return create, errors.New("timeout must be a number of seconds")
+			}
+			timeout := int32(seconds)
+			create.Timeout = &timeout
+		default:
+			if args == "" {
+				return create, errors.New("no phrase given")
+			}
+			create.Phrase = args
+			return create, nil
+		}
+		args = strings.TrimSpace(rest)
+	}
+}
@@ -19,6 +19,13 @@ type Command interface {
 	Execute(ctx CommandContext) (CommandResponse, error)
 }
 
+// PrivateCommand is a Command that can also run in whispers, for the
+// invocations AllowsPrivate accepts.
+type PrivateCommand interface {
+	Command
+	AllowsPrivate(command ParsedCommand) bool
+}
+
 type PlatformUser struct {
 	ID       string
 	Name     string
@@ -71,4 +78,6 @@ type CommandContext struct {
 	Bot     PlatformUser
 	Message Message
 	Command ParsedCommand
+	// Private is set when the command was whispered to the bot.
+	Private bool
 }
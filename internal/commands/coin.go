@@ -1,16 +1,23 @@
 package commands
 
 import (
-	"math/rand/v2"
 	"time"
 
+	"github.com/arnokay/arnobot-core/internal/app/service"
 	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
 )
 
-type coinCommand struct{}
+type coinCommand struct {
+	fairService *service.FairService
+}
 
-func NewCoinCommand() coinCommand {
-	return coinCommand{}
+func NewCoinCommand(
+	fairService *service.FairService,
+) coinCommand {
+	return coinCommand{
+		fairService: fairService,
+	}
 }
 
 func (c coinCommand) Name() string {
@@ -30,20 +37,27 @@ func (c coinCommand) Cooldown() time.Duration {
 }
 
 func (c coinCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
-	random := rand.IntN(6000)
+	roll, err := c.fairService.Roll(ctx.Context, fairRollOf(ctx, coreData.FairGameCoin), func(intN func(n int) int) string {
+		random := intN(6000)
 
-	side := "edge"
+		side := "edge"
 
-	if random < 2999 {
-		side = "heads"
-	}
+		if random < 2999 {
+			side = "heads"
+		}
+
+		if random > 2999 {
+			side = "tails"
+		}
 
-	if random > 2999 {
-		side = "tails"
+		return side
+	})
+	if err != nil {
+		return cmdtypes.CommandResponse{}, err
 	}
 
 	response :=  cmdtypes.CommandResponse{
-		Message: "🪙: " + side,
+		Message: "🪙: " + roll.Outcome + fairRollSuffix(roll.ID),
 		ReplyTo: ctx.Message.ID,
 	}
 
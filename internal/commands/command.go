@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"errors"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -9,15 +11,44 @@ import (
 	"github.com/arnokay/arnobot-shared/data"
 
 	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/cmdvalidate"
 	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
 )
 
 const (
-	createOp = "add"
-	updateOp = "edit"
-	deleteOp = "del"
+	createOp  = "add"
+	updateOp  = "edit"
+	deleteOp  = "del"
+	enableOp  = "enable"
+	disableOp = "disable"
+
+	addResponseOp    = "addresponse"
+	deleteResponseOp = "delresponse"
+	modeOp           = "mode"
+
+	restoreOp = "restore"
+	undoOp    = "undo"
+
+	showOp    = "show"
+	listOp    = "list"
+	renameOp  = "rename"
+	optionsOp = "options"
 )
 
+const weightFlag = "-w="
+
+const (
+	replyFlag     = "-reply"
+	noReplyFlag   = "-noreply"
+	cooldownFlag  = "-cd="
+	userLevelFlag = "-ul="
+)
+
+// listMessageLength keeps !cmd list within a single chat message.
+const listMessageLength = 450
+
 type cmdCommand struct {
 	userCommandService *service.UserCommandService
 }
@@ -35,19 +66,47 @@ func (c cmdCommand) Name() string {
 }
 
 func (c cmdCommand) Aliases() []string {
-	return []string{"cmd" + createOp, "cmd" + deleteOp, "cmd" + updateOp}
+	return []string{
+		"cmd" + createOp,
+		"cmd" + deleteOp,
+		"cmd" + updateOp,
+		"cmd" + enableOp,
+		"cmd" + disableOp,
+		"cmd" + addResponseOp,
+		"cmd" + deleteResponseOp,
+		"cmd" + modeOp,
+		"cmd" + restoreOp,
+		"cmd" + undoOp,
+		"cmd" + showOp,
+		"cmd" + listOp,
+		"cmd" + renameOp,
+		"cmd" + optionsOp,
+	}
 }
 
 func (c cmdCommand) Description() string {
-	return "example: !cmd (add|edit|del) command_name text of command (only for add or edit)"
+	return "example: !cmd (add|edit|del|enable|disable|addresponse|delresponse|mode|restore|undo|show|list|rename|options) command_name text of command (only for add, edit or addresponse)"
 }
 
 func (c cmdCommand) OpDescription(op string) string {
 	switch op {
 	case createOp, updateOp:
 		return op + " example: !cmd " + op + " !customcommand Response to custom command! PogChamp"
-	case deleteOp:
+	case deleteOp, enableOp, disableOp, restoreOp, undoOp, showOp:
 		return op + " example: !cmd " + op + " !customcommand"
+	case addResponseOp:
+		return op + " example: !cmd " + op + " !customcommand " + weightFlag + "3 Another response! (" + weightFlag + "N is optional)"
+	case deleteResponseOp:
+		return op + " example: !cmd " + op + " !customcommand 12"
+	case modeOp:
+		return op + " example: !cmd " + op + " !customcommand (random|weighted|sequential)"
+	case listOp:
+		return op + " example: !cmd " + op
+	case renameOp:
+		return op + " example: !cmd " + op + " !customcommand !newname"
+	case optionsOp:
+		return op + " example: !cmd " + op + " !customcommand " + replyFlag + " " + cooldownFlag + "30 " + userLevelFlag + "vip (" +
+			replyFlag + " or " + noReplyFlag + ", " + cooldownFlag + "seconds, " + userLevelFlag + "(everyone|sub|vip|moderator|broadcaster))"
 	default:
 		return c.Description()
 	}
@@ -66,15 +125,13 @@ func (c cmdCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandRespon
 
 	response.ReplyTo = ctx.Message.ID
 
-	var operation string
-	var rest string
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
 
-	if slices.Contains(c.Aliases(), ctx.Command.Command) {
-		operation = strings.TrimPrefix(ctx.Command.Command, "cmd")
-		rest = ctx.Command.Args
-	} else {
-		operation, rest, _ = strings.Cut(ctx.Command.Args, " ")
-	}
+	operation, rest := c.operation(ctx.Command)
 
 	switch operation {
 	case createOp:
@@ -85,7 +142,7 @@ func (c cmdCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandRespon
 			response.Message = c.Description()
 			break
 		}
-		_, err := c.userCommandService.Create(ctx.Context, data.UserCommandCreate{
+		_, err := c.userCommandService.Create(ctx.Context, coreData.UserCommandCreate{
 			UserID: ctx.Channel.UserID,
 			Name:   name,
 			Text:   text,
@@ -102,7 +159,7 @@ func (c cmdCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandRespon
 			response.Message = c.OpDescription(operation)
 			break
 		}
-		_, err := c.userCommandService.Delete(ctx.Context, data.UserCommandDelete{
+		_, err := c.userCommandService.Delete(ctx.Context, coreData.UserCommandDelete{
 			UserID: ctx.Channel.UserID,
 			Name:   name,
 		})
@@ -117,7 +174,7 @@ func (c cmdCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandRespon
 			response.Message = c.OpDescription(operation)
 			break
 		}
-		_, err := c.userCommandService.Update(ctx.Context, data.UserCommandUpdate{
+		_, err := c.userCommandService.Update(ctx.Context, coreData.UserCommandUpdate{
 			UserID: ctx.Channel.UserID,
 			Name:   name,
 			Text:   &text,
@@ -127,8 +184,287 @@ func (c cmdCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandRespon
 			break
 		}
 		response.Message = "command updated!"
+	case enableOp, disableOp:
+		name, _, _ := strings.Cut(rest, " ")
+		if name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		enabled := operation == enableOp
+		_, err := c.userCommandService.Update(ctx.Context, coreData.UserCommandUpdate{
+			UserID:  ctx.Channel.UserID,
+			Name:    name,
+			Enabled: &enabled,
+		})
+		if err != nil {
+			response.Message = "couldnt " + operation + " command, got error: " + err.Error()
+			break
+		}
+		response.Message = "command " + operation + "d!"
+	case addResponseOp:
+		name, text, _ := strings.Cut(rest, " ")
+		text = strings.TrimSpace(text)
+		var weight int32
+		if strings.HasPrefix(text, weightFlag) {
+			rawWeight, rawText, _ := strings.Cut(text, " ")
+			parsed, err := strconv.ParseInt(strings.TrimPrefix(rawWeight, weightFlag), 10, 32)
+			if err != nil || parsed <= 0 {
+				response.Message = c.OpDescription(operation)
+				break
+			}
+			weight = int32(parsed)
+			text = strings.TrimSpace(rawText)
+		}
+		if text == "" || name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		userCommand, err := c.userCommandService.AddResponse(ctx.Context, coreData.UserCommandResponseAdd{
+			UserID: ctx.Channel.UserID,
+			Name:   name,
+			Text:   text,
+			Weight: weight,
+		})
+		if err != nil {
+			response.Message = "couldnt add response, got error: " + err.Error()
+			break
+		}
+		added := userCommand.Responses[len(userCommand.Responses)-1]
+		response.Message = "response #" + strconv.Itoa(int(added.ID)) + " added!"
+	case deleteResponseOp:
+		name, rawID, _ := strings.Cut(rest, " ")
+		id, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(rawID), "#"), 10, 32)
+		if name == "" || err != nil {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		_, err = c.userCommandService.DeleteResponse(ctx.Context, coreData.UserCommandResponseDelete{
+			UserID:     ctx.Channel.UserID,
+			Name:       name,
+			ResponseID: int32(id),
+		})
+		if err != nil {
+			response.Message = "couldnt delete response, got error: " + err.Error()
+			break
+		}
+		response.Message = "response deleted!"
+	case modeOp:
+		name, rawMode, _ := strings.Cut(rest, " ")
+		mode := coreData.ResponseMode(strings.TrimSpace(rawMode))
+		if name == "" || !mode.IsEnum() {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		_, err := c.userCommandService.Update(ctx.Context, coreData.UserCommandUpdate{
+			UserID:       ctx.Channel.UserID,
+			Name:         name,
+			ResponseMode: &mode,
+		})
+		if err != nil {
+			response.Message = "couldnt change response mode, got error: " + err.Error()
+			break
+		}
+		response.Message = "response mode set to " + mode.String() + "!"
+	case restoreOp:
+		name, _, _ := strings.Cut(rest, " ")
+		if name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		_, err := c.userCommandService.Restore(ctx.Context, coreData.UserCommandRestore{
+			UserID: ctx.Channel.UserID,
+			Name:   name,
+		})
+		if err != nil {
+			response.Message = "couldnt restore command, got error: " + err.Error()
+			break
+		}
+		response.Message = "command restored!"
+	case undoOp:
+		name, _, _ := strings.Cut(rest, " ")
+		if name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		_, err := c.userCommandService.Undo(ctx.Context, coreData.UserCommandUndo{
+			UserID: ctx.Channel.UserID,
+			Name:   name,
+		})
+		if err != nil {
+			response.Message = "couldnt undo last change, got error: " + err.Error()
+			break
+		}
+		response.Message = "last change undone!"
+	case showOp:
+		name, _, _ := strings.Cut(rest, " ")
+		if name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		userCommand, err := c.userCommandService.GetOne(ctx.Context, coreData.UserCommandGetOne{
+			UserID: ctx.Channel.UserID,
+			Name:   cmdvalidate.NormalizeName(name),
+		})
+		if err != nil {
+			response.Message = "couldnt show command, got error: " + err.Error()
+			break
+		}
+		response.Message = c.show(userCommand)
+	case listOp:
+		page, err := c.userCommandService.List(ctx.Context, coreData.UserCommandList{
+			UserID: ctx.Channel.UserID,
+			Sort:   coreData.UserCommandSortName,
+			Limit:  100,
+		})
+		if err != nil {
+			response.Message = "couldnt list commands, got error: " + err.Error()
+			break
+		}
+		total := len(page.Items)
+		if page.NextCursor != "" {
+			total, err = c.userCommandService.Count(ctx.Context, ctx.Channel.UserID)
+			if err != nil {
+				response.Message = "couldnt list commands, got error: " + err.Error()
+				break
+			}
+		}
+		response.Message = c.list(page, total)
+	case renameOp:
+		name, newName, _ := strings.Cut(rest, " ")
+		newName = strings.TrimSpace(newName)
+		if name == "" || newName == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		userCommand, err := c.userCommandService.Update(ctx.Context, coreData.UserCommandUpdate{
+			UserID:  ctx.Channel.UserID,
+			Name:    name,
+			NewName: &newName,
+		})
+		if err != nil {
+			response.Message = "couldnt rename command, got error: " + err.Error()
+			break
+		}
+		response.Message = "command renamed to " + userCommand.Name + "!"
+	case optionsOp:
+		name, flags, _ := strings.Cut(rest, " ")
+		update, err := parseOptions(strings.Fields(flags))
+		if name == "" || err != nil {
+			response.Message = c.OpDescription(operation)
+			if err != nil {
+				response.Message = err.Error() + ", " + response.Message
+			}
+			break
+		}
+		update.UserID = ctx.Channel.UserID
+		update.Name = name
+		userCommand, err := c.userCommandService.Update(ctx.Context, update)
+		if err != nil {
+			response.Message = "couldnt change options, got error: " + err.Error()
+			break
+		}
+		response.Message = "options updated! " + c.show(userCommand)
 	default:
 		response.Message = c.Description()
 	}
 	return response, nil
 }
+
+// AllowsPrivate lets the operations that only read commands run in whispers.
+func (c cmdCommand) AllowsPrivate(command cmdtypes.ParsedCommand) bool {
+	operation, _ := c.operation(command)
+	return operation == showOp || operation == listOp
+}
+
+// operation splits the operation from its arguments, whether it was typed as
+// an alias like !cmdadd or as !cmd add.
+func (c cmdCommand) operation(command cmdtypes.ParsedCommand) (string, string) {
+	if slices.Contains(c.Aliases(), command.Command) {
+		return strings.TrimPrefix(command.Command, "cmd"), command.Args
+	}
+	operation, rest, _ := strings.Cut(command.Args, " ")
+	return operation, rest
+}
+
+// show describes the settings of the command followed by its raw text.
+func (c cmdCommand) show(userCommand coreData.UserCommand) string {
+	settings := []string{
+		"level " + userCommand.UserLevel.String(),
+		"cd " + strconv.Itoa(int(userCommand.Cooldown)) + "s",
+		"mode " + userCommand.ResponseMode.String(),
+	}
+	if !userCommand.Enabled {
+		settings = append(settings, "disabled")
+	}
+	if userCommand.Reply {
+		settings = append(settings, "reply")
+	}
+	if len(userCommand.Responses) > 0 {
+		settings = append(settings, "+"+strconv.Itoa(len(userCommand.Responses))+" responses")
+	}
+
+	message := []rune(userCommand.Name + " [" + strings.Join(settings, ", ") + "]: " + userCommand.Text)
+	if len(message) > cmdvalidate.MaxTextLength {
+		message = append(message[:cmdvalidate.MaxTextLength-1], '…')
+	}
+	return string(message)
+}
+
+// list names the commands of page, the first of total, as far as they fit.
+func (c cmdCommand) list(page coreData.UserCommandPage, total int) string {
+	if len(page.Items) == 0 {
+		return "there are no commands yet"
+	}
+
+	var names strings.Builder
+	for i, userCommand := range page.Items {
+		more := " and " + strconv.Itoa(total-i) + " more"
+		if names.Len()+len(userCommand.Name)+len(more) > listMessageLength {
+			names.WriteString(more)
+			return "commands: " + names.String()
+		}
+		if i > 0 {
+			names.WriteString(", ")
+		}
+		names.WriteString(userCommand.Name)
+	}
+	if total > len(page.Items) {
+		names.WriteString(" and " + strconv.Itoa(total-len(page.Items)) + " more")
+	}
+
+	return "commands: " + names.String()
+}
+
+// parseOptions reads the flags of the options operation into an update.
+func parseOptions(flags []string) (coreData.UserCommandUpdate, error) {
+	var update coreData.UserCommandUpdate
+
+	if len(flags) == 0 {
+		return update, errors.New("no options given")
+	}
+
+	for _, flag := range flags {
+		switch {
+		case flag == replyFlag, flag == noReplyFlag:
+			reply := flag == replyFlag
+			update.Reply = &reply
+		case strings.HasPrefix(flag, cooldownFlag):
+			seconds, err := strconv.ParseInt(strings.TrimPrefix(flag, cooldownFlag), 10, 32)
+			if err != nil {
+				return update, errors.New("cooldown must be a number of seconds")
+			}
+			cooldown := int32(seconds)
+			update.Cooldown = &cooldown
+		case strings.HasPrefix(flag, userLevelFlag):
+			userLevel := coreData.UserLevel(strings.ToLower(strings.TrimPrefix(flag, userLevelFlag)))
+			if !userLevel.IsEnum() {
+				return update, errors.New("unknown user level " + userLevel.String())
+			}
+			update.UserLevel = &userLevel
+		default:
+			return update, errors.New("unknown option " + flag)
+		}
+	}
+
+	return update, nil
+}
@@ -1,11 +1,12 @@
 package commands
 
 import (
-	"math/rand/v2"
 	"strconv"
 	"time"
 
+	"github.com/arnokay/arnobot-core/internal/app/service"
 	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
 	"github.com/arnokay/arnobot-shared/apperror"
 )
 
@@ -14,14 +15,20 @@ const (
 	defaultSides = 6
 )
 
-func randRange(min, max int) int {
-	return rand.IntN(max-min+1) + min
+func randRange(intN func(n int) int, min, max int) int {
+	return intN(max-min+1) + min
 }
 
-type diceCommand struct{}
+type diceCommand struct {
+	fairService *service.FairService
+}
 
-func NewDiceCommand() diceCommand {
-	return diceCommand{}
+func NewDiceCommand(
+	fairService *service.FairService,
+) diceCommand {
+	return diceCommand{
+		fairService: fairService,
+	}
 }
 
 func (c diceCommand) Name() string {
@@ -50,14 +57,21 @@ func (c diceCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandRespo
     sides = convSides
 	}
 
-	if sides > 100 || sides < 2 {
+	if sides > 100 || sides < minSides {
 		return cmdtypes.CommandResponse{}, apperror.New(apperror.CodeInvalidInput, "sides are limited to 100", nil)
 	}
 
-	side := randRange(minSides, sides)
+	var side int
+	roll, err := c.fairService.Roll(ctx.Context, fairRollOf(ctx, coreData.FairGameDice), func(intN func(n int) int) string {
+		side = randRange(intN, minSides, sides)
+		return strconv.Itoa(side) + " of " + strconv.Itoa(sides)
+	})
+	if err != nil {
+		return cmdtypes.CommandResponse{}, err
+	}
 
 	response := cmdtypes.CommandResponse{
-		Message: "🎲: " + strconv.Itoa(side),
+		Message: "🎲: " + strconv.Itoa(side) + fairRollSuffix(roll.ID),
 		ReplyTo: ctx.Message.ID,
 	}
 
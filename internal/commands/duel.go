@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+type duelCommand struct {
+	duelService *service.DuelService
+}
+
+func NewDuelCommand(
+	duelService *service.DuelService,
+) duelCommand {
+	return duelCommand{
+		duelService: duelService,
+	}
+}
+
+func (c duelCommand) Name() string {
+	return "duel"
+}
+
+func (c duelCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c duelCommand) Description() string {
+	return "example: !duel @user, !duel @user 100 (they answer with !accept or !decline)"
+}
+
+func (c duelCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c duelCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	response.ReplyTo = ctx.Message.ID
+
+	fields := strings.Fields(ctx.Command.Args)
+	if len(fields) == 0 || len(fields) > 2 {
+		response.Message = c.Description()
+		return response, nil
+	}
+
+	challenge := coreData.DuelChallenge{
+		From:    pointsChatterOf(ctx),
+		ToLogin: strings.TrimPrefix(fields[0], "@"),
+	}
+	if len(fields) == 2 {
+		stake, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			response.Message = c.Description()
+			return response, nil
+		}
+		challenge.Stake = stake
+	}
+	if strings.EqualFold(challenge.ToLogin, ctx.Bot.Login) {
+		response.Message = "i am a lover, not a fighter"
+		return response, nil
+	}
+
+	duel, err := c.duelService.Challenge(ctx.Context, challenge)
+	if err != nil {
+		response.Message = "couldnt challenge, got error: " + err.Error()
+		return response, nil
+	}
+
+	response.Message = "⚔️ @" + duel.ToLogin + ", @" + duel.FromLogin + " challenges you to a duel"
+	if challenge.Stake > 0 {
+		response.Message += " for " + formatPoints(challenge.Stake)
+	}
+	response.Message += "! type !accept or !decline within " + time.Until(duel.ExpiresAt).Round(time.Second).String()
+	return response, nil
+}
+
+func (c duelCommand) Kind() coreData.InteractionKind {
+	return coreData.InteractionKindDuel
+}
+
+func (c duelCommand) Accept(ctx cmdtypes.CommandContext, interaction coreData.Interaction) string {
+	result, err := c.duelService.Fight(ctx.Context, coreData.DuelFight{
+		Interaction: interaction,
+		To:          pointsChatterOf(ctx),
+		MessageID:   ctx.Message.ID,
+	})
+	if err != nil {
+		return "couldnt duel @" + interaction.FromLogin + ", got error: " + err.Error()
+	}
+
+	message := "⚔️ @" + result.WinnerLogin + " wins the duel against @" + result.LoserLogin
+	if result.Stake > 0 {
+		message += " and takes " + formatPoints(result.Stake) + " (now " + formatPoints(result.Balance) + ")"
+	}
+	return message + "!" + fairRollSuffix(result.RollID)
+}
+
+func (c duelCommand) Decline(ctx cmdtypes.CommandContext, interaction coreData.Interaction) string {
+	return "@" + interaction.FromLogin + ", @" + interaction.ToLogin + " declined your duel"
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	fairOnOp  = "on"
+	fairOffOp = "off"
+)
+
+// fairRollOf is the roll of game asked for by the message, which seeds it.
+func fairRollOf(ctx cmdtypes.CommandContext, game coreData.FairGame) coreData.FairRollCreate {
+	return coreData.FairRollCreate{
+		UserID:       ctx.Channel.UserID,
+		Game:         game,
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+		ClientSeed:   ctx.Message.ID,
+	}
+}
+
+// fairRollSuffix tells chatters which roll to !verify, when it is provably
+// fair.
+func fairRollSuffix(rollID int64) string {
+	if rollID == 0 {
+		return ""
+	}
+	return " (roll #" + strconv.FormatInt(rollID, 10) + ")"
+}
+
+type fairCommand struct {
+	fairService *service.FairService
+}
+
+func NewFairCommand(
+	fairService *service.FairService,
+) fairCommand {
+	return fairCommand{
+		fairService: fairService,
+	}
+}
+
+func (c fairCommand) Name() string {
+	return "fair"
+}
+
+func (c fairCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c fairCommand) Description() string {
+	return "example: !fair (shows the server seed hash) or !fair " + fairOnOp + "|" + fairOffOp
+}
+
+func (c fairCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c fairCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	response.ReplyTo = ctx.Message.ID
+
+	var settings coreData.FairSettings
+	var err error
+
+	switch operation := strings.TrimSpace(ctx.Command.Args); operation {
+	case "":
+		settings, err = c.fairService.GetSettings(ctx.Context, coreData.FairSettingsGet{UserID: ctx.Channel.UserID})
+	case fairOnOp, fairOffOp:
+		if ctx.Chatter.Role < data.ChatterModerator {
+			return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+		}
+		ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+			Platform:     ctx.Chatter.Platform,
+			ChatterID:    ctx.Chatter.ID,
+			ChatterLogin: ctx.Chatter.Login,
+		})
+		enabled := operation == fairOnOp
+		settings, err = c.fairService.UpdateSettings(ctx.Context, coreData.FairSettingsUpdate{
+			UserID:  ctx.Channel.UserID,
+			Enabled: &enabled,
+		})
+	default:
+		response.Message = c.Description()
+		return response, nil
+	}
+	if err != nil {
+		response.Message = "couldnt get provably fair mode, got error: " + err.Error()
+		return response, nil
+	}
+
+	if !settings.Enabled {
+		response.Message = "provably fair mode is off"
+		return response, nil
+	}
+	response.Message = "provably fair mode is on, server seed hash: " + settings.ServerSeedHash
+	return response, nil
+}
+
+type verifyCommand struct {
+	fairService *service.FairService
+}
+
+func NewVerifyCommand(
+	fairService *service.FairService,
+) verifyCommand {
+	return verifyCommand{
+		fairService: fairService,
+	}
+}
+
+func (c verifyCommand) Name() string {
+	return "verify"
+}
+
+func (c verifyCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c verifyCommand) Description() string {
+	return "example: !verify 42 (reveals the server seed of a provably fair roll)"
+}
+
+func (c verifyCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c verifyCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	response.ReplyTo = ctx.Message.ID
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(ctx.Command.Args), "#"), 10, 64)
+	if err != nil || id <= 0 {
+		response.Message = c.Description()
+		return response, nil
+	}
+
+	verification, err := c.fairService.Verify(ctx.Context, coreData.FairRollGet{
+		UserID: ctx.Channel.UserID,
+		ID:     id,
+	})
+	if err != nil {
+		response.Message = "couldnt verify roll, got error: " + err.Error()
+		return response, nil
+	}
+
+	response.Message = "roll #" + strconv.FormatInt(verification.ID, 10) +
+		" (" + verification.Game.String() + " " + verification.Outcome + "): " +
+		"server seed " + verification.ServerSeed +
+		", client seed " + verification.ClientSeed +
+		", nonce " + strconv.FormatInt(verification.Nonce, 10) +
+		", hash " + verification.ServerSeedHash +
+		". next hash " + verification.NextServerSeedHash
+	return response, nil
+}
@@ -0,0 +1,273 @@
+package commands
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	actionFlag  = "-action="
+	timeoutFlag = "-t="
+	exemptFlag  = "-exempt="
+	allowFlag   = "-allow="
+	minFlag     = "-min="
+	maxFlag     = "-max="
+	percentFlag = "-percent="
+	windowFlag  = "-window="
+)
+
+type filterCommand struct {
+	moderationService *service.ModerationService
+}
+
+func NewFilterCommand(
+	moderationService *service.ModerationService,
+) filterCommand {
+	return filterCommand{
+		moderationService: moderationService,
+	}
+}
+
+func (c filterCommand) Name() string {
+	return "filter"
+}
+
+func (c filterCommand) Aliases() []string {
+	return []string{
+		"filter" + enableOp,
+		"filter" + disableOp,
+		"filter" + listOp,
+		"filter" + optionsOp,
+	}
+}
+
+func (c filterCommand) Description() string {
+	return "example: !filter (enable|disable|list|options) (links|caps|symbols|repetition-words|repetition|length)"
+}
+
+func (c filterCommand) OpDescription(op string) string {
+	switch op {
+	case enableOp, disableOp:
+		return op + " example: !filter " + op + " links"
+	case listOp:
+		return op + " example: !filter " + op
+	case optionsOp:
+		return op + " example: !filter " + op + " links " + actionFlag + "timeout " + timeoutFlag + "600 " + allowFlag + "youtube.com,clips.twitch.tv (" +
+			actionFlag + "(delete|timeout|warn|ban), " + timeoutFlag + "seconds, " + exemptFlag + "sub,vip,moderator,broadcaster, " +
+			allowFlag + "domains, " + minFlag + "N, " + maxFlag + "N, " + percentFlag + "N, " + windowFlag + "seconds)"
+	default:
+		return c.Description()
+	}
+}
+
+func (c filterCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c filterCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	var operation string
+	var rest string
+
+	if slices.Contains(c.Aliases(), ctx.Command.Command) {
+		operation = strings.TrimPrefix(ctx.Command.Command, "filter")
+		rest = ctx.Command.Args
+	} else {
+		operation, rest, _ = strings.Cut(ctx.Command.Args, " ")
+	}
+
+	switch operation {
+	case enableOp, disableOp:
+		kind, _, _ := strings.Cut(strings.TrimSpace(rest), " ")
+		if kind == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		enabled := operation == enableOp
+		_, err := c.moderationService.Update(ctx.Context, coreData.ModerationFilterUpdate{
+			UserID:  ctx.Channel.UserID,
+			Kind:    coreData.FilterKind(strings.ToLower(kind)),
+			Enabled: &enabled,
+		})
+		if err != nil {
+			response.Message = "couldnt " + operation + " filter, got error: " + err.Error()
+			break
+		}
+		response.Message = "filter " + operation + "d!"
+	case listOp:
+		filters, err := c.moderationService.List(ctx.Context, coreData.ModerationFilterList{
+			UserID: ctx.Channel.UserID,
+		})
+		if err != nil {
+			response.Message = "couldnt list filters, got error: " + err.Error()
+			break
+		}
+		shown := make([]string, 0, len(filters))
+		for _, filter := range filters {
+			shown = append(shown, c.show(filter))
+		}
+		response.Message = "filters: " + strings.Join(shown, ", ")
+	case optionsOp:
+		kind, flags, _ := strings.Cut(strings.TrimSpace(rest), " ")
+		if kind == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		filter, err := c.get(ctx, coreData.FilterKind(strings.ToLower(kind)))
+		if err != nil {
+			response.Message = "couldnt change options, got error: " + err.Error()
+			break
+		}
+		update, err := parseFilterOptions(filter, strings.Fields(flags))
+		if err != nil {
+			response.Message = err.Error() + ", " + c.OpDescription(operation)
+			break
+		}
+		filter, err = c.moderationService.Update(ctx.Context, update)
+		if err != nil {
+			response.Message = "couldnt change options, got error: " + err.Error()
+			break
+		}
+		response.Message = "options updated! " + c.show(filter)
+	default:
+		response.Message = c.Description()
+	}
+	return response, nil
+}
+
+func (c filterCommand) get(ctx cmdtypes.CommandContext, kind coreData.FilterKind) (coreData.ModerationFilter, error) {
+	filters, err := c.moderationService.List(ctx.Context, coreData.ModerationFilterList{
+		UserID: ctx.Channel.UserID,
+	})
+	if err != nil {
+		return coreData.ModerationFilter{}, err
+	}
+	for _, filter := range filters {
+		if filter.Kind == kind {
+			return filter, nil
+		}
+	}
+	return coreData.ModerationFilter{}, errors.New("unknown filter " + kind.String())
+}
+
+func (c filterCommand) show(filter coreData.ModerationFilter) string {
+	if !filter.Enabled {
+		return filter.Kind.String() + " (off)"
+	}
+	action := filter.Action.String()
+	if filter.Action == coreData.ModerationActionTimeout {
+		action += " " + strconv.Itoa(int(filter.Timeout)) + "s"
+	}
+	return filter.Kind.String() + " (" + action + ")"
+}
+
+// parseFilterOptions reads the flags of the options operation into an update
+// of filter. The -min, -max, -percent and -window flags set the thresholds
+// of the kind of filter they are given for.
+func parseFilterOptions(filter coreData.ModerationFilter, flags []string) (coreData.ModerationFilterUpdate, error) {
+	update := coreData.ModerationFilterUpdate{
+		UserID: filter.UserID,
+		Kind:   filter.Kind,
+	}
+
+	if len(flags) == 0 {
+		return update, errors.New("no options given")
+	}
+
+	settings := filter.Settings
+	settingsChanged := false
+
+	for _, flag := range flags {
+		name, value, _ := strings.Cut(flag, "=")
+		name += "="
+
+		switch name {
+		case actionFlag:
+			action := coreData.ModerationAction(strings.ToLower(value))
+			if !action.IsEnum() {
+				return update, errors.New("unknown action " + value)
+			}
+			update.Action = &action
+		case exemptFlag:
+			update.Exempt = []coreData.UserLevel{}
+			for _, level := range strings.Split(value, ",") {
+				if level == "" {
+					continue
+				}
+				userLevel := coreData.UserLevel(strings.ToLower(level))
+				if !userLevel.IsEnum() {
+					return update, errors.New("unknown user level " + level)
+				}
+				update.Exempt = append(update.Exempt, userLevel)
+			}
+		case allowFlag:
+			settings.AllowedDomains = nil
+			for _, domain := range strings.Split(value, ",") {
+				if domain != "" {
+					settings.AllowedDomains = append(settings.AllowedDomains, strings.ToLower(domain))
+				}
+			}
+			settingsChanged = true
+		case timeoutFlag, minFlag, maxFlag, percentFlag, windowFlag:
+			number, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return update, errors.New(strings.TrimSuffix(strings.TrimPrefix(name, "-"), "=") + " must be a number")
+			}
+			n := int32(number)
+			switch name {
+			case timeoutFlag:
+				update.Timeout = &n
+			case minFlag:
+				settings.MinLength = n
+			case percentFlag:
+				settings.MaxPercent = n
+			case windowFlag:
+				settings.Window = n
+			case maxFlag:
+				switch filter.Kind {
+				case coreData.FilterKindRepetitionWords:
+					settings.MaxWordRepeats = n
+				case coreData.FilterKindRepetition:
+					settings.MaxRepeats = n
+				case coreData.FilterKindLength:
+					settings.MaxLength = n
+				default:
+					return update, errors.New(maxFlag + " does nothing for " + filter.Kind.String())
+				}
+			}
+			settingsChanged = settingsChanged || name != timeoutFlag
+		default:
+			return update, errors.New("unknown option " + flag)
+		}
+	}
+
+	if settingsChanged {
+		update.Settings = &settings
+	}
+
+	return update, nil
+}
@@ -1,27 +1,34 @@
 package commands
 
 import (
-	"math/rand/v2"
+	"errors"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
 	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
 )
 
-var numberToEmoji = map[int]string{
-	0: "🍒",
-	1: "🍋",
-	2: "🔔",
-	3: "💎",
-	4: "⭐",
-	5: "🍇",
-	6: "🍊",
-	7: "🔥",
-}
+const gambaStatsOp = "stats"
 
-type gambaCommand struct{}
+type gambaCommand struct {
+	slotService   *service.SlotService
+	pointsService *service.PointsService
+}
 
-func NewGambaCommand() gambaCommand {
-	return gambaCommand{}
+func NewGambaCommand(
+	slotService *service.SlotService,
+	pointsService *service.PointsService,
+) gambaCommand {
+	return gambaCommand{
+		slotService:   slotService,
+		pointsService: pointsService,
+	}
 }
 
 func (c gambaCommand) Name() string {
@@ -33,7 +40,7 @@ func (c gambaCommand) Aliases() []string {
 }
 
 func (c gambaCommand) Description() string {
-	return "gamba"
+	return "example: !gamba (for fun), !gamba 100 (bet points) or !gamba " + gambaStatsOp
 }
 
 func (c gambaCommand) Cooldown() time.Duration {
@@ -41,16 +48,71 @@ func (c gambaCommand) Cooldown() time.Duration {
 }
 
 func (c gambaCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
-	row1 := rand.IntN(7)
-	row2 := rand.IntN(7)
-	row3 := rand.IntN(7)
+	var response cmdtypes.CommandResponse
 
-	message := "🎰: " + numberToEmoji[row1] + numberToEmoji[row2] + numberToEmoji[row3]
+	response.ReplyTo = ctx.Message.ID
 
-	response := cmdtypes.CommandResponse{
-		Message: message,
-		ReplyTo: ctx.Message.ID,
+	arg, _, _ := strings.Cut(strings.TrimSpace(ctx.Command.Args), " ")
+	if arg == gambaStatsOp {
+		if ctx.Chatter.Role < data.ChatterModerator {
+			return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+		}
+		report, err := c.slotService.Get(ctx.Context, coreData.SlotMachineGet{UserID: ctx.Channel.UserID})
+		if err != nil {
+			response.Message = "couldnt get gamba stats, got error: " + err.Error()
+			return response, nil
+		}
+		response.Message = c.stats(report)
+		return response, nil
 	}
 
+	spin := coreData.SlotSpin{
+		PointsChatter: pointsChatterOf(ctx),
+		MessageID:     ctx.Message.ID,
+	}
+	if arg != "" && c.pointsService.Enabled(ctx.Context, ctx.Channel.UserID) {
+		bet, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || bet <= 0 {
+			response.Message = c.Description()
+			return response, nil
+		}
+		spin.Bet = bet
+	}
+
+	result, err := c.slotService.Spin(ctx.Context, spin)
+	if errors.Is(err, apperror.ErrNoAction) {
+		return cmdtypes.CommandResponse{}, err
+	}
+	if err != nil {
+		response.Message = "couldnt gamba, got error: " + err.Error()
+		return response, nil
+	}
+
+	response.Message = "🎰: " + strings.Join(result.Reels, "") + fairRollSuffix(result.RollID)
+	switch {
+	case spin.Bet == 0:
+	case result.Jackpot:
+		response.Message += " JACKPOT! won " + formatPoints(result.Won) + ", you have " + formatPoints(result.Balance)
+	case result.Won > 0:
+		response.Message += " won " + formatPoints(result.Won) + ", you have " + formatPoints(result.Balance)
+	default:
+		response.Message += " lost " + formatPoints(spin.Bet) + ", you have " + formatPoints(result.Balance) +
+			" (jackpot " + formatPoints(result.Pool) + ")"
+	}
 	return response, nil
 }
+
+func (c gambaCommand) stats(report coreData.SlotReport) string {
+	message := strconv.FormatInt(report.Spins, 10) + " spins, " +
+		formatPoints(report.Wagered) + " bet, " +
+		formatPoints(report.Paid) + " paid, " +
+		"house edge " + formatPercent(report.HouseEdge())
+	if report.Wagered > 0 {
+		message += " (" + formatPercent(1-report.ActualReturn) + " so far)"
+	}
+	return message + ", jackpot " + formatPoints(report.Jackpot)
+}
+
+func formatPercent(value float64) string {
+	return strconv.FormatFloat(value*100, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	giveawayStartOp  = "start"
+	giveawayCloseOp  = "close"
+	giveawayDrawOp   = "draw"
+	giveawayRedrawOp = "redraw"
+
+	costFlag    = "-cost="
+	subLuckFlag = "-subluck="
+)
+
+type giveawayCommand struct {
+	giveawayService *service.GiveawayService
+}
+
+func NewGiveawayCommand(
+	giveawayService *service.GiveawayService,
+) giveawayCommand {
+	return giveawayCommand{
+		giveawayService: giveawayService,
+	}
+}
+
+func (c giveawayCommand) Name() string {
+	return "giveaway"
+}
+
+func (c giveawayCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c giveawayCommand) Description() string {
+	return "example: !giveaway, !giveaway (start|close|draw|redraw)"
+}
+
+func (c giveawayCommand) OpDescription(op string) string {
+	switch op {
+	case giveawayStartOp:
+		return op + " example: !giveaway " + op + " keyword 10m " + userLevelFlag + "sub " + costFlag + "100 " + maxFlag + "5 " + subLuckFlag + "2 (" +
+			"only the keyword is required; " + userLevelFlag + "(everyone|sub|vip|moderator), " + costFlag + "points per ticket, " +
+			maxFlag + "tickets per chatter, " + subLuckFlag + "ticket multiplier for subs)"
+	default:
+		return c.Description()
+	}
+}
+
+func (c giveawayCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c giveawayCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	response.ReplyTo = ctx.Message.ID
+
+	channel := coreData.GiveawayGet{
+		UserID:   ctx.Channel.UserID,
+		Platform: ctx.Channel.Platform,
+	}
+
+	operation, rest, _ := strings.Cut(strings.TrimSpace(ctx.Command.Args), " ")
+	if operation == "" {
+		giveaway, err := c.giveawayService.Get(ctx.Context, channel)
+		if err != nil {
+			response.Message = "couldnt get giveaway, got error: " + err.Error()
+			return response, nil
+		}
+		response.Message = c.status(giveaway)
+		return response, nil
+	}
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	switch operation {
+	case giveawayStartOp:
+		start, err := parseGiveawayStart(rest)
+		if err != nil {
+			response.Message = err.Error() + ", " + c.OpDescription(operation)
+			break
+		}
+		start.UserID = channel.UserID
+		start.Platform = channel.Platform
+		giveaway, err := c.giveawayService.Start(ctx.Context, start)
+		if err != nil {
+			response.Message = "couldnt start giveaway, got error: " + err.Error()
+			break
+		}
+		response.Message = "giveaway started! " + c.howToEnter(giveaway)
+	case giveawayCloseOp:
+		giveaway, err := c.giveawayService.Close(ctx.Context, channel)
+		if err != nil {
+			response.Message = "couldnt close giveaway, got error: " + err.Error()
+			break
+		}
+		response.Message = "giveaway closed with " + strconv.FormatInt(giveaway.Entries, 10) + " entries"
+	case giveawayDrawOp, giveawayRedrawOp:
+		winner, err := c.giveawayService.Draw(ctx.Context, channel)
+		if err != nil {
+			response.Message = "couldnt draw giveaway, got error: " + err.Error()
+			break
+		}
+		response.Message = "🎉 @" + winner.ChatterLogin + " won the giveaway!"
+	default:
+		response.Message = c.Description()
+	}
+	return response, nil
+}
+
+func (c giveawayCommand) howToEnter(giveaway coreData.Giveaway) string {
+	message := "type " + giveaway.Keyword + " to enter"
+	if giveaway.TicketCost > 0 {
+		message += ", " + formatPoints(int64(giveaway.TicketCost)) + " per ticket"
+		if giveaway.MaxTickets > 1 {
+			message += ", up to " + strconv.Itoa(int(giveaway.MaxTickets)) + " like " + giveaway.Keyword + " " + strconv.Itoa(int(giveaway.MaxTickets))
+		}
+	}
+	if giveaway.MinLevel != coreData.UserLevelEveryone {
+		message += " (" + giveaway.MinLevel.String() + " and up)"
+	}
+	if giveaway.EndsAt != nil {
+		message += ", closes in " + time.Until(*giveaway.EndsAt).Round(time.Second).String()
+	}
+	return message
+}
+
+func (c giveawayCommand) status(giveaway coreData.Giveaway) string {
+	entries := strconv.FormatInt(giveaway.Entries, 10) + " entries"
+	if giveaway.Open(time.Now()) {
+		return "giveaway is open, " + c.howToEnter(giveaway) + " (" + entries + " so far)"
+	}
+
+	message := "giveaway is closed with " + entries
+	if len(giveaway.Winners) > 0 {
+		winners := make([]string, 0, len(giveaway.Winners))
+		for _, winner := range giveaway.Winners {
+			winners = append(winners, "@"+winner.ChatterLogin)
+		}
+		message += ", drawn: " + strings.Join(winners, ", ")
+	}
+	return message
+}
+
+// parseGiveawayStart reads the keyword, duration and flags of the start
+// operation, in any order.
+func parseGiveawayStart(args string) (coreData.GiveawayStart, error) {
+	var start coreData.GiveawayStart
+
+	for _, field := range strings.Fields(args) {
+		var err error
+		switch {
+		case strings.HasPrefix(field, userLevelFlag):
+			start.MinLevel = coreData.UserLevel(strings.ToLower(strings.TrimPrefix(field, userLevelFlag)))
+		case strings.HasPrefix(field, costFlag):
+			start.TicketCost, err = parseFlagNumber(field, costFlag)
+		case strings.HasPrefix(field, maxFlag):
+			start.MaxTickets, err = parseFlagNumber(field, maxFlag)
+		case strings.HasPrefix(field, subLuckFlag):
+			start.SubLuck, err = parseFlagNumber(field, subLuckFlag)
+		case start.Keyword == "":
+			start.Keyword = field
+		case start.Duration == 0:
+			duration, ok := parsePermitDuration(field)
+			if !ok {
+				return start, errors.New("duration must be like 90, 90s, 10m or 1h")
+			}
+			start.Duration = duration
+		default:
+			return start, errors.New("the keyword must be a single word")
+		}
+		if err != nil {
+			return start, err
+		}
+	}
+
+	if start.Keyword == "" {
+		return start, errors.New("no keyword given")
+	}
+	return start, nil
+}
+
+func parseFlagNumber(field string, flag string) (int32, error) {
+	n, err := strconv.ParseInt(strings.TrimPrefix(field, flag), 10, 32)
+	if err != nil {
+		return 0, errors.New(strings.Trim(flag, "-=") + " must be a number")
+	}
+	return int32(n), nil
+}
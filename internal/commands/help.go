@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+)
+
+type helpCommand struct {
+	cmdManagerService *service.CmdManagerService
+}
+
+func NewHelpCommand(
+	cmdManagerService *service.CmdManagerService,
+) helpCommand {
+	return helpCommand{
+		cmdManagerService: cmdManagerService,
+	}
+}
+
+func (c helpCommand) Name() string {
+	return "help"
+}
+
+func (c helpCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c helpCommand) Description() string {
+	return "example: !help or !help command_name"
+}
+
+func (c helpCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c helpCommand) AllowsPrivate(command cmdtypes.ParsedCommand) bool {
+	return true
+}
+
+func (c helpCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	response.ReplyTo = ctx.Message.ID
+
+	name, _, _ := strings.Cut(strings.TrimSpace(ctx.Command.Args), " ")
+	name = strings.TrimPrefix(name, cmdtypes.CommandPrefix)
+	if name == "" {
+		var names []string
+		for _, cmd := range c.cmdManagerService.Commands() {
+			names = append(names, cmdtypes.CommandPrefix+cmd.Name())
+		}
+		response.Message = "commands: " + strings.Join(names, ", ")
+		return response, nil
+	}
+
+	cmd, ok := c.cmdManagerService.Get(name)
+	switch {
+	case !ok:
+		response.Message = "there is no command " + cmdtypes.CommandPrefix + name
+	case cmd.Description() == "":
+		response.Message = cmdtypes.CommandPrefix + cmd.Name() + " has no description"
+	default:
+		response.Message = cmd.Description()
+	}
+	return response, nil
+}
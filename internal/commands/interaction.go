@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+// interactionAnswerer carries out or drops the interactions of its kind once
+// they are answered, returning what to reply.
+type interactionAnswerer interface {
+	Kind() coreData.InteractionKind
+	Accept(ctx cmdtypes.CommandContext, interaction coreData.Interaction) string
+	Decline(ctx cmdtypes.CommandContext, interaction coreData.Interaction) string
+}
+
+// answerCommand answers the interaction pending for the chatter, whatever
+// its kind, which is why !accept and !decline are not part of the commands
+// that ask.
+type answerCommand struct {
+	interactionService *service.InteractionService
+	accept             bool
+	answerers          map[coreData.InteractionKind]interactionAnswerer
+}
+
+func NewAcceptCommand(
+	interactionService *service.InteractionService,
+	answerers ...interactionAnswerer,
+) answerCommand {
+	return newAnswerCommand(interactionService, true, answerers)
+}
+
+func NewDeclineCommand(
+	interactionService *service.InteractionService,
+	answerers ...interactionAnswerer,
+) answerCommand {
+	return newAnswerCommand(interactionService, false, answerers)
+}
+
+func newAnswerCommand(
+	interactionService *service.InteractionService,
+	accept bool,
+	answerers []interactionAnswerer,
+) answerCommand {
+	c := answerCommand{
+		interactionService: interactionService,
+		accept:             accept,
+		answerers:          make(map[coreData.InteractionKind]interactionAnswerer, len(answerers)),
+	}
+	for _, answerer := range answerers {
+		c.answerers[answerer.Kind()] = answerer
+	}
+	return c
+}
+
+func (c answerCommand) Name() string {
+	if c.accept {
+		return "accept"
+	}
+	return "decline"
+}
+
+func (c answerCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c answerCommand) Description() string {
+	return "example: !" + c.Name() + " (answers a challenge like !duel)"
+}
+
+// Cooldown is zero: the cooldown is shared by the whole channel, so any
+// chatter typing !accept would otherwise block the one being challenged.
+func (c answerCommand) Cooldown() time.Duration {
+	return 0
+}
+
+func (c answerCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	response.ReplyTo = ctx.Message.ID
+
+	interaction, err := c.interactionService.Take(ctx.Context, coreData.InteractionTake{
+		UserID:       ctx.Channel.UserID,
+		Platform:     ctx.Channel.Platform,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+	if err != nil {
+		response.Message = "couldnt " + c.Name() + ", got error: " + err.Error()
+		return response, nil
+	}
+
+	answerer, ok := c.answerers[interaction.Kind]
+	if !ok {
+		response.Message = "couldnt " + c.Name() + ", got error: unknown " + interaction.Kind.String()
+		return response, nil
+	}
+
+	if c.accept {
+		response.Message = answerer.Accept(ctx, interaction)
+	} else {
+		response.Message = answerer.Decline(ctx, interaction)
+	}
+	return response, nil
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+type permitCommand struct {
+	moderationService *service.ModerationService
+}
+
+func NewPermitCommand(
+	moderationService *service.ModerationService,
+) permitCommand {
+	return permitCommand{
+		moderationService: moderationService,
+	}
+}
+
+func (c permitCommand) Name() string {
+	return "permit"
+}
+
+func (c permitCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c permitCommand) Description() string {
+	return "example: !permit @user (one link) or !permit @user 10m (any links for 10 minutes)"
+}
+
+func (c permitCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c permitCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	args := strings.Fields(ctx.Command.Args)
+	if len(args) == 0 || len(args) > 2 {
+		response.Message = c.Description()
+		return response, nil
+	}
+
+	permit := coreData.LinkPermit{
+		Platform:      ctx.Channel.Platform,
+		BroadcasterID: ctx.Channel.ID,
+		ChatterLogin:  args[0],
+	}
+	if len(args) == 2 {
+		duration, ok := parsePermitDuration(args[1])
+		if !ok {
+			response.Message = "duration must be like 90, 90s, 10m or 1h, " + c.Description()
+			return response, nil
+		}
+		permit.Duration = duration
+	}
+
+	permit, err := c.moderationService.Permit(ctx.Context, permit)
+	if err != nil {
+		response.Message = "couldnt permit links, got error: " + err.Error()
+		return response, nil
+	}
+
+	if permit.Duration == 0 {
+		response.Message = "@" + permit.ChatterLogin + " can post one link"
+	} else {
+		response.Message = "@" + permit.ChatterLogin + " can post links for " + (time.Second * time.Duration(permit.Duration)).String()
+	}
+	return response, nil
+}
+
+// parsePermitDuration reads a duration given as seconds or in Go duration
+// syntax, returning it in whole seconds.
+func parsePermitDuration(value string) (int32, bool) {
+	if seconds, err := strconv.ParseInt(value, 10, 32); err == nil {
+		return int32(seconds), seconds > 0
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < time.Second || duration.Seconds() > float64(1<<31-1) {
+		return 0, false
+	}
+	return int32(duration.Seconds()), true
+}
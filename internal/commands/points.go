@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+// formatPoints writes an amount of points for chat.
+func formatPoints(amount int64) string {
+	if amount == 1 || amount == -1 {
+		return strconv.FormatInt(amount, 10) + " point"
+	}
+	return strconv.FormatInt(amount, 10) + " points"
+}
+
+// parseChatterAmount reads the "@user N" arguments of the points commands.
+func parseChatterAmount(args string) (string, int64, bool) {
+	fields := strings.Fields(args)
+	if len(fields) != 2 {
+		return "", 0, false
+	}
+	amount, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return fields[0], amount, true
+}
+
+func pointsChatterOf(ctx cmdtypes.CommandContext) coreData.PointsChatter {
+	return coreData.PointsChatter{
+		UserID:       ctx.Channel.UserID,
+		Platform:     ctx.Channel.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	}
+}
+
+type pointsCommand struct {
+	pointsService *service.PointsService
+}
+
+func NewPointsCommand(
+	pointsService *service.PointsService,
+) pointsCommand {
+	return pointsCommand{
+		pointsService: pointsService,
+	}
+}
+
+func (c pointsCommand) Name() string {
+	return "points"
+}
+
+func (c pointsCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c pointsCommand) Description() string {
+	return "example: !points or !points @user"
+}
+
+// Cooldown is zero: the cooldown is shared by the whole channel, and one
+// chatter checking or giving points must not block the others.
+func (c pointsCommand) Cooldown() time.Duration {
+	return 0
+}
+
+// AllowsPrivate lets chatters check balances without posting in chat.
+func (c pointsCommand) AllowsPrivate(command cmdtypes.ParsedCommand) bool {
+	return true
+}
+
+func (c pointsCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if !c.pointsService.Enabled(ctx.Context, ctx.Channel.UserID) {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	chatter := pointsChatterOf(ctx)
+	login, _, _ := strings.Cut(strings.TrimSpace(ctx.Command.Args), " ")
+	if login != "" {
+		chatter.ChatterID = ""
+		chatter.ChatterLogin = login
+	}
+
+	account, err := c.pointsService.Balance(ctx.Context, chatter)
+	if err != nil {
+		response.Message = "couldnt get points, got error: " + err.Error()
+		return response, nil
+	}
+
+	response.Message = "@" + account.ChatterLogin + " has " + formatPoints(account.Balance)
+	return response, nil
+}
+
+type giveCommand struct {
+	pointsService *service.PointsService
+}
+
+func NewGiveCommand(
+	pointsService *service.PointsService,
+) giveCommand {
+	return giveCommand{
+		pointsService: pointsService,
+	}
+}
+
+func (c giveCommand) Name() string {
+	return "give"
+}
+
+func (c giveCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c giveCommand) Description() string {
+	return "example: !give @user 100"
+}
+
+// Cooldown is zero: the cooldown is shared by the whole channel, and one
+// chatter checking or giving points must not block the others.
+func (c giveCommand) Cooldown() time.Duration {
+	return 0
+}
+
+func (c giveCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if !c.pointsService.Enabled(ctx.Context, ctx.Channel.UserID) {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	login, amount, ok := parseChatterAmount(ctx.Command.Args)
+	if !ok {
+		response.Message = c.Description()
+		return response, nil
+	}
+
+	to := pointsChatterOf(ctx)
+	to.ChatterID = ""
+	to.ChatterLogin = login
+
+	result, err := c.pointsService.Transfer(ctx.Context, coreData.PointsTransfer{
+		From:           pointsChatterOf(ctx),
+		To:             to,
+		Amount:         amount,
+		Reason:         coreData.PointsReasonGive,
+		IdempotencyKey: service.PointsIdempotencyKey(coreData.PointsReasonGive, ctx.Chatter.Platform, ctx.Message.ID),
+	})
+	if err != nil {
+		response.Message = "couldnt give points, got error: " + err.Error()
+		return response, nil
+	}
+
+	response.Message = "gave " + formatPoints(amount) + " to @" + result.To.ChatterLogin + ", you have " + formatPoints(result.From.Balance) + " left"
+	return response, nil
+}
+
+type addPointsCommand struct {
+	pointsService *service.PointsService
+}
+
+func NewAddPointsCommand(
+	pointsService *service.PointsService,
+) addPointsCommand {
+	return addPointsCommand{
+		pointsService: pointsService,
+	}
+}
+
+func (c addPointsCommand) Name() string {
+	return "addpoints"
+}
+
+func (c addPointsCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c addPointsCommand) Description() string {
+	return "example: !addpoints @user 100 (a negative amount removes points)"
+}
+
+func (c addPointsCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c addPointsCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	login, amount, ok := parseChatterAmount(ctx.Command.Args)
+	if !ok {
+		response.Message = c.Description()
+		return response, nil
+	}
+
+	chatter := pointsChatterOf(ctx)
+	chatter.ChatterID = ""
+	chatter.ChatterLogin = login
+
+	account, err := c.pointsService.Adjust(ctx.Context, coreData.PointsAdjust{
+		PointsChatter:  chatter,
+		Amount:         amount,
+		Reason:         coreData.PointsReasonAdjust,
+		IdempotencyKey: service.PointsIdempotencyKey(coreData.PointsReasonAdjust, ctx.Chatter.Platform, ctx.Message.ID),
+	})
+	if err != nil {
+		response.Message = "couldnt add points, got error: " + err.Error()
+		return response, nil
+	}
+
+	response.Message = "@" + account.ChatterLogin + " now has " + formatPoints(account.Balance)
+	return response, nil
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	pollEndOp = "end"
+
+	pollOptionSeparator = "|"
+)
+
+type pollCommand struct {
+	pollService *service.PollService
+}
+
+func NewPollCommand(
+	pollService *service.PollService,
+) pollCommand {
+	return pollCommand{
+		pollService: pollService,
+	}
+}
+
+func (c pollCommand) Name() string {
+	return "poll"
+}
+
+func (c pollCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c pollCommand) Description() string {
+	return `example: !poll, !poll "pizza or tacos?" pizza | tacos | both 5m, !poll end ` +
+		"(vote by typing the number or the name of an option)"
+}
+
+func (c pollCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c pollCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	response.ReplyTo = ctx.Message.ID
+
+	args := strings.TrimSpace(ctx.Command.Args)
+	if args == "" {
+		poll, err := c.pollService.Get(ctx.Context, coreData.PollGet{
+			UserID:   ctx.Channel.UserID,
+			Platform: ctx.Channel.Platform,
+		})
+		if err != nil {
+			response.Message = "couldnt get poll, got error: " + err.Error()
+			return response, nil
+		}
+		if poll.Open(time.Now()) {
+			response.Message = poll.Standings()
+		} else {
+			response.Message = poll.Results()
+		}
+		return response, nil
+	}
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	if args == pollEndOp {
+		// the results are posted by the service, for polls ended elsewhere too
+		_, err := c.pollService.End(ctx.Context, coreData.PollEnd{
+			UserID:   ctx.Channel.UserID,
+			Platform: ctx.Channel.Platform,
+		})
+		if err != nil {
+			response.Message = "couldnt end poll, got error: " + err.Error()
+		}
+		return response, nil
+	}
+
+	start, err := parsePollStart(args)
+	if err != nil {
+		response.Message = err.Error() + ", " + c.Description()
+		return response, nil
+	}
+	start.UserID = ctx.Channel.UserID
+	start.Platform = ctx.Channel.Platform
+
+	poll, err := c.pollService.Start(ctx.Context, start)
+	if err != nil {
+		response.Message = "couldnt start poll, got error: " + err.Error()
+		return response, nil
+	}
+
+	response.Message = "poll started! vote with the number or the name: " + poll.Standings()
+	if poll.EndsAt != nil {
+		response.Message += ", ends in " + time.Until(*poll.EndsAt).Round(time.Second).String()
+	}
+	return response, nil
+}
+
+// parsePollStart reads a quoted question followed by options separated by
+// pipes, the last one optionally followed by a duration like 5m.
+func parsePollStart(args string) (coreData.PollStart, error) {
+	var start coreData.PollStart
+
+	if !strings.HasPrefix(args, `"`) {
+		return start, errors.New("the question must be in quotes")
+	}
+	question, rest, ok := strings.Cut(args[1:], `"`)
+	if !ok {
+		return start, errors.New("the question is missing its closing quote")
+	}
+	start.Question = question
+
+	start.Options = strings.Split(rest, pollOptionSeparator)
+	last := strings.TrimSpace(start.Options[len(start.Options)-1])
+	if i := strings.LastIndex(last, " "); i >= 0 {
+		duration, err := time.ParseDuration(last[i+1:])
+		if err == nil {
+			start.Duration = int32(duration / time.Second)
+			start.Options[len(start.Options)-1] = last[:i]
+		}
+	}
+
+	return start, nil
+}
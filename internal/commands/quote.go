@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+const quoteSearchOp = "search"
+
+type quoteCommand struct {
+	quoteService *service.QuoteService
+}
+
+func NewQuoteCommand(
+	quoteService *service.QuoteService,
+) quoteCommand {
+	return quoteCommand{
+		quoteService: quoteService,
+	}
+}
+
+func (c quoteCommand) Name() string {
+	return "quote"
+}
+
+func (c quoteCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c quoteCommand) Description() string {
+	return "example: !quote, !quote 42, !quote " + quoteSearchOp + " text, !quote (" + createOp + "|" + updateOp + "|" + deleteOp + ")"
+}
+
+func (c quoteCommand) OpDescription(op string) string {
+	switch op {
+	case createOp:
+		return op + " example: !quote " + op + " i am not lost, the map is wrong"
+	case updateOp:
+		return op + " example: !quote " + op + " 42 i am not lost, the map is"
+	case deleteOp:
+		return op + " example: !quote " + op + " 42"
+	case quoteSearchOp:
+		return op + " example: !quote " + op + " map"
+	default:
+		return c.Description()
+	}
+}
+
+func (c quoteCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c quoteCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	response.ReplyTo = ctx.Message.ID
+
+	operation, rest, _ := strings.Cut(strings.TrimSpace(ctx.Command.Args), " ")
+	rest = strings.TrimSpace(rest)
+
+	get := coreData.QuoteGet{UserID: ctx.Channel.UserID}
+	switch operation {
+	case "":
+	case quoteSearchOp:
+		if rest == "" {
+			response.Message = c.OpDescription(operation)
+			return response, nil
+		}
+		get.Search = rest
+	case createOp, updateOp, deleteOp:
+		return c.edit(ctx, operation, rest)
+	default:
+		number, err := strconv.ParseInt(strings.TrimPrefix(operation, "#"), 10, 32)
+		if err != nil {
+			response.Message = c.Description()
+			return response, nil
+		}
+		get.Number = int32(number)
+	}
+
+	quote, err := c.quoteService.Get(ctx.Context, get)
+	if err != nil {
+		response.Message = "couldnt get quote, got error: " + err.Error()
+		return response, nil
+	}
+	response.Message = formatQuote(quote)
+	return response, nil
+}
+
+// edit runs the operations that change quotes, which are for moderators.
+func (c quoteCommand) edit(ctx cmdtypes.CommandContext, operation string, rest string) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	if operation == createOp {
+		if rest == "" {
+			response.Message = c.OpDescription(operation)
+			return response, nil
+		}
+		quote, err := c.quoteService.Create(ctx.Context, coreData.QuoteCreate{
+			UserID:        ctx.Channel.UserID,
+			Text:          rest,
+			Platform:      ctx.Channel.Platform,
+			BroadcasterID: ctx.Channel.ID,
+		})
+		if err != nil {
+			response.Message = "couldnt add quote, got error: " + err.Error()
+			return response, nil
+		}
+		response.Message = "quote #" + strconv.Itoa(int(quote.Number)) + " added!"
+		return response, nil
+	}
+
+	field, text, _ := strings.Cut(rest, " ")
+	number, err := strconv.ParseInt(strings.TrimPrefix(field, "#"), 10, 32)
+	if err != nil || (operation == updateOp && strings.TrimSpace(text) == "") {
+		response.Message = c.OpDescription(operation)
+		return response, nil
+	}
+
+	switch operation {
+	case updateOp:
+		_, err = c.quoteService.Update(ctx.Context, coreData.QuoteUpdate{
+			UserID: ctx.Channel.UserID,
+			Number: int32(number),
+			Text:   &text,
+		})
+		if err != nil {
+			response.Message = "couldnt update quote, got error: " + err.Error()
+			break
+		}
+		response.Message = "quote #" + strconv.Itoa(int(number)) + " updated!"
+	case deleteOp:
+		_, err = c.quoteService.Delete(ctx.Context, coreData.QuoteDelete{
+			UserID: ctx.Channel.UserID,
+			Number: int32(number),
+		})
+		if err != nil {
+			response.Message = "couldnt delete quote, got error: " + err.Error()
+			break
+		}
+		response.Message = "quote #" + strconv.Itoa(int(number)) + " deleted!"
+	}
+	return response, nil
+}
+
+// formatQuote reads like "#42: i am not lost, the map is wrong [Minecraft,
+// 2026-10-19]".
+func formatQuote(quote coreData.Quote) string {
+	added := quote.CreatedAt.Format(time.DateOnly)
+	if quote.Category != "" {
+		added = quote.Category + ", " + added
+	}
+	return "#" + strconv.Itoa(int(quote.Number)) + ": " + quote.Text + " [" + added + "]"
+}
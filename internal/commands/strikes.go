@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+type strikesCommand struct {
+	moderationService *service.ModerationService
+}
+
+func NewStrikesCommand(
+	moderationService *service.ModerationService,
+) strikesCommand {
+	return strikesCommand{
+		moderationService: moderationService,
+	}
+}
+
+func (c strikesCommand) Name() string {
+	return "strikes"
+}
+
+func (c strikesCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c strikesCommand) Description() string {
+	return "example: !strikes @user"
+}
+
+func (c strikesCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c strikesCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	login, _, _ := strings.Cut(strings.TrimSpace(ctx.Command.Args), " ")
+	if login == "" {
+		response.Message = c.Description()
+		return response, nil
+	}
+
+	strikes, err := c.moderationService.Strikes(ctx.Context, coreData.StrikeChatter{
+		UserID:        ctx.Channel.UserID,
+		Platform:      ctx.Channel.Platform,
+		BroadcasterID: ctx.Channel.ID,
+		ChatterLogin:  login,
+	})
+	if err != nil {
+		response.Message = "couldnt count strikes, got error: " + err.Error()
+		return response, nil
+	}
+
+	response.Message = "@" + strikes.ChatterLogin + " has " + strconv.Itoa(strikes.Count) + " strikes"
+	return response, nil
+}
+
+type pardonCommand struct {
+	moderationService *service.ModerationService
+}
+
+func NewPardonCommand(
+	moderationService *service.ModerationService,
+) pardonCommand {
+	return pardonCommand{
+		moderationService: moderationService,
+	}
+}
+
+func (c pardonCommand) Name() string {
+	return "pardon"
+}
+
+func (c pardonCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c pardonCommand) Description() string {
+	return "example: !pardon @user (forgets their strikes)"
+}
+
+func (c pardonCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c pardonCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	login, _, _ := strings.Cut(strings.TrimSpace(ctx.Command.Args), " ")
+	if login == "" {
+		response.Message = c.Description()
+		return response, nil
+	}
+
+	strikes, err := c.moderationService.Pardon(ctx.Context, coreData.StrikeChatter{
+		UserID:        ctx.Channel.UserID,
+		Platform:      ctx.Channel.Platform,
+		BroadcasterID: ctx.Channel.ID,
+		ChatterLogin:  login,
+	})
+	if err != nil {
+		response.Message = "couldnt pardon, got error: " + err.Error()
+		return response, nil
+	}
+
+	response.Message = "@" + strikes.ChatterLogin + " pardoned, " + strconv.Itoa(strikes.Count) + " strikes forgotten"
+	return response, nil
+}
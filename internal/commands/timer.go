@@ -0,0 +1,281 @@
+package commands
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	intervalFlag = "-i="
+	linesFlag    = "-lines="
+	onlineFlag   = "-online"
+	alwaysFlag   = "-always"
+)
+
+type timerCommand struct {
+	timerService *service.TimerService
+}
+
+func NewTimerCommand(
+	timerService *service.TimerService,
+) timerCommand {
+	return timerCommand{
+		timerService: timerService,
+	}
+}
+
+func (c timerCommand) Name() string {
+	return "timer"
+}
+
+func (c timerCommand) Aliases() []string {
+	return []string{
+		"timer" + createOp,
+		"timer" + updateOp,
+		"timer" + deleteOp,
+		"timer" + enableOp,
+		"timer" + disableOp,
+		"timer" + listOp,
+		"timer" + optionsOp,
+	}
+}
+
+func (c timerCommand) Description() string {
+	return "example: !timer (add|edit|del|enable|disable|list|options) timer_name minutes text of timer (minutes only for add, text only for add or edit)"
+}
+
+func (c timerCommand) OpDescription(op string) string {
+	switch op {
+	case createOp:
+		return op + " example: !timer " + op + " discord 15 Join our discord! (every 15 minutes)"
+	case updateOp:
+		return op + " example: !timer " + op + " discord Join our new discord!"
+	case deleteOp, enableOp, disableOp:
+		return op + " example: !timer " + op + " discord"
+	case listOp:
+		return op + " example: !timer " + op
+	case optionsOp:
+		return op + " example: !timer " + op + " discord " + intervalFlag + "20 " + linesFlag + "10 " + onlineFlag + " (" +
+			intervalFlag + "minutes, " + linesFlag + "chat lines since last post, " + onlineFlag + " or " + alwaysFlag + ")"
+	default:
+		return c.Description()
+	}
+}
+
+func (c timerCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c timerCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	var operation string
+	var rest string
+
+	if slices.Contains(c.Aliases(), ctx.Command.Command) {
+		operation = strings.TrimPrefix(ctx.Command.Command, "timer")
+		rest = ctx.Command.Args
+	} else {
+		operation, rest, _ = strings.Cut(ctx.Command.Args, " ")
+	}
+
+	switch operation {
+	case createOp:
+		fields := strings.SplitN(strings.TrimSpace(rest), " ", 3)
+		if len(fields) < 3 {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		minutes, err := strconv.ParseInt(fields[1], 10, 16)
+		if err != nil {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		_, err = c.timerService.Create(ctx.Context, coreData.TimerCreate{
+			UserID:   ctx.Channel.UserID,
+			Platform: ctx.Channel.Platform,
+			Name:     fields[0],
+			Text:     fields[2],
+			Interval: int32(minutes) * 60,
+		})
+		if err != nil {
+			response.Message = "couldnt create timer, got error: " + err.Error()
+			break
+		}
+		response.Message = "timer created!"
+	case updateOp:
+		name, text, _ := strings.Cut(rest, " ")
+		if text == "" || name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		_, err := c.timerService.Update(ctx.Context, coreData.TimerUpdate{
+			UserID:   ctx.Channel.UserID,
+			Platform: ctx.Channel.Platform,
+			Name:     name,
+			Text:     &text,
+		})
+		if err != nil {
+			response.Message = "couldnt update timer, got error: " + err.Error()
+			break
+		}
+		response.Message = "timer updated!"
+	case deleteOp:
+		name, _, _ := strings.Cut(rest, " ")
+		if name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		_, err := c.timerService.Delete(ctx.Context, coreData.TimerDelete{
+			UserID:   ctx.Channel.UserID,
+			Platform: ctx.Channel.Platform,
+			Name:     name,
+		})
+		if err != nil {
+			response.Message = "couldnt delete timer, got error: " + err.Error()
+			break
+		}
+		response.Message = "timer deleted!"
+	case enableOp, disableOp:
+		name, _, _ := strings.Cut(rest, " ")
+		if name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		enabled := operation == enableOp
+		_, err := c.timerService.Update(ctx.Context, coreData.TimerUpdate{
+			UserID:   ctx.Channel.UserID,
+			Platform: ctx.Channel.Platform,
+			Name:     name,
+			Enabled:  &enabled,
+		})
+		if err != nil {
+			response.Message = "couldnt " + operation + " timer, got error: " + err.Error()
+			break
+		}
+		response.Message = "timer " + operation + "d!"
+	case listOp:
+		timers, err := c.timerService.List(ctx.Context, coreData.TimerList{
+			UserID: ctx.Channel.UserID,
+		})
+		if err != nil {
+			response.Message = "couldnt list timers, got error: " + err.Error()
+			break
+		}
+		response.Message = c.list(ctx.Channel, timers)
+	case optionsOp:
+		name, flags, _ := strings.Cut(rest, " ")
+		update, err := parseTimerOptions(strings.Fields(flags))
+		if name == "" || err != nil {
+			response.Message = c.OpDescription(operation)
+			if err != nil {
+				response.Message = err.Error() + ", " + response.Message
+			}
+			break
+		}
+		update.UserID = ctx.Channel.UserID
+		update.Platform = ctx.Channel.Platform
+		update.Name = name
+		timer, err := c.timerService.Update(ctx.Context, update)
+		if err != nil {
+			response.Message = "couldnt change options, got error: " + err.Error()
+			break
+		}
+		response.Message = "options updated! " + c.show(timer)
+	default:
+		response.Message = c.Description()
+	}
+	return response, nil
+}
+
+func (c timerCommand) show(timer coreData.Timer) string {
+	settings := []string{
+		"every " + strconv.Itoa(int(timer.Interval/60)) + "m",
+		strconv.Itoa(int(timer.MinLines)) + " lines",
+	}
+	if timer.OnlineOnly {
+		settings = append(settings, "online")
+	}
+	if !timer.Enabled {
+		settings = append(settings, "disabled")
+	}
+
+	return timer.Name + " [" + strings.Join(settings, ", ") + "]"
+}
+
+// list names the timers of the channel the command was sent in.
+func (c timerCommand) list(channel cmdtypes.PlatformUser, timers []coreData.Timer) string {
+	var shown []string
+	for _, timer := range timers {
+		if timer.Platform == channel.Platform {
+			shown = append(shown, c.show(timer))
+		}
+	}
+	if len(shown) == 0 {
+		return "there are no timers yet"
+	}
+
+	message := "timers: " + strings.Join(shown, ", ")
+	if runes := []rune(message); len(runes) > listMessageLength {
+		message = string(runes[:listMessageLength]) + "…"
+	}
+	return message
+}
+
+// parseTimerOptions reads the flags of the options operation into an update.
+func parseTimerOptions(flags []string) (coreData.TimerUpdate, error) {
+	var update coreData.TimerUpdate
+
+	if len(flags) == 0 {
+		return update, errors.New("no options given")
+	}
+
+	for _, flag := range flags {
+		switch {
+		case flag == onlineFlag, flag == alwaysFlag:
+			onlineOnly := flag == onlineFlag
+			update.OnlineOnly = &onlineOnly
+		case strings.HasPrefix(flag, intervalFlag):
+			minutes, err := strconv.ParseInt(strings.TrimPrefix(flag, intervalFlag), 10, 16)
+			if err != nil {
+				return update, errors.New("interval must be a number of minutes")
+			}
+			interval := int32(minutes) * 60
+			update.Interval = &interval
+		case strings.HasPrefix(flag, linesFlag):
+			lines, err := strconv.ParseInt(strings.TrimPrefix(flag, linesFlag), 10, 32)
+			if err != nil {
+				return update, errors.New("lines must be a number")
+			}
+			minLines := int32(lines)
+			update.MinLines = &minLines
+		default:
+			return update, errors.New("unknown option " + flag)
+		}
+	}
+
+	return update, nil
+}
@@ -0,0 +1,292 @@
+package commands
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	addRegexOp = "addregex"
+	patternOp  = "pattern"
+)
+
+// patternSeparator splits the pattern of a trigger from its response.
+const patternSeparator = " | "
+
+type triggerCommand struct {
+	triggerService *service.TriggerService
+}
+
+func NewTriggerCommand(
+	triggerService *service.TriggerService,
+) triggerCommand {
+	return triggerCommand{
+		triggerService: triggerService,
+	}
+}
+
+func (c triggerCommand) Name() string {
+	return "trigger"
+}
+
+func (c triggerCommand) Aliases() []string {
+	return []string{
+		"trigger" + createOp,
+		"trigger" + addRegexOp,
+		"trigger" + updateOp,
+		"trigger" + patternOp,
+		"trigger" + deleteOp,
+		"trigger" + enableOp,
+		"trigger" + disableOp,
+		"trigger" + listOp,
+		"trigger" + optionsOp,
+	}
+}
+
+func (c triggerCommand) Description() string {
+	return "example: !trigger (add|addregex|edit|pattern|del|enable|disable|list|options) trigger_name phrase or regex | response (pattern only for add, addregex or pattern, response only for add, addregex or edit)"
+}
+
+func (c triggerCommand) OpDescription(op string) string {
+	switch op {
+	case createOp:
+		return op + " example: !trigger " + op + " hello good morning" + patternSeparator + "Good morning {user}!"
+	case addRegexOp:
+		return op + " example: !trigger " + op + " rank ^what rank is (\\w+)" + patternSeparator + "{1} is unranked"
+	case updateOp:
+		return op + " example: !trigger " + op + " hello Good morning to you too {user}!"
+	case patternOp:
+		return op + " example: !trigger " + op + " hello good evening"
+	case deleteOp, enableOp, disableOp:
+		return op + " example: !trigger " + op + " hello"
+	case listOp:
+		return op + " example: !trigger " + op
+	case optionsOp:
+		return op + " example: !trigger " + op + " hello " + cooldownFlag + "30 " + userLevelFlag + "sub (" +
+			cooldownFlag + "seconds, " + userLevelFlag + "(everyone|sub|vip|moderator|broadcaster))"
+	default:
+		return c.Description()
+	}
+}
+
+func (c triggerCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c triggerCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	response.ReplyTo = ctx.Message.ID
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	var operation string
+	var rest string
+
+	if slices.Contains(c.Aliases(), ctx.Command.Command) {
+		operation = strings.TrimPrefix(ctx.Command.Command, "trigger")
+		rest = ctx.Command.Args
+	} else {
+		operation, rest, _ = strings.Cut(ctx.Command.Args, " ")
+	}
+
+	switch operation {
+	case createOp, addRegexOp:
+		name, definition, _ := strings.Cut(strings.TrimSpace(rest), " ")
+		pattern, text, found := strings.Cut(definition, patternSeparator)
+		if name == "" || !found {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		kind := coreData.TriggerKindPhrase
+		if operation == addRegexOp {
+			kind = coreData.TriggerKindRegex
+		}
+		_, err := c.triggerService.Create(ctx.Context, coreData.TriggerCreate{
+			UserID:  ctx.Channel.UserID,
+			Name:    name,
+			Kind:    kind,
+			Pattern: pattern,
+			Text:    text,
+		})
+		if err != nil {
+			response.Message = "couldnt create trigger, got error: " + err.Error()
+			break
+		}
+		response.Message = "trigger created!"
+	case updateOp:
+		name, text, _ := strings.Cut(rest, " ")
+		if text == "" || name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		_, err := c.triggerService.Update(ctx.Context, coreData.TriggerUpdate{
+			UserID: ctx.Channel.UserID,
+			Name:   name,
+			Text:   &text,
+		})
+		if err != nil {
+			response.Message = "couldnt update trigger, got error: " + err.Error()
+			break
+		}
+		response.Message = "trigger updated!"
+	case patternOp:
+		name, pattern, _ := strings.Cut(rest, " ")
+		if pattern == "" || name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		_, err := c.triggerService.Update(ctx.Context, coreData.TriggerUpdate{
+			UserID:  ctx.Channel.UserID,
+			Name:    name,
+			Pattern: &pattern,
+		})
+		if err != nil {
+			response.Message = "couldnt change pattern, got error: " + err.Error()
+			break
+		}
+		response.Message = "pattern changed!"
+	case deleteOp:
+		name, _, _ := strings.Cut(rest, " ")
+		if name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		_, err := c.triggerService.Delete(ctx.Context, coreData.TriggerDelete{
+			UserID: ctx.Channel.UserID,
+			Name:   name,
+		})
+		if err != nil {
+			response.Message = "couldnt delete trigger, got error: " + err.Error()
+			break
+		}
+		response.Message = "trigger deleted!"
+	case enableOp, disableOp:
+		name, _, _ := strings.Cut(rest, " ")
+		if name == "" {
+			response.Message = c.OpDescription(operation)
+			break
+		}
+		enabled := operation == enableOp
+		_, err := c.triggerService.Update(ctx.Context, coreData.TriggerUpdate{
+			UserID:  ctx.Channel.UserID,
+			Name:    name,
+			Enabled: &enabled,
+		})
+		if err != nil {
+			response.Message = "couldnt " + operation + " trigger, got error: " + err.Error()
+			break
+		}
+		response.Message = "trigger " + operation + "d!"
+	case listOp:
+		triggers, err := c.triggerService.List(ctx.Context, coreData.TriggerList{
+			UserID: ctx.Channel.UserID,
+		})
+		if err != nil {
+			response.Message = "couldnt list triggers, got error: " + err.Error()
+			break
+		}
+		response.Message = c.list(triggers)
+	case optionsOp:
+		name, flags, _ := strings.Cut(rest, " ")
+		update, err := parseTriggerOptions(strings.Fields(flags))
+		if name == "" || err != nil {
+			response.Message = c.OpDescription(operation)
+			if err != nil {
+				response.Message = err.Error() + ", " + response.Message
+			}
+			break
+		}
+		update.UserID = ctx.Channel.UserID
+		update.Name = name
+		trigger, err := c.triggerService.Update(ctx.Context, update)
+		if err != nil {
+			response.Message = "couldnt change options, got error: " + err.Error()
+			break
+		}
+		response.Message = "options updated! " + c.show(trigger)
+	default:
+		response.Message = c.Description()
+	}
+	return response, nil
+}
+
+func (c triggerCommand) show(trigger coreData.Trigger) string {
+	settings := []string{
+		trigger.Kind.String(),
+		"level " + trigger.UserLevel.String(),
+		"cd " + strconv.Itoa(int(trigger.Cooldown)) + "s",
+	}
+	if !trigger.Enabled {
+		settings = append(settings, "disabled")
+	}
+
+	return trigger.Name + " [" + strings.Join(settings, ", ") + "]"
+}
+
+func (c triggerCommand) list(triggers []coreData.Trigger) string {
+	if len(triggers) == 0 {
+		return "there are no triggers yet"
+	}
+
+	names := make([]string, 0, len(triggers))
+	for _, trigger := range triggers {
+		names = append(names, trigger.Name)
+	}
+
+	message := "triggers: " + strings.Join(names, ", ")
+	if runes := []rune(message); len(runes) > listMessageLength {
+		message = string(runes[:listMessageLength]) + "…"
+	}
+	return message
+}
+
+// parseTriggerOptions reads the flags of the options operation into an update.
+func parseTriggerOptions(flags []string) (coreData.TriggerUpdate, error) {
+	var update coreData.TriggerUpdate
+
+	if len(flags) == 0 {
+		return update, errors.New("no options given")
+	}
+
+	for _, flag := range flags {
+		switch {
+		case strings.HasPrefix(flag, cooldownFlag):
+			seconds, err := strconv.ParseInt(strings.TrimPrefix(flag, cooldownFlag), 10, 32)
+			if err != nil {
+				return update, errors.New("cooldown must be a number of seconds")
+			}
+			cooldown := int32(seconds)
+			update.Cooldown = &cooldown
+		case strings.HasPrefix(flag, userLevelFlag):
+			userLevel := coreData.UserLevel(strings.ToLower(strings.TrimPrefix(flag, userLevelFlag)))
+			if !userLevel.IsEnum() {
+				return update, errors.New("unknown user level " + userLevel.String())
+			}
+			update.UserLevel = &userLevel
+		default:
+			return update, errors.New("unknown option " + flag)
+		}
+	}
+
+	return update, nil
+}
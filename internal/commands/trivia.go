@@ -0,0 +1,233 @@
+package commands
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+	"github.com/arnokay/arnobot-shared/data"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/commands/cmdtypes"
+	coreData "github.com/arnokay/arnobot-core/internal/data"
+)
+
+const (
+	triviaStartOp      = "start"
+	triviaStopOp       = "stop"
+	triviaTopOp        = "top"
+	triviaCategoriesOp = "categories"
+
+	rewardFlag     = "-reward="
+	answerTimeFlag = "-time="
+)
+
+type triviaCommand struct {
+	triviaService *service.TriviaService
+}
+
+func NewTriviaCommand(
+	triviaService *service.TriviaService,
+) triviaCommand {
+	return triviaCommand{
+		triviaService: triviaService,
+	}
+}
+
+func (c triviaCommand) Name() string {
+	return "trivia"
+}
+
+func (c triviaCommand) Aliases() []string {
+	return []string{}
+}
+
+func (c triviaCommand) Description() string {
+	return "example: !trivia, !trivia top, !trivia categories, !trivia (start|stop) (answer by typing it in chat)"
+}
+
+func (c triviaCommand) OpDescription(op string) string {
+	switch op {
+	case triviaStartOp:
+		return op + " example: !trivia " + op + " science 10 " + rewardFlag + "50 " + answerTimeFlag + "20 (" +
+			"all optional; a category, the number of rounds, " + rewardFlag + "points per correct answer, " +
+			answerTimeFlag + "seconds to answer)"
+	default:
+		return c.Description()
+	}
+}
+
+func (c triviaCommand) Cooldown() time.Duration {
+	return time.Second * 5
+}
+
+func (c triviaCommand) Execute(ctx cmdtypes.CommandContext) (cmdtypes.CommandResponse, error) {
+	var response cmdtypes.CommandResponse
+
+	response.ReplyTo = ctx.Message.ID
+
+	operation, rest, _ := strings.Cut(strings.TrimSpace(ctx.Command.Args), " ")
+	switch operation {
+	case "":
+		session, err := c.triviaService.Get(ctx.Context, coreData.TriviaGet{
+			UserID:   ctx.Channel.UserID,
+			Platform: ctx.Channel.Platform,
+		})
+		if err != nil {
+			response.Message = "couldnt get trivia, got error: " + err.Error()
+			return response, nil
+		}
+		response.Message = c.status(session)
+		return response, nil
+	case triviaTopOp:
+		scores, err := c.triviaService.Top(ctx.Context, coreData.TriviaTop{
+			UserID:   ctx.Channel.UserID,
+			Platform: ctx.Channel.Platform,
+		})
+		if err != nil {
+			response.Message = "couldnt get trivia top, got error: " + err.Error()
+			return response, nil
+		}
+		if len(scores) == 0 {
+			response.Message = "no one answered a trivia question yet"
+			return response, nil
+		}
+		top := make([]string, 0, len(scores))
+		for i, score := range scores {
+			top = append(top, strconv.Itoa(i+1)+". @"+score.ChatterLogin+" "+strconv.Itoa(int(score.Wins)))
+		}
+		response.Message = "trivia top: " + strings.Join(top, ", ")
+		return response, nil
+	case triviaCategoriesOp:
+		categories, err := c.triviaService.Categories(ctx.Context, coreData.TriviaCategoryList{
+			UserID: ctx.Channel.UserID,
+		})
+		if err != nil {
+			response.Message = "couldnt get trivia categories, got error: " + err.Error()
+			return response, nil
+		}
+		names := make([]string, 0, len(categories))
+		for _, category := range categories {
+			names = append(names, category.Name+" ("+strconv.Itoa(category.Questions)+")")
+		}
+		response.Message = "trivia categories: " + strings.Join(names, ", ")
+		return response, nil
+	}
+
+	if ctx.Chatter.Role < data.ChatterModerator {
+		return cmdtypes.CommandResponse{}, apperror.ErrNoAction
+	}
+
+	ctx.Context = appctx.SetActor(ctx.Context, coreData.Actor{
+		Platform:     ctx.Chatter.Platform,
+		ChatterID:    ctx.Chatter.ID,
+		ChatterLogin: ctx.Chatter.Login,
+	})
+
+	switch operation {
+	case triviaStartOp:
+		start, err := parseTriviaStart(rest)
+		if err != nil {
+			response.Message = err.Error() + ", " + c.OpDescription(operation)
+			break
+		}
+		start.UserID = ctx.Channel.UserID
+		start.Platform = ctx.Channel.Platform
+		session, err := c.triviaService.Start(ctx.Context, start)
+		if err != nil {
+			response.Message = "couldnt start trivia, got error: " + err.Error()
+			break
+		}
+		response.Message = "trivia starts! " + c.rules(session)
+	case triviaStopOp:
+		// the scores are posted by the service, for sessions stopped elsewhere too
+		_, err := c.triviaService.Stop(ctx.Context, coreData.TriviaStop{
+			UserID:   ctx.Channel.UserID,
+			Platform: ctx.Channel.Platform,
+		})
+		if err != nil {
+			response.Message = "couldnt stop trivia, got error: " + err.Error()
+		}
+	default:
+		response.Message = c.Description()
+	}
+	return response, nil
+}
+
+func (c triviaCommand) rules(session coreData.TriviaSession) string {
+	category := "all categories"
+	if session.Category != "" {
+		category = session.Category
+	}
+	message := strconv.Itoa(int(session.Rounds)) + " questions of " + category +
+		", " + strconv.Itoa(int(session.AnswerTime)) + "s each, type your answer in chat"
+	if session.Reward > 0 {
+		message += ", " + formatPoints(int64(session.Reward)) + " per correct answer"
+	}
+	return message
+}
+
+func (c triviaCommand) status(session coreData.TriviaSession) string {
+	if session.ClosedAt != nil {
+		message := "no trivia is running"
+		if len(session.Scores) > 0 {
+			message += ", last winner: @" + session.Scores[0].ChatterLogin +
+				" with " + strconv.Itoa(int(session.Scores[0].Wins))
+		}
+		return message
+	}
+
+	message := "trivia is running, round " + strconv.Itoa(int(session.Round)) + "/" + strconv.Itoa(int(session.Rounds))
+	if question, ok := session.Question(); ok && session.RoundEndsAt != nil {
+		message += ": " + question.Question
+	}
+	if len(session.Scores) > 0 {
+		scores := make([]string, 0, len(session.Scores))
+		for _, score := range session.Scores[:min(len(session.Scores), 5)] {
+			scores = append(scores, "@"+score.ChatterLogin+" "+strconv.Itoa(int(score.Wins)))
+		}
+		message += " (" + strings.Join(scores, ", ") + ")"
+	}
+	return message
+}
+
+// parseTriviaStart reads the category, rounds and flags of the start
+// operation. The category may be several words, and a lone number is the
+// number of rounds.
+func parseTriviaStart(args string) (coreData.TriviaStart, error) {
+	var (
+		start    coreData.TriviaStart
+		category []string
+	)
+
+	for _, field := range strings.Fields(args) {
+		var err error
+		switch {
+		case strings.HasPrefix(field, rewardFlag):
+			var reward int32
+			reward, err = parseFlagNumber(field, rewardFlag)
+			start.Reward = &reward
+		case strings.HasPrefix(field, answerTimeFlag):
+			start.AnswerTime, err = parseFlagNumber(field, answerTimeFlag)
+		default:
+			rounds, convErr := strconv.ParseInt(field, 10, 32)
+			if convErr != nil {
+				category = append(category, field)
+				break
+			}
+			if start.Rounds != 0 {
+				return start, errors.New("the number of rounds is given twice")
+			}
+			start.Rounds = int32(rounds)
+		}
+		if err != nil {
+			return start, err
+		}
+	}
+
+	start.Category = strings.Join(category, " ")
+	return start, nil
+}
@@ -0,0 +1,21 @@
+package data
+
+import (
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+)
+
+// Actor identifies who issued a request: a dashboard user for requests coming
+// over NATS, or a chatter for changes made from chat.
+type Actor struct {
+	UserID       uuid.UUID         `json:"userId,omitzero"`
+	Platform     platform.Platform `json:"platform,omitempty"`
+	ChatterID    string            `json:"chatterId,omitempty"`
+	ChatterLogin string            `json:"chatterLogin,omitempty"`
+}
+
+// Owned is implemented by requests that touch resources of a single user, so
+// they can be authorized against the Actor making them.
+type Owned interface {
+	OwnerID() uuid.UUID
+}
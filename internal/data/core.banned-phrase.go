@@ -0,0 +1,56 @@
+package data
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+// BannedPhrase is a phrase the channel does not allow in chat, matched with
+// case, accents, homoglyphs and leetspeak folded. A * in the phrase stands
+// for the rest of a word.
+type BannedPhrase struct {
+	ID     int32     `json:"id"`
+	UserID uuid.UUID `json:"userId"`
+	Phrase string    `json:"phrase"`
+	// Pattern is the normalized phrase that is matched, and that makes two
+	// phrases the same.
+	Pattern   string           `json:"pattern"`
+	Action    ModerationAction `json:"action"`
+	Timeout   int32            `json:"timeout"` // in seconds, for the timeout action
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+func NewBannedPhraseFromDB(fromDB db.CoreBannedPhrase) BannedPhrase {
+	return BannedPhrase{
+		ID:        fromDB.ID,
+		UserID:    fromDB.UserID,
+		Phrase:    fromDB.Phrase,
+		Pattern:   fromDB.Pattern,
+		Action:    ModerationAction(fromDB.Action),
+		Timeout:   fromDB.Timeout,
+		CreatedAt: fromDB.CreatedAt,
+	}
+}
+
+type BannedPhraseCreate struct {
+	UserID  uuid.UUID         `json:"userId"`
+	Phrase  string            `json:"phrase"`
+	Action  *ModerationAction `json:"action"`
+	Timeout *int32            `json:"timeout"`
+}
+
+type BannedPhraseDelete struct {
+	UserID uuid.UUID `json:"userId"`
+	Phrase string    `json:"phrase"`
+}
+
+type BannedPhraseList struct {
+	UserID uuid.UUID `json:"userId"`
+}
+
+func (a BannedPhraseCreate) OwnerID() uuid.UUID { return a.UserID }
+func (a BannedPhraseDelete) OwnerID() uuid.UUID { return a.UserID }
+func (a BannedPhraseList) OwnerID() uuid.UUID   { return a.UserID }
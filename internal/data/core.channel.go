@@ -0,0 +1,16 @@
+package data
+
+import (
+	"github.com/arnokay/arnobot-shared/platform"
+)
+
+// StreamStatus is published by the platform modules when a stream goes online
+// or offline.
+type StreamStatus struct {
+	Platform      platform.Platform `json:"platform"`
+	BroadcasterID string            `json:"broadcasterId"`
+	Online        bool              `json:"online"`
+	// Category is the game or category the stream is in, when the module
+	// knows it.
+	Category string `json:"category"`
+}
@@ -0,0 +1,39 @@
+package data
+
+import (
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+)
+
+// Duel is the payload of a duel interaction.
+type Duel struct {
+	// Stake is put up by both chatters and taken by the winner.
+	Stake int64 `json:"stake"`
+}
+
+// DuelChallenge challenges ToLogin to a duel on behalf of From.
+type DuelChallenge struct {
+	From    PointsChatter `json:"from"`
+	ToLogin string        `json:"toLogin"`
+	Stake   int64         `json:"stake"`
+}
+
+// DuelFight fights the duel of the interaction To accepted, in the message
+// MessageID, which seeds a provably fair roll.
+type DuelFight struct {
+	Interaction Interaction   `json:"interaction"`
+	To          PointsChatter `json:"to"`
+	MessageID   string        `json:"messageId"`
+}
+
+type DuelResult struct {
+	UserID      uuid.UUID         `json:"userId"`
+	Platform    platform.Platform `json:"platform"`
+	WinnerLogin string            `json:"winnerLogin"`
+	LoserLogin  string            `json:"loserLogin"`
+	Stake       int64             `json:"stake"`
+	RollID      int64             `json:"rollId"`
+	// Balance is the one of the winner after the duel, when points were
+	// staked.
+	Balance int64 `json:"balance"`
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"slices"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+// FairGame is a command that rolls provably fair when the channel enabled
+// it.
+type FairGame string
+
+const (
+	FairGameGamba FairGame = "gamba"
+	FairGameDice  FairGame = "dice"
+	FairGameCoin  FairGame = "coin"
+	FairGameDuel  FairGame = "duel"
+)
+
+var fairGameValues = []FairGame{FairGameGamba, FairGameDice, FairGameCoin, FairGameDuel}
+
+func (g FairGame) String() string {
+	return string(g)
+}
+
+func (g FairGame) IsEnum() bool {
+	return slices.Contains(fairGameValues, g)
+}
+
+// FairSettings tells whether the channel rolls provably fair, and the hash
+// of the server seed its next rolls use.
+type FairSettings struct {
+	UserID         uuid.UUID `json:"userId"`
+	Enabled        bool      `json:"enabled"`
+	ServerSeedHash string    `json:"serverSeedHash,omitempty"`
+}
+
+type FairSettingsGet struct {
+	UserID uuid.UUID `json:"userId"`
+}
+
+type FairSettingsUpdate struct {
+	UserID  uuid.UUID `json:"userId"`
+	Enabled *bool     `json:"enabled"`
+}
+
+// FairRoll records one provably fair roll, with everything needed to draw
+// it again but the server seed, until that is revealed.
+type FairRoll struct {
+	ID             int64             `json:"id"`
+	UserID         uuid.UUID         `json:"userId"`
+	Game           FairGame          `json:"game"`
+	Platform       platform.Platform `json:"platform"`
+	ChatterID      string            `json:"chatterId"`
+	ChatterLogin   string            `json:"chatterLogin"`
+	ServerSeedHash string            `json:"serverSeedHash"`
+	ClientSeed     string            `json:"clientSeed"`
+	Nonce          int64             `json:"nonce"`
+	Outcome        string            `json:"outcome"`
+	CreatedAt      time.Time         `json:"createdAt"`
+}
+
+func NewFairRollFromDB(fromDB db.CoreFairRoll) FairRoll {
+	return FairRoll{
+		ID:             fromDB.ID,
+		UserID:         fromDB.UserID,
+		Game:           FairGame(fromDB.Game),
+		Platform:       fromDB.Platform,
+		ChatterID:      fromDB.ChatterID,
+		ChatterLogin:   fromDB.ChatterLogin,
+		ServerSeedHash: fromDB.ServerSeedHash,
+		ClientSeed:     fromDB.ClientSeed,
+		Nonce:          fromDB.Nonce,
+		Outcome:        fromDB.Outcome,
+		CreatedAt:      fromDB.CreatedAt,
+	}
+}
+
+// FairRollCreate is a roll about to be played. The client seed is the ID of
+// the chat message asking for it, which the bot does not choose.
+type FairRollCreate struct {
+	UserID       uuid.UUID
+	Game         FairGame
+	Platform     platform.Platform
+	ChatterID    string
+	ChatterLogin string
+	ClientSeed   string
+}
+
+type FairRollGet struct {
+	UserID uuid.UUID `json:"userId"`
+	ID     int64     `json:"id"`
+}
+
+type FairRollList struct {
+	UserID uuid.UUID `json:"userId"`
+	// ChatterLogin narrows the rolls to one chatter when set.
+	ChatterLogin string `json:"chatterLogin"`
+	BeforeID     int64  `json:"beforeId"`
+	Limit        int32  `json:"limit"`
+}
+
+// FairVerification reveals the server seed of a roll. Revealing it retires
+// it, so the rolls after it use the server seed of NextServerSeedHash.
+type FairVerification struct {
+	FairRoll
+
+	ServerSeed         string `json:"serverSeed"`
+	NextServerSeedHash string `json:"nextServerSeedHash"`
+}
+
+func (a FairSettingsGet) OwnerID() uuid.UUID    { return a.UserID }
+func (a FairSettingsUpdate) OwnerID() uuid.UUID { return a.UserID }
+func (a FairRollGet) OwnerID() uuid.UUID        { return a.UserID }
+func (a FairRollList) OwnerID() uuid.UUID       { return a.UserID }
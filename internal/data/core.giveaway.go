@@ -0,0 +1,97 @@
+package data
+
+import (
+	"time"
+
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+// Giveaway is entered by typing its keyword in chat, by chatters of at
+// least MinLevel.
+type Giveaway struct {
+	ID       int64             `json:"id"`
+	UserID   uuid.UUID         `json:"userId"`
+	Platform platform.Platform `json:"platform"`
+	Keyword  string            `json:"keyword"`
+	MinLevel UserLevel         `json:"minLevel"`
+	// TicketCost in points of every ticket; when zero, entering is free
+	// and gets a single ticket. Otherwise chatters buy up to MaxTickets by
+	// typing their number after the keyword.
+	TicketCost int32 `json:"ticketCost"`
+	MaxTickets int32 `json:"maxTickets"`
+	// SubLuck multiplies the tickets of subscribers and above.
+	SubLuck   int32      `json:"subLuck"`
+	EndsAt    *time.Time `json:"endsAt"`
+	ClosedAt  *time.Time `json:"closedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+
+	Entries int64            `json:"entries"`
+	Tickets int64            `json:"tickets"`
+	Winners []GiveawayWinner `json:"winners"`
+}
+
+func NewGiveawayFromDB(fromDB db.CoreGiveaway) Giveaway {
+	return Giveaway{
+		ID:         fromDB.ID,
+		UserID:     fromDB.UserID,
+		Platform:   fromDB.Platform,
+		Keyword:    fromDB.Keyword,
+		MinLevel:   UserLevel(fromDB.MinLevel),
+		TicketCost: fromDB.TicketCost,
+		MaxTickets: fromDB.MaxTickets,
+		SubLuck:    fromDB.SubLuck,
+		EndsAt:     fromDB.EndsAt,
+		ClosedAt:   fromDB.ClosedAt,
+		CreatedAt:  fromDB.CreatedAt,
+	}
+}
+
+// Open reports whether the giveaway still takes entries at now.
+func (g Giveaway) Open(now time.Time) bool {
+	return g.ClosedAt == nil && (g.EndsAt == nil || g.EndsAt.After(now))
+}
+
+type GiveawayWinner struct {
+	ChatterID    string    `json:"chatterId"`
+	ChatterLogin string    `json:"chatterLogin"`
+	Tickets      int32     `json:"tickets"`
+	DrawnAt      time.Time `json:"drawnAt"`
+}
+
+func NewGiveawayWinnerFromDB(fromDB db.CoreGiveawayEntry) GiveawayWinner {
+	winner := GiveawayWinner{
+		ChatterID:    fromDB.ChatterID,
+		ChatterLogin: fromDB.ChatterLogin,
+		Tickets:      fromDB.Tickets,
+	}
+	if fromDB.DrawnAt != nil {
+		winner.DrawnAt = *fromDB.DrawnAt
+	}
+	return winner
+}
+
+// GiveawayStart starts a giveaway, open for Duration seconds or, when zero,
+// until it is closed.
+type GiveawayStart struct {
+	UserID     uuid.UUID         `json:"userId"`
+	Platform   platform.Platform `json:"platform"`
+	Keyword    string            `json:"keyword"`
+	Duration   int32             `json:"duration"`
+	MinLevel   UserLevel         `json:"minLevel"`
+	TicketCost int32             `json:"ticketCost"`
+	MaxTickets int32             `json:"maxTickets"`
+	SubLuck    int32             `json:"subLuck"`
+}
+
+// GiveawayGet names the last giveaway of a channel, the one that is closed
+// and drawn.
+type GiveawayGet struct {
+	UserID   uuid.UUID         `json:"userId"`
+	Platform platform.Platform `json:"platform"`
+}
+
+func (a GiveawayStart) OwnerID() uuid.UUID { return a.UserID }
+func (a GiveawayGet) OwnerID() uuid.UUID   { return a.UserID }
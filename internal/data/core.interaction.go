@@ -0,0 +1,65 @@
+package data
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+)
+
+// InteractionKind is what a pending interaction asks, and so what answers
+// it.
+type InteractionKind string
+
+const (
+	InteractionKindDuel InteractionKind = "duel"
+)
+
+var interactionKindValues = []InteractionKind{InteractionKindDuel}
+
+func (k InteractionKind) String() string {
+	return string(k)
+}
+
+func (k InteractionKind) IsEnum() bool {
+	return slices.Contains(interactionKindValues, k)
+}
+
+// Interaction is asked by one chatter to another, who answers it with
+// !accept or !decline in a later message before it expires. A chatter has at
+// most one pending interaction per channel.
+type Interaction struct {
+	ID        uuid.UUID         `json:"id"`
+	Kind      InteractionKind   `json:"kind"`
+	UserID    uuid.UUID         `json:"userId"`
+	Platform  platform.Platform `json:"platform"`
+	FromID    string            `json:"fromId"`
+	FromLogin string            `json:"fromLogin"`
+	ToLogin   string            `json:"toLogin"`
+	// Payload is what the kind needs to carry out the interaction once it is
+	// accepted.
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+	ExpiresAt time.Time       `json:"expiresAt"`
+}
+
+// InteractionOffer asks an interaction, pending for TTL seconds.
+type InteractionOffer struct {
+	Kind      InteractionKind   `json:"kind"`
+	UserID    uuid.UUID         `json:"userId"`
+	Platform  platform.Platform `json:"platform"`
+	FromID    string            `json:"fromId"`
+	FromLogin string            `json:"fromLogin"`
+	ToLogin   string            `json:"toLogin"`
+	Payload   json.RawMessage   `json:"payload,omitempty"`
+	TTL       int32             `json:"ttl"`
+}
+
+// InteractionTake names the chatter whose pending interaction is answered.
+type InteractionTake struct {
+	UserID       uuid.UUID         `json:"userId"`
+	Platform     platform.Platform `json:"platform"`
+	ChatterLogin string            `json:"chatterLogin"`
+}
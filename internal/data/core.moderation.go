@@ -0,0 +1,190 @@
+package data
+
+import (
+	"encoding/json"
+	"slices"
+
+	sharedData "github.com/arnokay/arnobot-shared/data"
+	"github.com/arnokay/arnobot-shared/events"
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+type FilterKind string
+
+const (
+	// FilterKindLinks catches links to domains that are not allowed.
+	FilterKindLinks FilterKind = "links"
+	// FilterKindCaps catches messages mostly written in capitals.
+	FilterKindCaps FilterKind = "caps"
+	// FilterKindSymbols catches messages mostly made of symbols.
+	FilterKindSymbols FilterKind = "symbols"
+	// FilterKindRepetitionWords catches any word repeated over and over in
+	// one message. It also catches emote spam, but chat events carry no
+	// emote positions, so it cannot tell emotes from other words.
+	FilterKindRepetitionWords FilterKind = "repetition-words"
+	// FilterKindRepetition catches a chatter sending the same message again
+	// and again.
+	FilterKindRepetition FilterKind = "repetition"
+	// FilterKindLength catches overly long messages.
+	FilterKindLength FilterKind = "length"
+)
+
+var filterKindValues = []FilterKind{
+	FilterKindLinks,
+	FilterKindCaps,
+	FilterKindSymbols,
+	FilterKindRepetitionWords,
+	FilterKindRepetition,
+	FilterKindLength,
+}
+
+// FilterKinds returns every kind of filter, in the order they run.
+func FilterKinds() []FilterKind {
+	return slices.Clone(filterKindValues)
+}
+
+func (k FilterKind) String() string {
+	return string(k)
+}
+
+func (k FilterKind) IsEnum() bool {
+	return slices.Contains(filterKindValues, k)
+}
+
+type ModerationAction string
+
+const (
+	ModerationActionDelete  ModerationAction = "delete"
+	ModerationActionTimeout ModerationAction = "timeout"
+	// ModerationActionWarn replies to the message with the reason it broke
+	// the rules.
+	ModerationActionWarn ModerationAction = "warn"
+	ModerationActionBan  ModerationAction = "ban"
+)
+
+var moderationActionValues = []ModerationAction{ModerationActionDelete, ModerationActionTimeout, ModerationActionWarn, ModerationActionBan}
+
+func (a ModerationAction) String() string {
+	return string(a)
+}
+
+func (a ModerationAction) IsEnum() bool {
+	return slices.Contains(moderationActionValues, a)
+}
+
+// UserLevelOf returns the highest user level a chatter with role has.
+func UserLevelOf(role sharedData.ChatterRole) UserLevel {
+	level := UserLevelEveryone
+	for candidate, required := range userLevelRoles {
+		if role >= required && required > userLevelRoles[level] {
+			level = candidate
+		}
+	}
+	return level
+}
+
+// FilterSettings holds the thresholds of every kind of filter; each filter
+// only reads its own, and zero means the default.
+type FilterSettings struct {
+	// AllowedDomains also allows their subdomains, for links.
+	AllowedDomains []string `json:"allowedDomains,omitempty"`
+	// MinLength is the length below which caps and symbols are not checked.
+	MinLength int32 `json:"minLength,omitempty"`
+	// MaxPercent of the message that can be caps or symbols.
+	MaxPercent int32 `json:"maxPercent,omitempty"`
+	// MaxWordRepeats is how many times one word can appear, for
+	// repetition-words.
+	MaxWordRepeats int32 `json:"maxWordRepeats,omitempty"`
+	// MaxRepeats is how many times the same message can be sent within
+	// Window seconds, for repetition.
+	MaxRepeats int32 `json:"maxRepeats,omitempty"`
+	Window     int32 `json:"window,omitempty"`
+	// MaxLength in characters, for length.
+	MaxLength int32 `json:"maxLength,omitempty"`
+}
+
+type ModerationFilter struct {
+	UserID   uuid.UUID        `json:"userId"`
+	Kind     FilterKind       `json:"kind"`
+	Enabled  bool             `json:"enabled"`
+	Action   ModerationAction `json:"action"`
+	Timeout  int32            `json:"timeout"` // in seconds, for the timeout action
+	Exempt   []UserLevel      `json:"exempt"`
+	Settings FilterSettings   `json:"settings"`
+}
+
+func NewModerationFilterFromDB(fromDB db.CoreModerationFilter) ModerationFilter {
+	filter := ModerationFilter{
+		UserID:  fromDB.UserID,
+		Kind:    FilterKind(fromDB.Kind),
+		Enabled: fromDB.Enabled,
+		Action:  ModerationAction(fromDB.Action),
+		Timeout: fromDB.Timeout,
+	}
+	for _, level := range fromDB.Exempt {
+		filter.Exempt = append(filter.Exempt, UserLevel(level))
+	}
+	_ = json.Unmarshal(fromDB.Settings, &filter.Settings)
+
+	return filter
+}
+
+// NewModerationFilter returns the settings of a filter that was never
+// configured, matching the column defaults.
+func NewModerationFilter(userID uuid.UUID, kind FilterKind) ModerationFilter {
+	return ModerationFilter{
+		UserID:  userID,
+		Kind:    kind,
+		Enabled: false,
+		Action:  ModerationActionDelete,
+		Timeout: 60,
+		Exempt:  []UserLevel{UserLevelModerator, UserLevelBroadcaster},
+	}
+}
+
+// Exempts reports whether chatters with role are not filtered.
+func (f ModerationFilter) Exempts(role sharedData.ChatterRole) bool {
+	return slices.Contains(f.Exempt, UserLevelOf(role))
+}
+
+type ModerationFilterUpdate struct {
+	UserID   uuid.UUID         `json:"userId"`
+	Kind     FilterKind        `json:"kind"`
+	Enabled  *bool             `json:"enabled"`
+	Action   *ModerationAction `json:"action"`
+	Timeout  *int32            `json:"timeout"`
+	Exempt   []UserLevel       `json:"exempt"`
+	Settings *FilterSettings   `json:"settings"`
+}
+
+type ModerationFilterList struct {
+	UserID uuid.UUID `json:"userId"`
+}
+
+func (a ModerationFilterUpdate) OwnerID() uuid.UUID { return a.UserID }
+func (a ModerationFilterList) OwnerID() uuid.UUID   { return a.UserID }
+
+// ModerationSend asks a platform module to act on a chatter or a message.
+type ModerationSend struct {
+	events.EventCommon
+
+	Action       ModerationAction `json:"action"`
+	MessageID    string           `json:"messageId,omitempty"`
+	ChatterID    string           `json:"chatterId"`
+	ChatterLogin string           `json:"chatterLogin"`
+	Duration     int32            `json:"duration,omitempty"` // in seconds, for timeouts
+	Reason       string           `json:"reason"`
+}
+
+// LinkPermit lets a chatter post links in a channel despite the links filter.
+type LinkPermit struct {
+	Platform      platform.Platform `json:"platform"`
+	BroadcasterID string            `json:"broadcasterId"`
+	ChatterLogin  string            `json:"chatterLogin"`
+	// Duration in seconds during which any number of links can be posted.
+	// Zero permits a single link instead.
+	Duration int32 `json:"duration"`
+}
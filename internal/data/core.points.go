@@ -0,0 +1,153 @@
+package data
+
+import (
+	"slices"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+type PointsReason string
+
+const (
+	// PointsReasonChat is earned by chatting.
+	PointsReasonChat PointsReason = "chat"
+	// PointsReasonGive moves points from one chatter to another.
+	PointsReasonGive PointsReason = "give"
+	// PointsReasonAdjust is added or removed by a mod or the dashboard.
+	PointsReasonAdjust PointsReason = "adjust"
+	// PointsReasonGamba is staked and won on the slot machine.
+	PointsReasonGamba PointsReason = "gamba"
+	// PointsReasonGiveaway buys tickets of a giveaway.
+	PointsReasonGiveaway PointsReason = "giveaway"
+	// PointsReasonDuel is staked by both chatters of a duel and won by one.
+	PointsReasonDuel PointsReason = "duel"
+	// PointsReasonTrivia rewards the first correct answer of a trivia round.
+	PointsReasonTrivia PointsReason = "trivia"
+)
+
+var pointsReasonValues = []PointsReason{PointsReasonChat, PointsReasonGive, PointsReasonAdjust, PointsReasonGamba, PointsReasonGiveaway, PointsReasonDuel, PointsReasonTrivia}
+
+func (r PointsReason) String() string {
+	return string(r)
+}
+
+func (r PointsReason) IsEnum() bool {
+	return slices.Contains(pointsReasonValues, r)
+}
+
+// PointsSettings controls how chatters earn the points of a channel.
+type PointsSettings struct {
+	UserID  uuid.UUID `json:"userId"`
+	Enabled bool      `json:"enabled"`
+	// EarnAmount is earned for a message at most once per EarnInterval
+	// seconds, so an interval of 60 pays per active minute and an interval of
+	// zero pays every message.
+	EarnAmount   int32 `json:"earnAmount"`
+	EarnInterval int32 `json:"earnInterval"`
+	// MinMessageLength in characters, not counting spaces, below which a
+	// message earns nothing.
+	MinMessageLength int32 `json:"minMessageLength"`
+	// DailyCap on the points earned by chatting, zero for none.
+	DailyCap int32 `json:"dailyCap"`
+}
+
+func NewPointsSettingsFromDB(fromDB db.CorePointsSettings) PointsSettings {
+	return PointsSettings{
+		UserID:           fromDB.UserID,
+		Enabled:          fromDB.Enabled,
+		EarnAmount:       fromDB.EarnAmount,
+		EarnInterval:     fromDB.EarnInterval,
+		MinMessageLength: fromDB.MinMessageLength,
+		DailyCap:         fromDB.DailyCap,
+	}
+}
+
+// NewPointsSettings returns the settings of a channel that never configured
+// points, matching the column defaults.
+func NewPointsSettings(userID uuid.UUID) PointsSettings {
+	return PointsSettings{
+		UserID:           userID,
+		Enabled:          false,
+		EarnAmount:       1,
+		EarnInterval:     60,
+		MinMessageLength: 2,
+		DailyCap:         0,
+	}
+}
+
+type PointsSettingsGet struct {
+	UserID uuid.UUID `json:"userId"`
+}
+
+type PointsSettingsUpdate struct {
+	UserID           uuid.UUID `json:"userId"`
+	Enabled          *bool     `json:"enabled"`
+	EarnAmount       *int32    `json:"earnAmount"`
+	EarnInterval     *int32    `json:"earnInterval"`
+	MinMessageLength *int32    `json:"minMessageLength"`
+	DailyCap         *int32    `json:"dailyCap"`
+}
+
+// PointsAccount is the balance of a chatter in a channel.
+type PointsAccount struct {
+	UserID       uuid.UUID         `json:"userId"`
+	Platform     platform.Platform `json:"platform"`
+	ChatterID    string            `json:"chatterId"`
+	ChatterLogin string            `json:"chatterLogin"`
+	Balance      int64             `json:"balance"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+}
+
+func NewPointsAccountFromDB(fromDB db.CorePointsAccount) PointsAccount {
+	return PointsAccount{
+		UserID:       fromDB.UserID,
+		Platform:     fromDB.Platform,
+		ChatterID:    fromDB.ChatterID,
+		ChatterLogin: fromDB.ChatterLogin,
+		Balance:      fromDB.Balance,
+		UpdatedAt:    fromDB.UpdatedAt,
+	}
+}
+
+// PointsChatter identifies a chatter of a channel by ID or, when the ID is
+// empty, by login.
+type PointsChatter struct {
+	UserID       uuid.UUID         `json:"userId"`
+	Platform     platform.Platform `json:"platform"`
+	ChatterID    string            `json:"chatterId"`
+	ChatterLogin string            `json:"chatterLogin"`
+}
+
+// PointsAdjust adds Amount, or removes it when negative, from the balance of
+// a chatter. Applying it again with the same IdempotencyKey does nothing.
+type PointsAdjust struct {
+	PointsChatter
+
+	Amount         int64        `json:"amount"`
+	Reason         PointsReason `json:"reason"`
+	IdempotencyKey string       `json:"idempotencyKey"`
+}
+
+// PointsTransfer moves Amount points from one chatter of a channel to
+// another. Applying it again with the same IdempotencyKey does nothing.
+type PointsTransfer struct {
+	From           PointsChatter `json:"from"`
+	To             PointsChatter `json:"to"`
+	Amount         int64         `json:"amount"`
+	Reason         PointsReason  `json:"reason"`
+	IdempotencyKey string        `json:"idempotencyKey"`
+}
+
+type PointsTransferResult struct {
+	From PointsAccount `json:"from"`
+	To   PointsAccount `json:"to"`
+}
+
+func (a PointsSettingsGet) OwnerID() uuid.UUID    { return a.UserID }
+func (a PointsSettingsUpdate) OwnerID() uuid.UUID { return a.UserID }
+func (a PointsChatter) OwnerID() uuid.UUID        { return a.UserID }
+func (a PointsTransfer) OwnerID() uuid.UUID       { return a.From.UserID }
@@ -0,0 +1,154 @@
+package data
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+// Poll is voted on in chat by typing the number or the name of an option,
+// one vote per chatter.
+type Poll struct {
+	ID        int64             `json:"id"`
+	UserID    uuid.UUID         `json:"userId"`
+	Platform  platform.Platform `json:"platform"`
+	Question  string            `json:"question"`
+	Options   []PollOption      `json:"options"`
+	Votes     int64             `json:"votes"`
+	EndsAt    *time.Time        `json:"endsAt"`
+	ClosedAt  *time.Time        `json:"closedAt"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type PollOption struct {
+	Name  string `json:"name"`
+	Votes int64  `json:"votes"`
+}
+
+// NewPollFromDB returns the poll without its votes, see Tally.
+func NewPollFromDB(fromDB db.CorePoll) Poll {
+	poll := Poll{
+		ID:        fromDB.ID,
+		UserID:    fromDB.UserID,
+		Platform:  fromDB.Platform,
+		Question:  fromDB.Question,
+		Options:   make([]PollOption, 0, len(fromDB.Options)),
+		EndsAt:    fromDB.EndsAt,
+		ClosedAt:  fromDB.ClosedAt,
+		CreatedAt: fromDB.CreatedAt,
+	}
+	for _, option := range fromDB.Options {
+		poll.Options = append(poll.Options, PollOption{Name: option})
+	}
+
+	return poll
+}
+
+// Tally counts the votes of the poll, given by option.
+func (p *Poll) Tally(fromDBs []db.CorePollTallyRow) {
+	p.Votes = 0
+	for _, fromDB := range fromDBs {
+		if int(fromDB.Option) < len(p.Options) {
+			p.Options[fromDB.Option].Votes = fromDB.Votes
+			p.Votes += fromDB.Votes
+		}
+	}
+}
+
+// Open reports whether the poll still takes votes at now.
+func (p Poll) Open(now time.Time) bool {
+	return p.ClosedAt == nil && (p.EndsAt == nil || p.EndsAt.After(now))
+}
+
+// Leaders returns the options with the most votes, more than one on a tie,
+// and none without votes.
+func (p Poll) Leaders() []PollOption {
+	var leaders []PollOption
+	for _, option := range p.Options {
+		switch {
+		case option.Votes == 0:
+		case len(leaders) == 0 || option.Votes > leaders[0].Votes:
+			leaders = []PollOption{option}
+		case option.Votes == leaders[0].Votes:
+			leaders = append(leaders, option)
+		}
+	}
+	return leaders
+}
+
+// Standings lists the options with their votes, as in
+// "Q? 1) a: 3 (60%) | 2) b: 2 (40%)".
+func (p Poll) Standings() string {
+	var b strings.Builder
+	b.WriteString(p.Question)
+	for i, option := range p.Options {
+		if i == 0 {
+			b.WriteString(" ")
+		} else {
+			b.WriteString(" | ")
+		}
+		b.WriteString(strconv.Itoa(i+1) + ") " + option.Name + ": " + strconv.FormatInt(option.Votes, 10))
+		if p.Votes > 0 {
+			b.WriteString(" (" + strconv.FormatInt(option.Votes*100/p.Votes, 10) + "%)")
+		}
+	}
+	return b.String()
+}
+
+// Results names the winner of the poll, or the options that tied, before
+// its standings.
+func (p Poll) Results() string {
+	leaders := p.Leaders()
+	switch len(leaders) {
+	case 0:
+		return "poll is over without votes: " + p.Question
+	case 1:
+		return "poll is over, " + leaders[0].Name + " wins! " + p.Standings()
+	}
+
+	names := make([]string, 0, len(leaders))
+	for _, leader := range leaders {
+		names = append(names, leader.Name)
+	}
+	return "poll is over, tie between " + strings.Join(names, ", ") + "! " + p.Standings()
+}
+
+// PollStart starts a poll, open for Duration seconds or, when zero, until it
+// is ended.
+type PollStart struct {
+	UserID   uuid.UUID         `json:"userId"`
+	Platform platform.Platform `json:"platform"`
+	Question string            `json:"question"`
+	Options  []string          `json:"options"`
+	Duration int32             `json:"duration"`
+}
+
+// PollGet names a poll of the channel by ID, or its last poll when ID is
+// zero.
+type PollGet struct {
+	UserID   uuid.UUID         `json:"userId"`
+	Platform platform.Platform `json:"platform"`
+	ID       int64             `json:"id"`
+}
+
+// PollEnd ends the open poll of the channel.
+type PollEnd struct {
+	UserID   uuid.UUID         `json:"userId"`
+	Platform platform.Platform `json:"platform"`
+}
+
+type PollList struct {
+	UserID   uuid.UUID `json:"userId"`
+	BeforeID int64     `json:"beforeId"`
+	Limit    int32     `json:"limit"`
+}
+
+func (a PollStart) OwnerID() uuid.UUID { return a.UserID }
+func (a PollGet) OwnerID() uuid.UUID   { return a.UserID }
+func (a PollEnd) OwnerID() uuid.UUID   { return a.UserID }
+func (a PollList) OwnerID() uuid.UUID  { return a.UserID }
@@ -0,0 +1,74 @@
+package data
+
+import (
+	"time"
+
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+// Quote is numbered per channel in the order it was added, and keeps its
+// number when other quotes are deleted.
+type Quote struct {
+	UserID    uuid.UUID `json:"userId"`
+	Number    int32     `json:"number"`
+	Text      string    `json:"text"`
+	Category  string    `json:"category"`
+	AddedBy   string    `json:"addedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func NewQuoteFromDB(fromDB db.CoreQuote) Quote {
+	return Quote{
+		UserID:    fromDB.UserID,
+		Number:    fromDB.Number,
+		Text:      fromDB.Text,
+		Category:  fromDB.Category,
+		AddedBy:   fromDB.AddedBy,
+		CreatedAt: fromDB.CreatedAt,
+		UpdatedAt: fromDB.UpdatedAt,
+	}
+}
+
+// QuoteCreate adds a quote. Without a Category, the one the stream of
+// BroadcasterID is in is taken, when known.
+type QuoteCreate struct {
+	UserID        uuid.UUID         `json:"userId"`
+	Text          string            `json:"text"`
+	Category      *string           `json:"category"`
+	Platform      platform.Platform `json:"platform"`
+	BroadcasterID string            `json:"broadcasterId"`
+}
+
+type QuoteUpdate struct {
+	UserID   uuid.UUID `json:"userId"`
+	Number   int32     `json:"number"`
+	Text     *string   `json:"text"`
+	Category *string   `json:"category"`
+}
+
+type QuoteDelete struct {
+	UserID uuid.UUID `json:"userId"`
+	Number int32     `json:"number"`
+}
+
+// QuoteGet picks the quote of the given Number or, when zero, a random one,
+// among the ones containing Search if given.
+type QuoteGet struct {
+	UserID uuid.UUID `json:"userId"`
+	Number int32     `json:"number"`
+	Search string    `json:"search"`
+}
+
+type QuoteList struct {
+	UserID uuid.UUID `json:"userId"`
+}
+
+func (a QuoteCreate) OwnerID() uuid.UUID { return a.UserID }
+func (a QuoteUpdate) OwnerID() uuid.UUID { return a.UserID }
+func (a QuoteDelete) OwnerID() uuid.UUID { return a.UserID }
+func (a QuoteGet) OwnerID() uuid.UUID    { return a.UserID }
+func (a QuoteList) OwnerID() uuid.UUID   { return a.UserID }
@@ -0,0 +1,161 @@
+package data
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+// SlotSymbol is a symbol of the reels, landing in proportion to its weight.
+type SlotSymbol struct {
+	Emoji  string `json:"emoji"`
+	Weight int32  `json:"weight"`
+}
+
+// SlotPayout pays Multiplier times the bet when at least Count of the three
+// reels land on Symbol. A Jackpot payout also wins the jackpot pool. Only the
+// best payout of a spin is paid.
+type SlotPayout struct {
+	Symbol     string `json:"symbol"`
+	Count      int32  `json:"count"`
+	Multiplier int32  `json:"multiplier"`
+	Jackpot    bool   `json:"jackpot,omitempty"`
+}
+
+var defaultSlotSymbols = []SlotSymbol{
+	{Emoji: "🍒", Weight: 24},
+	{Emoji: "🍋", Weight: 20},
+	{Emoji: "🍊", Weight: 16},
+	{Emoji: "🍇", Weight: 12},
+	{Emoji: "🔔", Weight: 8},
+	{Emoji: "⭐", Weight: 5},
+	{Emoji: "💎", Weight: 3},
+	{Emoji: "🔥", Weight: 2},
+}
+
+var defaultSlotPayouts = []SlotPayout{
+	{Symbol: "🍒", Count: 2, Multiplier: 2},
+	{Symbol: "🍒", Count: 3, Multiplier: 8},
+	{Symbol: "🍋", Count: 3, Multiplier: 12},
+	{Symbol: "🍊", Count: 3, Multiplier: 20},
+	{Symbol: "🍇", Count: 3, Multiplier: 30},
+	{Symbol: "🔔", Count: 3, Multiplier: 60},
+	{Symbol: "⭐", Count: 3, Multiplier: 150},
+	{Symbol: "💎", Count: 3, Multiplier: 400},
+	{Symbol: "🔥", Count: 3, Multiplier: 1000, Jackpot: true},
+}
+
+// SlotMachine is the !gamba slot machine of a channel, with its running
+// totals.
+type SlotMachine struct {
+	UserID  uuid.UUID    `json:"userId"`
+	Symbols []SlotSymbol `json:"symbols"`
+	Payouts []SlotPayout `json:"payouts"`
+	MinBet  int32        `json:"minBet"`
+	MaxBet  int32        `json:"maxBet"`
+	// JackpotPercent of every bet goes to the jackpot pool, which starts
+	// over from JackpotSeed once won.
+	JackpotPercent int32 `json:"jackpotPercent"`
+	JackpotSeed    int64 `json:"jackpotSeed"`
+	Jackpot        int64 `json:"jackpot"`
+	Spins          int64 `json:"spins"`
+	Wagered        int64 `json:"wagered"`
+	Paid           int64 `json:"paid"`
+}
+
+func NewSlotMachineFromDB(fromDB db.CoreSlotMachine) SlotMachine {
+	machine := SlotMachine{
+		UserID:         fromDB.UserID,
+		MinBet:         fromDB.MinBet,
+		MaxBet:         fromDB.MaxBet,
+		JackpotPercent: fromDB.JackpotPercent,
+		JackpotSeed:    fromDB.JackpotSeed,
+		Jackpot:        fromDB.Jackpot,
+		Spins:          fromDB.Spins,
+		Wagered:        fromDB.Wagered,
+		Paid:           fromDB.Paid,
+	}
+	_ = json.Unmarshal(fromDB.Symbols, &machine.Symbols)
+	_ = json.Unmarshal(fromDB.Payouts, &machine.Payouts)
+	if len(machine.Symbols) == 0 {
+		machine.Symbols = slices.Clone(defaultSlotSymbols)
+	}
+	if len(machine.Payouts) == 0 {
+		machine.Payouts = slices.Clone(defaultSlotPayouts)
+	}
+
+	return machine
+}
+
+// NewSlotMachine returns the machine of a channel that never configured one,
+// matching the column defaults.
+func NewSlotMachine(userID uuid.UUID) SlotMachine {
+	return SlotMachine{
+		UserID:         userID,
+		Symbols:        slices.Clone(defaultSlotSymbols),
+		Payouts:        slices.Clone(defaultSlotPayouts),
+		MinBet:         10,
+		MaxBet:         10000,
+		JackpotPercent: 5,
+		JackpotSeed:    1000,
+		Jackpot:        1000,
+	}
+}
+
+// SlotReport tells the broadcaster how much the machine keeps.
+type SlotReport struct {
+	SlotMachine
+
+	// TheoreticalReturn is the share of bets paid back in the long run,
+	// counting the jackpot contributions as paid back.
+	TheoreticalReturn float64 `json:"theoreticalReturn"`
+	// ActualReturn is the share of bets paid back so far.
+	ActualReturn float64 `json:"actualReturn"`
+}
+
+// HouseEdge is the theoretical share of bets the house keeps.
+func (r SlotReport) HouseEdge() float64 {
+	return 1 - r.TheoreticalReturn
+}
+
+type SlotMachineGet struct {
+	UserID uuid.UUID `json:"userId"`
+}
+
+// SlotMachineUpdate changes the non-nil fields of the machine. Empty symbols
+// or payouts go back to the built-in ones.
+type SlotMachineUpdate struct {
+	UserID         uuid.UUID    `json:"userId"`
+	Symbols        []SlotSymbol `json:"symbols"`
+	Payouts        []SlotPayout `json:"payouts"`
+	MinBet         *int32       `json:"minBet"`
+	MaxBet         *int32       `json:"maxBet"`
+	JackpotPercent *int32       `json:"jackpotPercent"`
+	JackpotSeed    *int64       `json:"jackpotSeed"`
+}
+
+func (a SlotMachineGet) OwnerID() uuid.UUID    { return a.UserID }
+func (a SlotMachineUpdate) OwnerID() uuid.UUID { return a.UserID }
+
+// SlotSpin spins the machine of a channel for a chatter. A zero Bet spins
+// for fun, without points.
+type SlotSpin struct {
+	PointsChatter
+	MessageID string `json:"messageId"`
+	Bet       int64  `json:"bet"`
+}
+
+type SlotSpinResult struct {
+	Reels []string `json:"reels"`
+	// RollID is the ID of the provably fair roll of the reels, zero when
+	// the channel does not roll provably fair.
+	RollID  int64 `json:"rollId"`
+	Won     int64 `json:"won"`
+	Jackpot bool  `json:"jackpot"`
+	// Pool is the jackpot pool after the spin.
+	Pool    int64 `json:"pool"`
+	Balance int64 `json:"balance"`
+}
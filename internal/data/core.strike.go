@@ -0,0 +1,90 @@
+package data
+
+import (
+	"encoding/json"
+
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+// StrikeStep is the action taken on a chatter for one of their strikes.
+type StrikeStep struct {
+	Action   ModerationAction `json:"action"`
+	Duration int32            `json:"duration,omitempty"` // in seconds, for timeouts
+}
+
+// StrikeLadder escalates the moderation actions taken on repeat offenders.
+// Once enabled, a broken filter or banned phrase gives the chatter a strike
+// and takes the step of their number of strikes instead of its own action;
+// the last step is repeated past the end of the ladder.
+type StrikeLadder struct {
+	UserID  uuid.UUID    `json:"userId"`
+	Enabled bool         `json:"enabled"`
+	Steps   []StrikeStep `json:"steps"`
+	Decay   int32        `json:"decay"` // in seconds, after which a strike is forgotten
+}
+
+func NewStrikeLadderFromDB(fromDB db.CoreStrikeLadder) StrikeLadder {
+	ladder := StrikeLadder{
+		UserID:  fromDB.UserID,
+		Enabled: fromDB.Enabled,
+		Decay:   fromDB.Decay,
+	}
+	_ = json.Unmarshal(fromDB.Steps, &ladder.Steps)
+
+	return ladder
+}
+
+// NewStrikeLadder returns the ladder of a channel that never configured one,
+// matching the column defaults.
+func NewStrikeLadder(userID uuid.UUID) StrikeLadder {
+	return StrikeLadder{
+		UserID:  userID,
+		Enabled: false,
+		Steps: []StrikeStep{
+			{Action: ModerationActionWarn},
+			{Action: ModerationActionTimeout, Duration: 60},
+			{Action: ModerationActionTimeout, Duration: 600},
+			{Action: ModerationActionBan},
+		},
+		Decay: 24 * 60 * 60,
+	}
+}
+
+// Step returns the step taken for the strike-th strike, counting from one.
+func (l StrikeLadder) Step(strike int) StrikeStep {
+	return l.Steps[min(max(strike, 1), len(l.Steps))-1]
+}
+
+type StrikeLadderGet struct {
+	UserID uuid.UUID `json:"userId"`
+}
+
+type StrikeLadderUpdate struct {
+	UserID  uuid.UUID    `json:"userId"`
+	Enabled *bool        `json:"enabled"`
+	Steps   []StrikeStep `json:"steps"`
+	Decay   *int32       `json:"decay"`
+}
+
+func (a StrikeLadderGet) OwnerID() uuid.UUID    { return a.UserID }
+func (a StrikeLadderUpdate) OwnerID() uuid.UUID { return a.UserID }
+
+// StrikeChatter identifies the chatter whose strikes are counted or pardoned.
+// Strikes are kept by chatter ID, which survives renames, so a chatter named
+// only by login, as mods do in chat, is looked up by the login their last
+// strike in the channel was given under.
+type StrikeChatter struct {
+	UserID        uuid.UUID         `json:"userId"`
+	Platform      platform.Platform `json:"platform"`
+	BroadcasterID string            `json:"broadcasterId"`
+	ChatterID     string            `json:"chatterId"`
+	ChatterLogin  string            `json:"chatterLogin"`
+}
+
+type Strikes struct {
+	ChatterLogin string `json:"chatterLogin"`
+	Count        int    `json:"count"`
+}
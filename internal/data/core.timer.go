@@ -0,0 +1,84 @@
+package data
+
+import (
+	"time"
+
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+// Timer posts Text in a channel every Interval seconds, as long as at least
+// MinLines chat lines were sent since its last post.
+type Timer struct {
+	ID         int32             `json:"id"`
+	UserID     uuid.UUID         `json:"userId"`
+	Platform   platform.Platform `json:"platform"`
+	Name       string            `json:"name"`
+	Text       string            `json:"text"`
+	Interval   int32             `json:"interval"`
+	MinLines   int32             `json:"minLines"`
+	OnlineOnly bool              `json:"onlineOnly"`
+	Enabled    bool              `json:"enabled"`
+	LastRunAt  *time.Time        `json:"lastRunAt,omitempty"`
+	NextRunAt  time.Time         `json:"nextRunAt"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+}
+
+func NewTimerFromDB(fromDB db.CoreTimer) Timer {
+	return Timer{
+		ID:         fromDB.ID,
+		UserID:     fromDB.UserID,
+		Platform:   fromDB.Platform,
+		Name:       fromDB.Name,
+		Text:       fromDB.Text,
+		Interval:   fromDB.Interval,
+		MinLines:   fromDB.MinLines,
+		OnlineOnly: fromDB.OnlineOnly,
+		Enabled:    fromDB.Enabled,
+		LastRunAt:  fromDB.LastRunAt,
+		NextRunAt:  fromDB.NextRunAt,
+		CreatedAt:  fromDB.CreatedAt,
+		UpdatedAt:  fromDB.UpdatedAt,
+	}
+}
+
+type TimerCreate struct {
+	UserID     uuid.UUID         `json:"userId"`
+	Platform   platform.Platform `json:"platform"`
+	Name       string            `json:"name"`
+	Text       string            `json:"text"`
+	Interval   int32             `json:"interval"`
+	MinLines   *int32            `json:"minLines"`
+	OnlineOnly *bool             `json:"onlineOnly"`
+	Enabled    *bool             `json:"enabled"`
+}
+
+type TimerUpdate struct {
+	UserID     uuid.UUID         `json:"userId"`
+	Platform   platform.Platform `json:"platform"`
+	Name       string            `json:"name"`
+	NewName    *string           `json:"newName"`
+	Text       *string           `json:"text"`
+	Interval   *int32            `json:"interval"`
+	MinLines   *int32            `json:"minLines"`
+	OnlineOnly *bool             `json:"onlineOnly"`
+	Enabled    *bool             `json:"enabled"`
+}
+
+type TimerDelete struct {
+	UserID   uuid.UUID         `json:"userId"`
+	Platform platform.Platform `json:"platform"`
+	Name     string            `json:"name"`
+}
+
+type TimerList struct {
+	UserID uuid.UUID `json:"userId"`
+}
+
+func (a TimerCreate) OwnerID() uuid.UUID { return a.UserID }
+func (a TimerUpdate) OwnerID() uuid.UUID { return a.UserID }
+func (a TimerDelete) OwnerID() uuid.UUID { return a.UserID }
+func (a TimerList) OwnerID() uuid.UUID   { return a.UserID }
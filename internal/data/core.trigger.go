@@ -0,0 +1,99 @@
+package data
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+type TriggerKind string
+
+const (
+	// TriggerKindPhrase fires when a message contains the pattern, ignoring
+	// case.
+	TriggerKindPhrase TriggerKind = "phrase"
+	// TriggerKindRegex fires when a message matches the pattern, whose capture
+	// groups can be used in the response as {1} to {9}.
+	TriggerKindRegex TriggerKind = "regex"
+)
+
+var triggerKindValues = []TriggerKind{TriggerKindPhrase, TriggerKindRegex}
+
+func (k TriggerKind) String() string {
+	return string(k)
+}
+
+func (k TriggerKind) IsEnum() bool {
+	return slices.Contains(triggerKindValues, k)
+}
+
+// Trigger auto-responds to chat messages that are not commands.
+type Trigger struct {
+	ID        int32       `json:"id"`
+	UserID    uuid.UUID   `json:"userId"`
+	Name      string      `json:"name"`
+	Kind      TriggerKind `json:"kind"`
+	Pattern   string      `json:"pattern"`
+	Text      string      `json:"text"`
+	Cooldown  int32       `json:"cooldown"` // in seconds, zero disables it
+	UserLevel UserLevel   `json:"userLevel"`
+	Enabled   bool        `json:"enabled"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+func NewTriggerFromDB(fromDB db.CoreTrigger) Trigger {
+	return Trigger{
+		ID:        fromDB.ID,
+		UserID:    fromDB.UserID,
+		Name:      fromDB.Name,
+		Kind:      TriggerKind(fromDB.Kind),
+		Pattern:   fromDB.Pattern,
+		Text:      fromDB.Text,
+		Cooldown:  fromDB.Cooldown,
+		UserLevel: UserLevel(fromDB.UserLevel),
+		Enabled:   fromDB.Enabled,
+		CreatedAt: fromDB.CreatedAt,
+		UpdatedAt: fromDB.UpdatedAt,
+	}
+}
+
+type TriggerCreate struct {
+	UserID    uuid.UUID   `json:"userId"`
+	Name      string      `json:"name"`
+	Kind      TriggerKind `json:"kind"`
+	Pattern   string      `json:"pattern"`
+	Text      string      `json:"text"`
+	Cooldown  *int32      `json:"cooldown"`
+	UserLevel *UserLevel  `json:"userLevel"`
+	Enabled   *bool       `json:"enabled"`
+}
+
+type TriggerUpdate struct {
+	UserID    uuid.UUID    `json:"userId"`
+	Name      string       `json:"name"`
+	NewName   *string      `json:"newName"`
+	Kind      *TriggerKind `json:"kind"`
+	Pattern   *string      `json:"pattern"`
+	Text      *string      `json:"text"`
+	Cooldown  *int32       `json:"cooldown"`
+	UserLevel *UserLevel   `json:"userLevel"`
+	Enabled   *bool        `json:"enabled"`
+}
+
+type TriggerDelete struct {
+	UserID uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+}
+
+type TriggerList struct {
+	UserID uuid.UUID `json:"userId"`
+}
+
+func (a TriggerCreate) OwnerID() uuid.UUID { return a.UserID }
+func (a TriggerUpdate) OwnerID() uuid.UUID { return a.UserID }
+func (a TriggerDelete) OwnerID() uuid.UUID { return a.UserID }
+func (a TriggerList) OwnerID() uuid.UUID   { return a.UserID }
@@ -0,0 +1,175 @@
+package data
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/arnokay/arnobot-shared/platform"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+	"github.com/arnokay/arnobot-core/internal/trivia"
+)
+
+// TriviaPackFormat is how an imported question pack is written, see
+// trivia.ParseJSON and trivia.ParseCSV.
+type TriviaPackFormat string
+
+const (
+	TriviaPackFormatJSON TriviaPackFormat = "json"
+	TriviaPackFormatCSV  TriviaPackFormat = "csv"
+)
+
+var triviaPackFormatValues = []TriviaPackFormat{TriviaPackFormatJSON, TriviaPackFormatCSV}
+
+func (f TriviaPackFormat) String() string {
+	return string(f)
+}
+
+func (f TriviaPackFormat) IsEnum() bool {
+	return slices.Contains(triviaPackFormatValues, f)
+}
+
+// TriviaSession asks its questions one round at a time. A round ends with
+// the first correct answer or after AnswerTime seconds, and the next one is
+// asked after a short break.
+type TriviaSession struct {
+	ID       int64             `json:"id"`
+	UserID   uuid.UUID         `json:"userId"`
+	Platform platform.Platform `json:"platform"`
+	Category string            `json:"category"`
+	// Questions are kept from chatters, as they hold the answers.
+	Questions   []trivia.Question `json:"-"`
+	Rounds      int32             `json:"rounds"`
+	Round       int32             `json:"round"`
+	AnswerTime  int32             `json:"answerTime"`
+	Reward      int32             `json:"reward"`
+	RoundEndsAt *time.Time        `json:"roundEndsAt"`
+	NextRoundAt time.Time         `json:"nextRoundAt"`
+	ClosedAt    *time.Time        `json:"closedAt"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	Scores      []TriviaScore     `json:"scores"`
+}
+
+func NewTriviaSessionFromDB(fromDB db.CoreTriviaSession) TriviaSession {
+	session := TriviaSession{
+		ID:          fromDB.ID,
+		UserID:      fromDB.UserID,
+		Platform:    fromDB.Platform,
+		Category:    fromDB.Category,
+		Round:       fromDB.Round,
+		AnswerTime:  fromDB.AnswerTime,
+		Reward:      fromDB.Reward,
+		RoundEndsAt: fromDB.RoundEndsAt,
+		NextRoundAt: fromDB.NextRoundAt,
+		ClosedAt:    fromDB.ClosedAt,
+		CreatedAt:   fromDB.CreatedAt,
+	}
+	_ = json.Unmarshal(fromDB.Questions, &session.Questions)
+	session.Rounds = int32(len(session.Questions))
+
+	return session
+}
+
+// Question returns the question of the current round, if one was asked.
+func (s TriviaSession) Question() (trivia.Question, bool) {
+	if s.Round < 1 || int(s.Round) > len(s.Questions) {
+		return trivia.Question{}, false
+	}
+	return s.Questions[s.Round-1], true
+}
+
+// TriviaScore counts the questions a chatter answered first, in a session or
+// in all of them.
+type TriviaScore struct {
+	ChatterID    string `json:"chatterId"`
+	ChatterLogin string `json:"chatterLogin"`
+	Wins         int32  `json:"wins"`
+}
+
+func NewTriviaScoreFromDB(fromDB db.CoreTriviaScore) TriviaScore {
+	return TriviaScore{
+		ChatterID:    fromDB.ChatterID,
+		ChatterLogin: fromDB.ChatterLogin,
+		Wins:         fromDB.Wins,
+	}
+}
+
+func NewTriviaSessionScoreFromDB(fromDB db.CoreTriviaSessionScore) TriviaScore {
+	return TriviaScore{
+		ChatterID:    fromDB.ChatterID,
+		ChatterLogin: fromDB.ChatterLogin,
+		Wins:         fromDB.Wins,
+	}
+}
+
+// TriviaCategory counts the questions of a category a channel can play.
+type TriviaCategory struct {
+	Name      string `json:"name"`
+	Questions int    `json:"questions"`
+	// BuiltIn counts the ones of the default pack.
+	BuiltIn int `json:"builtIn"`
+}
+
+// TriviaStart starts a session of Rounds questions of Category, or of all
+// categories when empty. Reward is paid in points for every correct answer.
+type TriviaStart struct {
+	UserID     uuid.UUID         `json:"userId"`
+	Platform   platform.Platform `json:"platform"`
+	Category   string            `json:"category"`
+	Rounds     int32             `json:"rounds"`
+	AnswerTime int32             `json:"answerTime"`
+	Reward     *int32            `json:"reward"`
+}
+
+// TriviaGet names the last session of a channel.
+type TriviaGet struct {
+	UserID   uuid.UUID         `json:"userId"`
+	Platform platform.Platform `json:"platform"`
+}
+
+// TriviaStop stops the running session of a channel.
+type TriviaStop struct {
+	UserID   uuid.UUID         `json:"userId"`
+	Platform platform.Platform `json:"platform"`
+}
+
+// TriviaImport adds the questions of a pack to the channel, replacing the
+// ones it has already with the same text.
+type TriviaImport struct {
+	UserID uuid.UUID        `json:"userId"`
+	Format TriviaPackFormat `json:"format"`
+	Pack   string           `json:"pack"`
+}
+
+type TriviaImportResult struct {
+	Imported int `json:"imported"`
+}
+
+type TriviaCategoryList struct {
+	UserID uuid.UUID `json:"userId"`
+}
+
+// TriviaCategoryDelete deletes the imported questions of a category. The
+// default pack cannot be deleted.
+type TriviaCategoryDelete struct {
+	UserID   uuid.UUID `json:"userId"`
+	Category string    `json:"category"`
+}
+
+// TriviaTop lists the chatters with the most correct answers of all
+// sessions.
+type TriviaTop struct {
+	UserID   uuid.UUID         `json:"userId"`
+	Platform platform.Platform `json:"platform"`
+	Limit    int32             `json:"limit"`
+}
+
+func (a TriviaStart) OwnerID() uuid.UUID          { return a.UserID }
+func (a TriviaGet) OwnerID() uuid.UUID            { return a.UserID }
+func (a TriviaStop) OwnerID() uuid.UUID           { return a.UserID }
+func (a TriviaImport) OwnerID() uuid.UUID         { return a.UserID }
+func (a TriviaCategoryList) OwnerID() uuid.UUID   { return a.UserID }
+func (a TriviaCategoryDelete) OwnerID() uuid.UUID { return a.UserID }
+func (a TriviaTop) OwnerID() uuid.UUID            { return a.UserID }
@@ -0,0 +1,71 @@
+package data
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+type RevisionAction string
+
+const (
+	RevisionActionCreate  RevisionAction = "create"
+	RevisionActionUpdate  RevisionAction = "update"
+	RevisionActionDelete  RevisionAction = "delete"
+	RevisionActionRestore RevisionAction = "restore"
+)
+
+// UserCommandRevision records one change of a user command. Before is nil for
+// creations and restores, After is nil for deletions.
+type UserCommandRevision struct {
+	ID        int64          `json:"id"`
+	UserID    uuid.UUID      `json:"userId"`
+	Name      string         `json:"name"`
+	Action    RevisionAction `json:"action"`
+	Before    *UserCommand   `json:"before"`
+	After     *UserCommand   `json:"after"`
+	Actor     *Actor         `json:"actor"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+func NewUserCommandRevisionFromDB(fromDB db.CoreUserCommandRevision) (UserCommandRevision, error) {
+	revision := UserCommandRevision{
+		ID:        fromDB.ID,
+		UserID:    fromDB.UserID,
+		Name:      fromDB.CommandName,
+		Action:    RevisionAction(fromDB.Action),
+		CreatedAt: fromDB.CreatedAt,
+	}
+
+	for _, field := range []struct {
+		raw []byte
+		to  any
+	}{
+		{fromDB.Before, &revision.Before},
+		{fromDB.After, &revision.After},
+		{fromDB.Actor, &revision.Actor},
+	} {
+		if len(field.raw) == 0 {
+			continue
+		}
+		err := json.Unmarshal(field.raw, field.to)
+		if err != nil {
+			return UserCommandRevision{}, err
+		}
+	}
+
+	return revision, nil
+}
+
+type UserCommandRevisionList struct {
+	UserID uuid.UUID `json:"userId"`
+	// Name narrows the history to one command when set.
+	Name     string `json:"name"`
+	BeforeID int64  `json:"beforeId"`
+	Limit    int32  `json:"limit"`
+}
+
+func (a UserCommandRevisionList) OwnerID() uuid.UUID { return a.UserID }
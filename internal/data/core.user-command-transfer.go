@@ -0,0 +1,75 @@
+package data
+
+import "github.com/google/uuid"
+
+type TransferFormat string
+
+const (
+	// TransferFormatNative is the versioned JSON format of this bot.
+	TransferFormatNative         TransferFormat = "arnobot"
+	TransferFormatNightbot       TransferFormat = "nightbot"
+	TransferFormatStreamElements TransferFormat = "streamelements"
+	TransferFormatStreamlabs     TransferFormat = "streamlabs"
+)
+
+type ConflictStrategy string
+
+const (
+	ConflictSkip      ConflictStrategy = "skip"
+	ConflictOverwrite ConflictStrategy = "overwrite"
+	ConflictRename    ConflictStrategy = "rename"
+)
+
+type ImportAction string
+
+const (
+	ImportActionCreate    ImportAction = "create"
+	ImportActionOverwrite ImportAction = "overwrite"
+	ImportActionRename    ImportAction = "rename"
+	ImportActionSkip      ImportAction = "skip"
+	ImportActionFail      ImportAction = "fail"
+)
+
+type UserCommandImport struct {
+	UserID   uuid.UUID        `json:"userId"`
+	Format   TransferFormat   `json:"format"`
+	Content  string           `json:"content"`
+	Strategy ConflictStrategy `json:"strategy"`
+	DryRun   bool             `json:"dryRun"`
+}
+
+type UserCommandImportItem struct {
+	Name      string       `json:"name"`
+	FinalName string       `json:"finalName"`
+	Action    ImportAction `json:"action"`
+	// CurrentText is the text that gets replaced on overwrite.
+	CurrentText *string  `json:"currentText,omitempty"`
+	Text        string   `json:"text"`
+	Warnings    []string `json:"warnings,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+type UserCommandImportResult struct {
+	DryRun bool                    `json:"dryRun"`
+	Items  []UserCommandImportItem `json:"items"`
+}
+
+type UserCommandExport struct {
+	UserID uuid.UUID      `json:"userId"`
+	Format TransferFormat `json:"format"`
+}
+
+// UserCommandExportWarning tells what of a command the format cannot hold.
+type UserCommandExportWarning struct {
+	Name     string   `json:"name"`
+	Warnings []string `json:"warnings"`
+}
+
+type UserCommandExportResult struct {
+	Format   TransferFormat             `json:"format"`
+	Content  string                     `json:"content"`
+	Warnings []UserCommandExportWarning `json:"warnings,omitempty"`
+}
+
+func (a UserCommandImport) OwnerID() uuid.UUID { return a.UserID }
+func (a UserCommandExport) OwnerID() uuid.UUID { return a.UserID }
@@ -0,0 +1,234 @@
+package data
+
+import (
+	"slices"
+	"time"
+
+	sharedData "github.com/arnokay/arnobot-shared/data"
+	"github.com/google/uuid"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+type ResponseMode string
+
+const (
+	// ResponseModeRandom picks any response with equal chance.
+	ResponseModeRandom ResponseMode = "random"
+	// ResponseModeWeighted picks a response proportionally to its weight.
+	ResponseModeWeighted ResponseMode = "weighted"
+	// ResponseModeSequential cycles through responses in round-robin order.
+	ResponseModeSequential ResponseMode = "sequential"
+)
+
+var responseModeValues = []ResponseMode{ResponseModeRandom, ResponseModeWeighted, ResponseModeSequential}
+
+func (m ResponseMode) String() string {
+	return string(m)
+}
+
+func (m ResponseMode) IsEnum() bool {
+	return slices.Contains(responseModeValues, m)
+}
+
+type UserLevel string
+
+const (
+	UserLevelEveryone    UserLevel = "everyone"
+	UserLevelSub         UserLevel = "sub"
+	UserLevelVIP         UserLevel = "vip"
+	UserLevelModerator   UserLevel = "moderator"
+	UserLevelBroadcaster UserLevel = "broadcaster"
+)
+
+var userLevelRoles = map[UserLevel]sharedData.ChatterRole{
+	UserLevelEveryone:    sharedData.ChatterPleb,
+	UserLevelSub:         sharedData.ChatterSub,
+	UserLevelVIP:         sharedData.ChatterVIP,
+	UserLevelModerator:   sharedData.ChatterModerator,
+	UserLevelBroadcaster: sharedData.ChatterBroadcaster,
+}
+
+func (l UserLevel) String() string {
+	return string(l)
+}
+
+func (l UserLevel) IsEnum() bool {
+	_, ok := userLevelRoles[l]
+	return ok
+}
+
+// Allows reports whether a chatter with role can use a command of this level.
+// Everyone includes chatters whose role the platform did not send, and
+// unknown levels allow everyone, like commands stored before levels existed.
+func (l UserLevel) Allows(role sharedData.ChatterRole) bool {
+	if l == UserLevelEveryone {
+		return true
+	}
+	required, ok := userLevelRoles[l]
+	if !ok {
+		return true
+	}
+	return role >= required
+}
+
+type UserCommand struct {
+	UserID       uuid.UUID    `json:"userId"`
+	Name         string       `json:"name"`
+	Text         string       `json:"text"`
+	Reply        bool         `json:"reply"`
+	Enabled      bool         `json:"enabled"`
+	ResponseMode ResponseMode `json:"responseMode"`
+	Cooldown     int32        `json:"cooldown"` // in seconds, zero disables it
+	UserLevel    UserLevel    `json:"userLevel"`
+	// Variables is false for a text written before commands had variables,
+	// which is sent as is until it is changed.
+	Variables bool                  `json:"variables"`
+	Responses []UserCommandResponse `json:"responses"`
+	CreatedAt time.Time             `json:"createdAt"`
+	UpdatedAt time.Time             `json:"updatedAt"`
+	DeletedAt *time.Time            `json:"deletedAt,omitempty"`
+}
+
+func NewUserCommandFromDB(fromDB db.CoreUserCommand) UserCommand {
+	return UserCommand{
+		UserID:       fromDB.UserID,
+		Name:         fromDB.Name,
+		Text:         fromDB.Text,
+		Reply:        fromDB.Reply,
+		Enabled:      fromDB.Enabled,
+		ResponseMode: ResponseMode(fromDB.ResponseMode),
+		Cooldown:     fromDB.Cooldown,
+		UserLevel:    UserLevel(fromDB.UserLevel),
+		Variables:    fromDB.Variables,
+		CreatedAt:    fromDB.CreatedAt,
+		UpdatedAt:    fromDB.UpdatedAt,
+		DeletedAt:    fromDB.DeletedAt,
+	}
+}
+
+// Variants returns every response the command can answer with. Text is always
+// the first variant with weight 1, so commands without extra responses behave
+// as a single-text command.
+func (c UserCommand) Variants() []UserCommandResponse {
+	variants := make([]UserCommandResponse, 0, len(c.Responses)+1)
+	variants = append(variants, UserCommandResponse{Text: c.Text, Weight: 1, Variables: c.Variables})
+	variants = append(variants, c.Responses...)
+	return variants
+}
+
+type UserCommandResponse struct {
+	ID     int32  `json:"id"`
+	Text   string `json:"text"`
+	Weight int32  `json:"weight"`
+	// Variables is false for a response added before commands had variables.
+	Variables bool      `json:"variables"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewUserCommandResponseFromDB(fromDB db.CoreUserCommandResponse) UserCommandResponse {
+	return UserCommandResponse{
+		ID:        fromDB.ID,
+		Text:      fromDB.Text,
+		Weight:    fromDB.Weight,
+		Variables: fromDB.Variables,
+		CreatedAt: fromDB.CreatedAt,
+	}
+}
+
+type UserCommandGetOne struct {
+	UserID uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+}
+
+type UserCommandSort string
+
+const (
+	UserCommandSortName      UserCommandSort = "name"
+	UserCommandSortCreatedAt UserCommandSort = "createdAt"
+	UserCommandSortUpdatedAt UserCommandSort = "updatedAt"
+)
+
+type UserCommandList struct {
+	UserID uuid.UUID       `json:"userId"`
+	Cursor string          `json:"cursor"`
+	Limit  int32           `json:"limit"`
+	Sort   UserCommandSort `json:"sort"`
+	Desc   bool            `json:"desc"`
+	Search string          `json:"search"`
+}
+
+type UserCommandPage struct {
+	Items      []UserCommand `json:"items"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+type UserCommandCreate struct {
+	UserID       uuid.UUID     `json:"userId"`
+	Name         string        `json:"name"`
+	Text         string        `json:"text"`
+	Reply        bool          `json:"reply"`
+	Enabled      *bool         `json:"enabled"`
+	ResponseMode *ResponseMode `json:"responseMode"`
+	Cooldown     *int32        `json:"cooldown"`
+	UserLevel    *UserLevel    `json:"userLevel"`
+}
+
+type UserCommandUpdate struct {
+	UserID       uuid.UUID     `json:"userId"`
+	Name         string        `json:"name"`
+	NewName      *string       `json:"newName"`
+	Text         *string       `json:"text"`
+	Reply        *bool         `json:"reply"`
+	Enabled      *bool         `json:"enabled"`
+	ResponseMode *ResponseMode `json:"responseMode"`
+	Cooldown     *int32        `json:"cooldown"`
+	UserLevel    *UserLevel    `json:"userLevel"`
+}
+
+type UserCommandDelete struct {
+	UserID uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+}
+
+type UserCommandResponseAdd struct {
+	UserID uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+	Text   string    `json:"text"`
+	Weight int32     `json:"weight"`
+}
+
+type UserCommandResponseDelete struct {
+	UserID     uuid.UUID `json:"userId"`
+	Name       string    `json:"name"`
+	ResponseID int32     `json:"responseId"`
+}
+
+type UserCommandRestore struct {
+	UserID uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+}
+
+type UserCommandUndo struct {
+	UserID uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+}
+
+// UserCommandGetByUserID is the bare user id that
+// topics.CoreUserCommandGetByUserID has always been sent.
+type UserCommandGetByUserID uuid.UUID
+
+func (a *UserCommandGetByUserID) UnmarshalText(b []byte) error {
+	return (*uuid.UUID)(a).UnmarshalText(b)
+}
+
+func (a UserCommandGetByUserID) OwnerID() uuid.UUID    { return uuid.UUID(a) }
+func (a UserCommandGetOne) OwnerID() uuid.UUID         { return a.UserID }
+func (a UserCommandList) OwnerID() uuid.UUID           { return a.UserID }
+func (a UserCommandCreate) OwnerID() uuid.UUID         { return a.UserID }
+func (a UserCommandUpdate) OwnerID() uuid.UUID         { return a.UserID }
+func (a UserCommandDelete) OwnerID() uuid.UUID         { return a.UserID }
+func (a UserCommandResponseAdd) OwnerID() uuid.UUID    { return a.UserID }
+func (a UserCommandResponseDelete) OwnerID() uuid.UUID { return a.UserID }
+func (a UserCommandRestore) OwnerID() uuid.UUID        { return a.UserID }
+func (a UserCommandUndo) OwnerID() uuid.UUID           { return a.UserID }
@@ -0,0 +1,55 @@
+package data
+
+import (
+	"testing"
+
+	sharedData "github.com/arnokay/arnobot-shared/data"
+)
+
+func TestUserLevelAllows(t *testing.T) {
+	tests := []struct {
+		level UserLevel
+		role  sharedData.ChatterRole
+		want  bool
+	}{
+		{UserLevelEveryone, 0, true},
+		{UserLevelEveryone, sharedData.ChatterPleb, true},
+		{UserLevel(""), 0, true},
+		{UserLevel("unknown"), sharedData.ChatterPleb, true},
+		{UserLevelSub, 0, false},
+		{UserLevelSub, sharedData.ChatterPleb, false},
+		{UserLevelSub, sharedData.ChatterSub, true},
+		{UserLevelVIP, sharedData.ChatterSub, false},
+		{UserLevelModerator, sharedData.ChatterVIP, false},
+		{UserLevelModerator, sharedData.ChatterBroadcaster, true},
+		{UserLevelBroadcaster, sharedData.ChatterModerator, false},
+		{UserLevelBroadcaster, sharedData.ChatterBroadcaster, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.level.Allows(tt.role); got != tt.want {
+			t.Errorf("UserLevel(%q).Allows(%d) = %v, want %v", tt.level, tt.role, got, tt.want)
+		}
+	}
+}
+
+func TestUserCommandVariants(t *testing.T) {
+	cmd := UserCommand{
+		Text:      "hi {user}",
+		Variables: false,
+		Responses: []UserCommandResponse{
+			{ID: 1, Text: "hey {user}", Weight: 3, Variables: true},
+		},
+	}
+
+	variants := cmd.Variants()
+	if len(variants) != 2 {
+		t.Fatalf("len(Variants()) = %d, want 2", len(variants))
+	}
+	if v := variants[0]; v.Text != cmd.Text || v.Weight != 1 || v.Variables {
+		t.Errorf("Variants()[0] = %+v, want the text with weight 1 and without variables", v)
+	}
+	if v := variants[1]; v != cmd.Responses[0] {
+		t.Errorf("Variants()[1] = %+v, want %+v", v, cmd.Responses[0])
+	}
+}
@@ -0,0 +1,2 @@
+// data structs owned by core, complementing arnobot-shared/data
+package data
@@ -0,0 +1,43 @@
+package data
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/arnokay/arnobot-shared/apperror"
+)
+
+// FieldErrors maps the JSON names of invalid request fields to what is wrong
+// with them.
+type FieldErrors map[string]string
+
+func (e FieldErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	messages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, field+": "+e[field])
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Add records the problem of field unless it is empty.
+func (e FieldErrors) Add(field string, problem string) {
+	if problem != "" {
+		e[field] = problem
+	}
+}
+
+// Err returns nil without errors, otherwise an invalid input error that
+// carries them.
+func (e FieldErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return apperror.New(apperror.CodeInvalidInput, e.Error(), e)
+}
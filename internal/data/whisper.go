@@ -0,0 +1,31 @@
+package data
+
+import (
+	"github.com/arnokay/arnobot-shared/data"
+	"github.com/arnokay/arnobot-shared/platform"
+)
+
+// Whisper is a private message sent to a bot, published by the platform
+// modules. BroadcasterID is the channel the bot was whispered as, and
+// ChatterRole the role of the whisperer in that channel.
+type Whisper struct {
+	Platform      platform.Platform `json:"platform"`
+	BotID         string            `json:"botId"`
+	BroadcasterID string            `json:"broadcasterId"`
+	MessageID     string            `json:"messageId"`
+	Message       string            `json:"message"`
+
+	ChatterID    string           `json:"chatterId"`
+	ChatterLogin string           `json:"chatterLogin"`
+	ChatterName  string           `json:"chatterName"`
+	ChatterRole  data.ChatterRole `json:"chatterRole"`
+}
+
+// WhisperSend asks a platform module to whisper Message from the bot to the
+// chatter ToID.
+type WhisperSend struct {
+	Platform platform.Platform `json:"platform"`
+	BotID    string            `json:"botId"`
+	ToID     string            `json:"toId"`
+	Message  string            `json:"message"`
+}
@@ -0,0 +1,2 @@
+-- Modify "user_commands" table
+ALTER TABLE "core"."user_commands" ADD COLUMN "enabled" boolean NOT NULL DEFAULT TRUE;
@@ -0,0 +1,16 @@
+-- Modify "user_commands" table
+ALTER TABLE "core"."user_commands" ADD COLUMN "response_mode" varchar(20) NOT NULL DEFAULT 'random';
+-- Create "user_command_responses" table
+CREATE TABLE "core"."user_command_responses" (
+  "id" serial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "command_name" character varying(50) NOT NULL,
+  "text" text NOT NULL,
+  "weight" integer NOT NULL DEFAULT 1,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "user_command_responses_weight_check" CHECK (weight > 0),
+  CONSTRAINT "user_command_responses_user_id_command_name_fkey" FOREIGN KEY ("user_id", "command_name") REFERENCES "core"."user_commands" ("user_id", "name") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "user_command_responses_user_id_command_name_idx" to table: "user_command_responses"
+CREATE INDEX "user_command_responses_user_id_command_name_idx" ON "core"."user_command_responses" ("user_id", "command_name");
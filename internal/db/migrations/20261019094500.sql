@@ -0,0 +1,17 @@
+-- Modify "user_commands" table
+ALTER TABLE "core"."user_commands" ADD COLUMN "deleted_at" timestamp NULL;
+-- Create "user_command_revisions" table
+CREATE TABLE "core"."user_command_revisions" (
+  "id" bigserial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "command_name" character varying(50) NOT NULL,
+  "action" character varying(20) NOT NULL,
+  "before" jsonb NULL,
+  "after" jsonb NULL,
+  "actor" jsonb NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "user_command_revisions_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE RESTRICT
+);
+-- Create index "user_command_revisions_user_id_command_name_id_idx" to table: "user_command_revisions"
+CREATE INDEX "user_command_revisions_user_id_command_name_id_idx" ON "core"."user_command_revisions" ("user_id", "command_name", "id" DESC);
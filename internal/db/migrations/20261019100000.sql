@@ -0,0 +1,24 @@
+-- Create "notify_user_command_changed" function
+CREATE FUNCTION "core"."notify_user_command_changed" () RETURNS trigger LANGUAGE plpgsql AS $$
+DECLARE
+    name_column text := TG_ARGV[0];
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM pg_notify('core_user_command_changed', json_build_object(
+            'userId', OLD.user_id,
+            'name', to_jsonb(OLD) ->> name_column
+        )::text);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM pg_notify('core_user_command_changed', json_build_object(
+            'userId', NEW.user_id,
+            'name', to_jsonb(NEW) ->> name_column
+        )::text);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+-- Create trigger "user_commands_notify_changed"
+CREATE TRIGGER "user_commands_notify_changed" AFTER INSERT OR UPDATE OR DELETE ON "core"."user_commands" FOR EACH ROW EXECUTE FUNCTION "core"."notify_user_command_changed"('name');
+-- Create trigger "user_command_responses_notify_changed"
+CREATE TRIGGER "user_command_responses_notify_changed" AFTER INSERT OR UPDATE OR DELETE ON "core"."user_command_responses" FOR EACH ROW EXECUTE FUNCTION "core"."notify_user_command_changed"('command_name');
@@ -0,0 +1,2 @@
+-- Modify "user_commands" table
+ALTER TABLE "core"."user_commands" ADD COLUMN "cooldown" integer NOT NULL DEFAULT 10, ADD COLUMN "user_level" character varying(20) NOT NULL DEFAULT 'everyone';
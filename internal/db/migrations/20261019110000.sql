@@ -0,0 +1,23 @@
+-- Create "timers" table
+CREATE TABLE "core"."timers" (
+  "id" serial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "platform" character varying(20) NOT NULL,
+  "name" character varying(50) NOT NULL,
+  "text" text NOT NULL,
+  "interval" integer NOT NULL,
+  "min_lines" integer NOT NULL DEFAULT 0,
+  "online_only" boolean NOT NULL DEFAULT true,
+  "enabled" boolean NOT NULL DEFAULT true,
+  "lines_at_last_run" bigint NOT NULL DEFAULT 0,
+  "last_run_at" timestamp NULL,
+  "next_run_at" timestamp NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "timers_user_id_platform_name_key" UNIQUE ("user_id", "platform", "name"),
+  CONSTRAINT "timers_interval_check" CHECK (interval > 0),
+  CONSTRAINT "timers_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "timers_next_run_at_idx" to table: "timers"
+CREATE INDEX "timers_next_run_at_idx" ON "core"."timers" ("next_run_at") WHERE enabled;
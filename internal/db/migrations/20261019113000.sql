@@ -0,0 +1,18 @@
+-- Create "triggers" table
+CREATE TABLE "core"."triggers" (
+  "id" serial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "name" character varying(50) NOT NULL,
+  "kind" character varying(10) NOT NULL,
+  "pattern" text NOT NULL,
+  "text" text NOT NULL,
+  "cooldown" integer NOT NULL DEFAULT 10,
+  "user_level" character varying(20) NOT NULL DEFAULT 'everyone',
+  "enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "triggers_user_id_name_key" UNIQUE ("user_id", "name"),
+  CONSTRAINT "triggers_kind_check" CHECK (kind IN ('phrase', 'regex')),
+  CONSTRAINT "triggers_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
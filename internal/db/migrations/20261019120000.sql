@@ -0,0 +1,14 @@
+-- Create "moderation_filters" table
+CREATE TABLE "core"."moderation_filters" (
+  "user_id" uuid NOT NULL,
+  "kind" character varying(20) NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT false,
+  "action" character varying(10) NOT NULL DEFAULT 'delete',
+  "timeout" integer NOT NULL DEFAULT 60,
+  "exempt" character varying(20)[] NOT NULL DEFAULT '{moderator,broadcaster}',
+  "settings" jsonb NOT NULL DEFAULT '{}',
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id", "kind"),
+  CONSTRAINT "moderation_filters_timeout_check" CHECK (timeout > 0),
+  CONSTRAINT "moderation_filters_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,28 @@
+-- Create "banned_phrases" table
+CREATE TABLE "core"."banned_phrases" (
+  "id" serial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "phrase" character varying(100) NOT NULL,
+  "pattern" character varying(100) NOT NULL,
+  "action" character varying(10) NOT NULL DEFAULT 'delete',
+  "timeout" integer NOT NULL DEFAULT 60,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "banned_phrases_user_id_pattern_key" UNIQUE ("user_id", "pattern"),
+  CONSTRAINT "banned_phrases_action_check" CHECK (action IN ('delete', 'timeout')),
+  CONSTRAINT "banned_phrases_timeout_check" CHECK (timeout > 0),
+  CONSTRAINT "banned_phrases_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create "notify_banned_phrases_changed" function
+CREATE FUNCTION "core"."notify_banned_phrases_changed" () RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('core_banned_phrases_changed', OLD.user_id::text);
+    ELSE
+        PERFORM pg_notify('core_banned_phrases_changed', NEW.user_id::text);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+-- Create trigger "banned_phrases_notify_changed"
+CREATE TRIGGER "banned_phrases_notify_changed" AFTER INSERT OR UPDATE OR DELETE ON "core"."banned_phrases" FOR EACH ROW EXECUTE FUNCTION "core"."notify_banned_phrases_changed"();
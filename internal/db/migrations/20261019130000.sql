@@ -0,0 +1,11 @@
+-- Create "strike_ladders" table
+CREATE TABLE "core"."strike_ladders" (
+  "user_id" uuid NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT false,
+  "steps" jsonb NOT NULL DEFAULT '[{"action": "warn"}, {"action": "timeout", "duration": 60}, {"action": "timeout", "duration": 600}, {"action": "ban"}]',
+  "decay" integer NOT NULL DEFAULT 86400,
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id"),
+  CONSTRAINT "strike_ladders_decay_check" CHECK (decay > 0),
+  CONSTRAINT "strike_ladders_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
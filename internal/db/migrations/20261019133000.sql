@@ -0,0 +1,42 @@
+-- Create "points_settings" table
+CREATE TABLE "core"."points_settings" (
+  "user_id" uuid NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT false,
+  "earn_amount" integer NOT NULL DEFAULT 1,
+  "earn_interval" integer NOT NULL DEFAULT 60,
+  "min_message_length" integer NOT NULL DEFAULT 2,
+  "daily_cap" integer NOT NULL DEFAULT 0,
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id"),
+  CONSTRAINT "points_settings_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create "points_accounts" table
+CREATE TABLE "core"."points_accounts" (
+  "user_id" uuid NOT NULL,
+  "platform" character varying(20) NOT NULL,
+  "chatter_id" character varying(64) NOT NULL,
+  "chatter_login" character varying(64) NOT NULL,
+  "balance" bigint NOT NULL DEFAULT 0,
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id", "platform", "chatter_id"),
+  CONSTRAINT "points_accounts_balance_check" CHECK (balance >= 0),
+  CONSTRAINT "points_accounts_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "points_accounts_login_idx" to table: "points_accounts"
+CREATE INDEX "points_accounts_login_idx" ON "core"."points_accounts" ("user_id", "platform", "chatter_login");
+-- Create "points_ledger" table
+CREATE TABLE "core"."points_ledger" (
+  "id" bigserial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "platform" character varying(20) NOT NULL,
+  "chatter_id" character varying(64) NOT NULL,
+  "amount" bigint NOT NULL,
+  "reason" character varying(20) NOT NULL,
+  "idempotency_key" character varying(100) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "points_ledger_user_id_idempotency_key_key" UNIQUE ("user_id", "idempotency_key"),
+  CONSTRAINT "points_ledger_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "points_ledger_chatter_idx" to table: "points_ledger"
+CREATE INDEX "points_ledger_chatter_idx" ON "core"."points_ledger" ("user_id", "platform", "chatter_id", "created_at");
@@ -0,0 +1,18 @@
+-- Create "slot_machines" table
+CREATE TABLE "core"."slot_machines" (
+  "user_id" uuid NOT NULL,
+  "symbols" jsonb NOT NULL DEFAULT '[]',
+  "payouts" jsonb NOT NULL DEFAULT '[]',
+  "min_bet" integer NOT NULL DEFAULT 10,
+  "max_bet" integer NOT NULL DEFAULT 10000,
+  "jackpot_percent" integer NOT NULL DEFAULT 5,
+  "jackpot_seed" bigint NOT NULL DEFAULT 1000,
+  "jackpot" bigint NOT NULL DEFAULT 1000,
+  "spins" bigint NOT NULL DEFAULT 0,
+  "wagered" bigint NOT NULL DEFAULT 0,
+  "paid" bigint NOT NULL DEFAULT 0,
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id"),
+  CONSTRAINT "slot_machines_bets_check" CHECK (min_bet > 0 AND max_bet >= min_bet),
+  CONSTRAINT "slot_machines_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,42 @@
+-- Create "fair_settings" table
+CREATE TABLE "core"."fair_settings" (
+  "user_id" uuid NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT false,
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id"),
+  CONSTRAINT "fair_settings_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create "fair_seeds" table
+CREATE TABLE "core"."fair_seeds" (
+  "id" bigserial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "seed" character varying(64) NOT NULL,
+  "hash" character varying(64) NOT NULL,
+  "nonce" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "revealed_at" timestamp NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fair_seeds_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "fair_seeds_active_idx" to table: "fair_seeds"
+CREATE UNIQUE INDEX "fair_seeds_active_idx" ON "core"."fair_seeds" ("user_id") WHERE (revealed_at IS NULL);
+-- Create "fair_rolls" table
+CREATE TABLE "core"."fair_rolls" (
+  "id" bigserial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "seed_id" bigint NOT NULL,
+  "game" character varying(20) NOT NULL,
+  "platform" character varying(20) NOT NULL,
+  "chatter_id" character varying(64) NOT NULL,
+  "chatter_login" character varying(64) NOT NULL,
+  "server_seed_hash" character varying(64) NOT NULL,
+  "client_seed" character varying(100) NOT NULL,
+  "nonce" bigint NOT NULL,
+  "outcome" character varying(200) NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fair_rolls_seed_id_fkey" FOREIGN KEY ("seed_id") REFERENCES "core"."fair_seeds" ("id") ON UPDATE CASCADE ON DELETE CASCADE,
+  CONSTRAINT "fair_rolls_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "fair_rolls_user_id_idx" to table: "fair_rolls"
+CREATE INDEX "fair_rolls_user_id_idx" ON "core"."fair_rolls" ("user_id", "id");
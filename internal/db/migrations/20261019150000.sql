@@ -0,0 +1,34 @@
+-- Create "giveaways" table
+CREATE TABLE "core"."giveaways" (
+  "id" bigserial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "platform" character varying(20) NOT NULL,
+  "keyword" character varying(25) NOT NULL,
+  "min_level" character varying(20) NOT NULL DEFAULT 'everyone',
+  "ticket_cost" integer NOT NULL DEFAULT 0,
+  "max_tickets" integer NOT NULL DEFAULT 1,
+  "sub_luck" integer NOT NULL DEFAULT 1,
+  "ends_at" timestamp NULL,
+  "closed_at" timestamp NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "giveaways_ticket_cost_check" CHECK (ticket_cost >= 0),
+  CONSTRAINT "giveaways_max_tickets_check" CHECK (max_tickets >= 1),
+  CONSTRAINT "giveaways_sub_luck_check" CHECK (sub_luck >= 1),
+  CONSTRAINT "giveaways_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "giveaways_open_idx" to table: "giveaways"
+CREATE UNIQUE INDEX "giveaways_open_idx" ON "core"."giveaways" ("user_id", "platform") WHERE (closed_at IS NULL);
+-- Create "giveaway_entries" table
+CREATE TABLE "core"."giveaway_entries" (
+  "giveaway_id" bigint NOT NULL,
+  "chatter_id" character varying(64) NOT NULL,
+  "chatter_login" character varying(64) NOT NULL,
+  "tickets" integer NOT NULL,
+  "sub" boolean NOT NULL DEFAULT false,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "drawn_at" timestamp NULL,
+  PRIMARY KEY ("giveaway_id", "chatter_id"),
+  CONSTRAINT "giveaway_entries_tickets_check" CHECK (tickets >= 1),
+  CONSTRAINT "giveaway_entries_giveaway_id_fkey" FOREIGN KEY ("giveaway_id") REFERENCES "core"."giveaways" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
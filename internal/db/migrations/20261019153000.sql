@@ -0,0 +1,29 @@
+-- Create "polls" table
+CREATE TABLE "core"."polls" (
+  "id" bigserial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "platform" character varying(20) NOT NULL,
+  "question" character varying(200) NOT NULL,
+  "options" character varying(50)[] NOT NULL,
+  "ends_at" timestamp NULL,
+  "closed_at" timestamp NULL,
+  "next_update_at" timestamp NOT NULL,
+  "votes_at_last_update" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "polls_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "polls_open_idx" to table: "polls"
+CREATE UNIQUE INDEX "polls_open_idx" ON "core"."polls" ("user_id", "platform") WHERE (closed_at IS NULL);
+-- Create index "polls_user_id_idx" to table: "polls"
+CREATE INDEX "polls_user_id_idx" ON "core"."polls" ("user_id", "platform", "id");
+-- Create "poll_votes" table
+CREATE TABLE "core"."poll_votes" (
+  "poll_id" bigint NOT NULL,
+  "chatter_id" character varying(64) NOT NULL,
+  "chatter_login" character varying(64) NOT NULL,
+  "option" smallint NOT NULL,
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("poll_id", "chatter_id"),
+  CONSTRAINT "poll_votes_poll_id_fkey" FOREIGN KEY ("poll_id") REFERENCES "core"."polls" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,19 @@
+-- Create "quote_counters" table
+CREATE TABLE "core"."quote_counters" (
+  "user_id" uuid NOT NULL,
+  "last_number" integer NOT NULL,
+  PRIMARY KEY ("user_id"),
+  CONSTRAINT "quote_counters_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create "quotes" table
+CREATE TABLE "core"."quotes" (
+  "user_id" uuid NOT NULL,
+  "number" integer NOT NULL,
+  "text" character varying(400) NOT NULL,
+  "category" character varying(100) NOT NULL DEFAULT '',
+  "added_by" character varying(64) NOT NULL DEFAULT '',
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id", "number"),
+  CONSTRAINT "quotes_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,55 @@
+-- Create "trivia_questions" table
+CREATE TABLE "core"."trivia_questions" (
+  "id" bigserial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "category" character varying(50) NOT NULL,
+  "question" character varying(300) NOT NULL,
+  "answers" character varying(100)[] NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "trivia_questions_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "trivia_questions_question_idx" to table: "trivia_questions"
+CREATE UNIQUE INDEX "trivia_questions_question_idx" ON "core"."trivia_questions" ("user_id", "question");
+-- Create "trivia_sessions" table
+CREATE TABLE "core"."trivia_sessions" (
+  "id" bigserial NOT NULL,
+  "user_id" uuid NOT NULL,
+  "platform" character varying(20) NOT NULL,
+  "category" character varying(50) NOT NULL DEFAULT '',
+  "questions" jsonb NOT NULL,
+  "round" integer NOT NULL DEFAULT 0,
+  "answer_time" integer NOT NULL,
+  "reward" integer NOT NULL DEFAULT 0,
+  "round_ends_at" timestamp NULL,
+  "next_round_at" timestamp NOT NULL,
+  "closed_at" timestamp NULL,
+  "created_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "trivia_sessions_reward_check" CHECK (reward >= 0),
+  CONSTRAINT "trivia_sessions_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "trivia_sessions_open_idx" to table: "trivia_sessions"
+CREATE UNIQUE INDEX "trivia_sessions_open_idx" ON "core"."trivia_sessions" ("user_id", "platform") WHERE (closed_at IS NULL);
+-- Create "trivia_session_scores" table
+CREATE TABLE "core"."trivia_session_scores" (
+  "session_id" bigint NOT NULL,
+  "chatter_id" character varying(64) NOT NULL,
+  "chatter_login" character varying(64) NOT NULL,
+  "wins" integer NOT NULL DEFAULT 0,
+  PRIMARY KEY ("session_id", "chatter_id"),
+  CONSTRAINT "trivia_session_scores_session_id_fkey" FOREIGN KEY ("session_id") REFERENCES "core"."trivia_sessions" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create "trivia_scores" table
+CREATE TABLE "core"."trivia_scores" (
+  "user_id" uuid NOT NULL,
+  "platform" character varying(20) NOT NULL,
+  "chatter_id" character varying(64) NOT NULL,
+  "chatter_login" character varying(64) NOT NULL,
+  "wins" integer NOT NULL DEFAULT 0,
+  "updated_at" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY ("user_id", "platform", "chatter_id"),
+  CONSTRAINT "trivia_scores_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- Create index "trivia_scores_wins_idx" to table: "trivia_scores"
+CREATE INDEX "trivia_scores_wins_idx" ON "core"."trivia_scores" ("user_id", "platform", "wins" DESC);
@@ -0,0 +1,4 @@
+-- Modify "user_commands" table
+ALTER TABLE "core"."user_commands" ALTER COLUMN "name" TYPE character varying(100);
+-- Modify "user_command_responses" table
+ALTER TABLE "core"."user_command_responses" ALTER COLUMN "command_name" TYPE character varying(100);
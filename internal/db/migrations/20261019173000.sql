@@ -0,0 +1,16 @@
+-- Modify "timers" table
+ALTER TABLE "core"."timers" ALTER COLUMN "platform" TYPE "public"."platform" USING "platform"::"public"."platform";
+-- Modify "points_accounts" table
+ALTER TABLE "core"."points_accounts" ALTER COLUMN "platform" TYPE "public"."platform" USING "platform"::"public"."platform";
+-- Modify "points_ledger" table
+ALTER TABLE "core"."points_ledger" ALTER COLUMN "platform" TYPE "public"."platform" USING "platform"::"public"."platform";
+-- Modify "fair_rolls" table
+ALTER TABLE "core"."fair_rolls" ALTER COLUMN "platform" TYPE "public"."platform" USING "platform"::"public"."platform";
+-- Modify "giveaways" table
+ALTER TABLE "core"."giveaways" ALTER COLUMN "platform" TYPE "public"."platform" USING "platform"::"public"."platform";
+-- Modify "polls" table
+ALTER TABLE "core"."polls" ALTER COLUMN "platform" TYPE "public"."platform" USING "platform"::"public"."platform";
+-- Modify "trivia_sessions" table
+ALTER TABLE "core"."trivia_sessions" ALTER COLUMN "platform" TYPE "public"."platform" USING "platform"::"public"."platform";
+-- Modify "trivia_scores" table
+ALTER TABLE "core"."trivia_scores" ALTER COLUMN "platform" TYPE "public"."platform" USING "platform"::"public"."platform";
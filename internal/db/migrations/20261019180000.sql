@@ -0,0 +1,8 @@
+-- Modify "user_commands" table
+ALTER TABLE "core"."user_commands" ADD COLUMN "variables" boolean NOT NULL DEFAULT false;
+-- Modify "user_commands" table
+ALTER TABLE "core"."user_commands" ALTER COLUMN "variables" SET DEFAULT true;
+-- Modify "user_command_responses" table
+ALTER TABLE "core"."user_command_responses" ADD COLUMN "variables" boolean NOT NULL DEFAULT false;
+-- Modify "user_command_responses" table
+ALTER TABLE "core"."user_command_responses" ALTER COLUMN "variables" SET DEFAULT true;
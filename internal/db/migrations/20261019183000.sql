@@ -0,0 +1,2 @@
+-- Rename the "emotes" filters, which catch any repeated word, and turn them off
+UPDATE "core"."moderation_filters" SET "kind" = 'repetition-words', "enabled" = false WHERE "kind" = 'emotes';
@@ -0,0 +1,13 @@
+-- Create "notify_triggers_changed" function
+CREATE FUNCTION "core"."notify_triggers_changed" () RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('core_triggers_changed', OLD.user_id::text);
+    ELSE
+        PERFORM pg_notify('core_triggers_changed', NEW.user_id::text);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+-- Create trigger "triggers_notify_changed"
+CREATE TRIGGER "triggers_notify_changed" AFTER INSERT OR UPDATE OR DELETE ON "core"."triggers" FOR EACH ROW EXECUTE FUNCTION "core"."notify_triggers_changed"();
@@ -0,0 +1,13 @@
+-- Create "notify_quotes_changed" function
+CREATE FUNCTION "core"."notify_quotes_changed" () RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('core_quotes_changed', OLD.user_id::text);
+    ELSE
+        PERFORM pg_notify('core_quotes_changed', NEW.user_id::text);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+-- Create trigger "quotes_notify_changed"
+CREATE TRIGGER "quotes_notify_changed" AFTER INSERT OR UPDATE OR DELETE ON "core"."quotes" FOR EACH ROW EXECUTE FUNCTION "core"."notify_quotes_changed"();
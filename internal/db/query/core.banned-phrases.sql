@@ -0,0 +1,30 @@
+-- name: CoreBannedPhraseCount :one
+SELECT
+    count(*)
+FROM
+    core.banned_phrases
+WHERE
+    user_id = $1;
+
+-- name: CoreBannedPhraseCreate :one
+INSERT INTO core.banned_phrases (user_id, phrase, pattern, action, timeout)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('phrase'), sqlc.arg('pattern'), sqlc.arg('action'), sqlc.arg('timeout'))
+RETURNING
+    *;
+
+-- name: CoreBannedPhraseDelete :one
+DELETE FROM core.banned_phrases
+WHERE user_id = sqlc.arg('user_id')
+    AND pattern = sqlc.arg('pattern')
+RETURNING
+    *;
+
+-- name: CoreBannedPhraseGetByUserID :many
+SELECT
+    *
+FROM
+    core.banned_phrases
+WHERE
+    user_id = $1
+ORDER BY
+    id;
@@ -0,0 +1,93 @@
+-- name: CoreFairRollCreate :one
+INSERT INTO core.fair_rolls (user_id, seed_id, game, platform, chatter_id, chatter_login, server_seed_hash, client_seed, nonce, outcome)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('seed_id'), sqlc.arg('game'), sqlc.arg('platform'), sqlc.arg('chatter_id'), sqlc.arg('chatter_login'), sqlc.arg('server_seed_hash'), sqlc.arg('client_seed'), sqlc.arg('nonce'), sqlc.arg('outcome'))
+RETURNING
+    *;
+
+-- name: CoreFairRollGet :one
+SELECT
+    *
+FROM
+    core.fair_rolls
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND id = sqlc.arg('id');
+
+-- name: CoreFairRollList :many
+SELECT
+    *
+FROM
+    core.fair_rolls
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND (sqlc.narg('chatter_login')::varchar(64) IS NULL
+        OR chatter_login = sqlc.narg('chatter_login'))
+    AND (sqlc.narg('before_id')::bigint IS NULL
+        OR id < sqlc.narg('before_id'))
+ORDER BY
+    id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CoreFairSeedCreate :one
+-- CoreFairSeedCreate starts a new server seed for the channel, returning no
+-- rows when it already has an unrevealed one.
+INSERT INTO core.fair_seeds (user_id, seed, hash)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('seed'), sqlc.arg('hash'))
+ON CONFLICT (user_id)
+WHERE
+    revealed_at IS NULL
+        DO NOTHING
+    RETURNING
+        *;
+
+-- name: CoreFairSeedGetActive :one
+SELECT
+    *
+FROM
+    core.fair_seeds
+WHERE
+    user_id = $1
+    AND revealed_at IS NULL;
+
+-- name: CoreFairSeedNext :one
+-- CoreFairSeedNext takes the next nonce of the unrevealed server seed of the
+-- channel.
+UPDATE
+    core.fair_seeds
+SET
+    nonce = nonce + 1
+WHERE
+    user_id = $1
+    AND revealed_at IS NULL
+RETURNING
+    *;
+
+-- name: CoreFairSeedReveal :one
+-- CoreFairSeedReveal marks the server seed as revealed, so no more rolls use
+-- it.
+UPDATE
+    core.fair_seeds
+SET
+    revealed_at = COALESCE(revealed_at, CURRENT_TIMESTAMP)
+WHERE
+    id = $1
+RETURNING
+    *;
+
+-- name: CoreFairSettingsGet :one
+SELECT
+    *
+FROM
+    core.fair_settings
+WHERE
+    user_id = $1;
+
+-- name: CoreFairSettingsUpsert :one
+INSERT INTO core.fair_settings (user_id, enabled)
+    VALUES (sqlc.arg('user_id'), COALESCE(sqlc.narg('enabled')::boolean, FALSE))
+ON CONFLICT (user_id)
+    DO UPDATE SET
+        enabled = COALESCE(sqlc.narg('enabled')::boolean, fair_settings.enabled),
+        updated_at = CURRENT_TIMESTAMP
+    RETURNING
+        *;
@@ -0,0 +1,103 @@
+-- name: CoreGiveawayClose :one
+-- CoreGiveawayClose stops the entries of the giveaway, at its end when that
+-- has passed already. It returns no rows when it was closed before.
+UPDATE
+    core.giveaways
+SET
+    closed_at = LEAST(CURRENT_TIMESTAMP, ends_at)
+WHERE
+    id = $1
+    AND closed_at IS NULL
+RETURNING
+    *;
+
+-- name: CoreGiveawayCreate :one
+INSERT INTO core.giveaways (user_id, platform, keyword, min_level, ticket_cost, max_tickets, sub_luck, ends_at)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('platform'), sqlc.arg('keyword'), sqlc.arg('min_level'), sqlc.arg('ticket_cost'), sqlc.arg('max_tickets'), sqlc.arg('sub_luck'), sqlc.narg('ends_at'))
+RETURNING
+    *;
+
+-- name: CoreGiveawayEntryCreate :one
+-- CoreGiveawayEntryCreate enters the chatter in the giveaway while it is
+-- open, waiting for a close in progress. It returns no rows when it is not
+-- open, or when they entered already.
+INSERT INTO core.giveaway_entries (giveaway_id, chatter_id, chatter_login, tickets, sub)
+SELECT
+    id,
+    sqlc.arg('chatter_id'),
+    sqlc.arg('chatter_login'),
+    sqlc.arg('tickets'),
+    sqlc.arg('sub')
+FROM
+    core.giveaways
+WHERE
+    id = sqlc.arg('giveaway_id')
+    AND closed_at IS NULL
+    AND (ends_at IS NULL
+        OR ends_at > CURRENT_TIMESTAMP)
+FOR SHARE
+ON CONFLICT (giveaway_id,
+    chatter_id)
+    DO NOTHING
+RETURNING
+    *;
+
+-- name: CoreGiveawayEntryGetUndrawn :many
+SELECT
+    *
+FROM
+    core.giveaway_entries
+WHERE
+    giveaway_id = $1
+    AND drawn_at IS NULL
+ORDER BY
+    created_at,
+    chatter_id;
+
+-- name: CoreGiveawayEntryMarkDrawn :one
+UPDATE
+    core.giveaway_entries
+SET
+    drawn_at = CURRENT_TIMESTAMP
+WHERE
+    giveaway_id = sqlc.arg('giveaway_id')
+    AND chatter_id = sqlc.arg('chatter_id')
+    AND drawn_at IS NULL
+RETURNING
+    *;
+
+-- name: CoreGiveawayEntryStats :one
+SELECT
+    count(*) AS entries,
+    COALESCE(sum(tickets), 0)::bigint AS tickets
+FROM
+    core.giveaway_entries
+WHERE
+    giveaway_id = $1;
+
+-- name: CoreGiveawayGetLatest :one
+-- CoreGiveawayGetLatest returns the last giveaway of the channel, locked
+-- until the end of the transaction so it is changed by one replica at a
+-- time.
+SELECT
+    *
+FROM
+    core.giveaways
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND platform = sqlc.arg('platform')
+ORDER BY
+    id DESC
+LIMIT 1
+FOR UPDATE;
+
+-- name: CoreGiveawayGetWinners :many
+SELECT
+    *
+FROM
+    core.giveaway_entries
+WHERE
+    giveaway_id = $1
+    AND drawn_at IS NOT NULL
+ORDER BY
+    drawn_at;
@@ -0,0 +1,25 @@
+-- name: CoreModerationFilterGetByUserID :many
+SELECT
+    *
+FROM
+    core.moderation_filters
+WHERE
+    user_id = $1
+ORDER BY
+    kind;
+
+-- name: CoreModerationFilterUpsert :one
+-- CoreModerationFilterUpsert creates the filter with defaults for the nil
+-- fields, or changes only the non-nil fields of an existing one.
+INSERT INTO core.moderation_filters (user_id, kind, enabled, action, timeout, exempt, settings)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('kind'), COALESCE(sqlc.narg('enabled')::bool, FALSE), COALESCE(sqlc.narg('action')::varchar(10), 'delete'), COALESCE(sqlc.narg('timeout')::integer, 60), COALESCE(sqlc.arg('exempt')::varchar(20)[], '{moderator,broadcaster}'), COALESCE(sqlc.arg('settings')::jsonb, '{}'))
+ON CONFLICT (user_id, kind)
+    DO UPDATE SET
+        enabled = COALESCE(sqlc.narg('enabled')::bool, moderation_filters.enabled),
+        action = COALESCE(sqlc.narg('action')::varchar(10), moderation_filters.action),
+        timeout = COALESCE(sqlc.narg('timeout')::integer, moderation_filters.timeout),
+        exempt = COALESCE(sqlc.arg('exempt')::varchar(20)[], moderation_filters.exempt),
+        settings = COALESCE(sqlc.arg('settings')::jsonb, moderation_filters.settings),
+        updated_at = CURRENT_TIMESTAMP
+    RETURNING
+        *;
@@ -0,0 +1,83 @@
+-- name: CorePointsAccountAdd :one
+-- CorePointsAccountAdd adds amount to the balance of the account, creating
+-- it when needed. A balance that would go negative breaks a check constraint.
+INSERT INTO core.points_accounts (user_id, platform, chatter_id, chatter_login, balance)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('platform'), sqlc.arg('chatter_id'), sqlc.arg('chatter_login'), sqlc.arg('amount'))
+ON CONFLICT (user_id, platform, chatter_id)
+    DO UPDATE SET
+        balance = points_accounts.balance + EXCLUDED.balance,
+        chatter_login = COALESCE(NULLIF(EXCLUDED.chatter_login, ''), points_accounts.chatter_login),
+        updated_at = CURRENT_TIMESTAMP
+    RETURNING
+        *;
+
+-- name: CorePointsAccountGet :one
+SELECT
+    *
+FROM
+    core.points_accounts
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND platform = sqlc.arg('platform')
+    AND chatter_id = sqlc.arg('chatter_id');
+
+-- name: CorePointsAccountGetByLogin :one
+-- CorePointsAccountGetByLogin returns the account last used by the login,
+-- as a login can move to another chatter once it is given up.
+SELECT
+    *
+FROM
+    core.points_accounts
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND platform = sqlc.arg('platform')
+    AND chatter_login = sqlc.arg('chatter_login')
+ORDER BY
+    updated_at DESC
+LIMIT 1;
+
+-- name: CorePointsLedgerCreate :one
+-- CorePointsLedgerCreate returns pgx.ErrNoRows when an entry with the same
+-- idempotency key was already recorded.
+INSERT INTO core.points_ledger (user_id, platform, chatter_id, amount, reason, idempotency_key)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('platform'), sqlc.arg('chatter_id'), sqlc.arg('amount'), sqlc.arg('reason'), sqlc.arg('idempotency_key'))
+ON CONFLICT (user_id, idempotency_key)
+    DO NOTHING
+RETURNING
+    *;
+
+-- name: CorePointsLedgerSumSince :one
+SELECT
+    COALESCE(sum(amount), 0)::bigint
+FROM
+    core.points_ledger
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND platform = sqlc.arg('platform')
+    AND chatter_id = sqlc.arg('chatter_id')
+    AND reason = sqlc.arg('reason')
+    AND created_at >= sqlc.arg('since');
+
+-- name: CorePointsSettingsGet :one
+SELECT
+    *
+FROM
+    core.points_settings
+WHERE
+    user_id = $1;
+
+-- name: CorePointsSettingsUpsert :one
+-- CorePointsSettingsUpsert creates the settings with defaults for the nil
+-- fields, or changes only the non-nil fields of existing ones.
+INSERT INTO core.points_settings (user_id, enabled, earn_amount, earn_interval, min_message_length, daily_cap)
+    VALUES (sqlc.arg('user_id'), COALESCE(sqlc.narg('enabled')::bool, FALSE), COALESCE(sqlc.narg('earn_amount')::integer, 1), COALESCE(sqlc.narg('earn_interval')::integer, 60), COALESCE(sqlc.narg('min_message_length')::integer, 2), COALESCE(sqlc.narg('daily_cap')::integer, 0))
+ON CONFLICT (user_id)
+    DO UPDATE SET
+        enabled = COALESCE(sqlc.narg('enabled')::bool, points_settings.enabled),
+        earn_amount = COALESCE(sqlc.narg('earn_amount')::integer, points_settings.earn_amount),
+        earn_interval = COALESCE(sqlc.narg('earn_interval')::integer, points_settings.earn_interval),
+        min_message_length = COALESCE(sqlc.narg('min_message_length')::integer, points_settings.min_message_length),
+        daily_cap = COALESCE(sqlc.narg('daily_cap')::integer, points_settings.daily_cap),
+        updated_at = CURRENT_TIMESTAMP
+    RETURNING
+        *;
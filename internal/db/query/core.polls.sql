@@ -0,0 +1,117 @@
+-- name: CorePollClaimDue :many
+-- CorePollClaimDue locks the open polls that are due an update or are over,
+-- skipping the ones another transaction already holds. It only makes sense
+-- inside a transaction.
+SELECT
+    *
+FROM
+    core.polls
+WHERE
+    closed_at IS NULL
+    AND (next_update_at <= CURRENT_TIMESTAMP
+        OR ends_at <= CURRENT_TIMESTAMP)
+ORDER BY
+    next_update_at
+LIMIT $1
+FOR UPDATE
+    SKIP LOCKED;
+
+-- name: CorePollClose :one
+-- CorePollClose ends the poll, at its end when that has passed already. It
+-- returns no rows when it was closed before.
+UPDATE
+    core.polls
+SET
+    closed_at = LEAST(CURRENT_TIMESTAMP, ends_at)
+WHERE
+    id = $1
+    AND closed_at IS NULL
+RETURNING
+    *;
+
+-- name: CorePollCreate :one
+INSERT INTO core.polls (user_id, platform, question, options, ends_at, next_update_at)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('platform'), sqlc.arg('question'), sqlc.arg('options'), sqlc.narg('ends_at'), CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg('update_in')::integer))
+RETURNING
+    *;
+
+-- name: CorePollGet :one
+SELECT
+    *
+FROM
+    core.polls
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND id = sqlc.arg('id');
+
+-- name: CorePollGetLatest :one
+SELECT
+    *
+FROM
+    core.polls
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND platform = sqlc.arg('platform')
+ORDER BY
+    id DESC
+LIMIT 1;
+
+-- name: CorePollList :many
+SELECT
+    *
+FROM
+    core.polls
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND (sqlc.narg('before_id')::bigint IS NULL
+        OR id < sqlc.narg('before_id'))
+ORDER BY
+    id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CorePollMarkUpdate :exec
+UPDATE
+    core.polls
+SET
+    votes_at_last_update = sqlc.arg('votes'),
+    next_update_at = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg('next_update_in')::integer)
+WHERE
+    id = sqlc.arg('id');
+
+-- name: CorePollTally :many
+SELECT
+    option,
+    count(*) AS votes
+FROM
+    core.poll_votes
+WHERE
+    poll_id = $1
+GROUP BY
+    option;
+
+-- name: CorePollVoteUpsert :one
+-- CorePollVoteUpsert records the vote of the chatter, replacing the one
+-- they cast before, while the poll is open. It returns no rows when it is
+-- not.
+INSERT INTO core.poll_votes (poll_id, chatter_id, chatter_login, option)
+SELECT
+    id,
+    sqlc.arg('chatter_id'),
+    sqlc.arg('chatter_login'),
+    sqlc.arg('option')
+FROM
+    core.polls
+WHERE
+    id = sqlc.arg('poll_id')
+    AND closed_at IS NULL
+    AND (ends_at IS NULL
+        OR ends_at > CURRENT_TIMESTAMP)
+FOR SHARE
+ON CONFLICT (poll_id,
+    chatter_id)
+    DO UPDATE SET
+        option = EXCLUDED.option,
+        chatter_login = EXCLUDED.chatter_login,
+        updated_at = CURRENT_TIMESTAMP
+    RETURNING
+        poll_id, chatter_id, chatter_login, option, updated_at;
@@ -0,0 +1,60 @@
+-- name: CoreQuoteCount :one
+SELECT
+    count(*)
+FROM
+    core.quotes
+WHERE
+    user_id = $1;
+
+-- name: CoreQuoteCreate :one
+-- CoreQuoteCreate numbers the quote after the last one the channel ever
+-- added, so numbers of deleted quotes are not given out again.
+WITH counter AS (
+INSERT INTO core.quote_counters (user_id, last_number)
+        VALUES (sqlc.arg('user_id'), 1)
+    ON CONFLICT (user_id)
+        DO UPDATE SET
+            last_number = quote_counters.last_number + 1
+        RETURNING
+            last_number)
+    INSERT INTO core.quotes (user_id, number, text, category, added_by)
+    SELECT
+        sqlc.arg('user_id'),
+        last_number,
+        sqlc.arg('text'),
+        sqlc.arg('category'),
+        sqlc.arg('added_by')
+    FROM
+        counter
+    RETURNING
+        *;
+
+-- name: CoreQuoteDelete :one
+DELETE FROM core.quotes
+WHERE user_id = sqlc.arg('user_id')
+    AND number = sqlc.arg('number')
+RETURNING
+    *;
+
+-- name: CoreQuoteGetByUserID :many
+SELECT
+    *
+FROM
+    core.quotes
+WHERE
+    user_id = $1
+ORDER BY
+    number;
+
+-- name: CoreQuoteUpdate :one
+UPDATE
+    core.quotes
+SET
+    text = COALESCE(sqlc.narg('text')::varchar, text),
+    category = COALESCE(sqlc.narg('category')::varchar, category),
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND number = sqlc.arg('number')
+RETURNING
+    *;
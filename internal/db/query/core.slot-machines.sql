@@ -0,0 +1,49 @@
+-- name: CoreSlotMachineGet :one
+SELECT
+    *
+FROM
+    core.slot_machines
+WHERE
+    user_id = $1;
+
+-- name: CoreSlotMachineLock :one
+-- CoreSlotMachineLock returns the machine of the channel, creating it with
+-- defaults when needed, and locks it until the end of the transaction so
+-- spins update the jackpot one after the other.
+INSERT INTO core.slot_machines (user_id)
+    VALUES ($1)
+ON CONFLICT (user_id)
+    DO UPDATE SET
+        user_id = EXCLUDED.user_id
+    RETURNING
+        *;
+
+-- name: CoreSlotMachineRecordSpin :exec
+UPDATE
+    core.slot_machines
+SET
+    jackpot = sqlc.arg('jackpot'),
+    spins = spins + 1,
+    wagered = wagered + sqlc.arg('wagered'),
+    paid = paid + sqlc.arg('paid')
+WHERE
+    user_id = sqlc.arg('user_id');
+
+-- name: CoreSlotMachineUpsert :one
+-- CoreSlotMachineUpsert creates the machine with defaults for the nil fields,
+-- or changes only the non-nil fields of an existing one. The jackpot is
+-- raised to a new seed, never lowered.
+INSERT INTO core.slot_machines (user_id, symbols, payouts, min_bet, max_bet, jackpot_percent, jackpot_seed, jackpot)
+    VALUES (sqlc.arg('user_id'), COALESCE(sqlc.arg('symbols')::jsonb, '[]'), COALESCE(sqlc.arg('payouts')::jsonb, '[]'), COALESCE(sqlc.narg('min_bet')::integer, 10), COALESCE(sqlc.narg('max_bet')::integer, 10000), COALESCE(sqlc.narg('jackpot_percent')::integer, 5), COALESCE(sqlc.narg('jackpot_seed')::bigint, 1000), COALESCE(sqlc.narg('jackpot_seed')::bigint, 1000))
+ON CONFLICT (user_id)
+    DO UPDATE SET
+        symbols = COALESCE(sqlc.arg('symbols')::jsonb, slot_machines.symbols),
+        payouts = COALESCE(sqlc.arg('payouts')::jsonb, slot_machines.payouts),
+        min_bet = COALESCE(sqlc.narg('min_bet')::integer, slot_machines.min_bet),
+        max_bet = COALESCE(sqlc.narg('max_bet')::integer, slot_machines.max_bet),
+        jackpot_percent = COALESCE(sqlc.narg('jackpot_percent')::integer, slot_machines.jackpot_percent),
+        jackpot_seed = COALESCE(sqlc.narg('jackpot_seed')::bigint, slot_machines.jackpot_seed),
+        jackpot = GREATEST(slot_machines.jackpot, COALESCE(sqlc.narg('jackpot_seed')::bigint, slot_machines.jackpot_seed)),
+        updated_at = CURRENT_TIMESTAMP
+    RETURNING
+        *;
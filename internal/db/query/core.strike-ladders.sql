@@ -0,0 +1,21 @@
+-- name: CoreStrikeLadderGet :one
+SELECT
+    *
+FROM
+    core.strike_ladders
+WHERE
+    user_id = $1;
+
+-- name: CoreStrikeLadderUpsert :one
+-- CoreStrikeLadderUpsert creates the ladder with defaults for the nil fields,
+-- or changes only the non-nil fields of an existing one.
+INSERT INTO core.strike_ladders (user_id, enabled, steps, decay)
+    VALUES (sqlc.arg('user_id'), COALESCE(sqlc.narg('enabled')::bool, FALSE), COALESCE(sqlc.arg('steps')::jsonb, '[{"action": "warn"}, {"action": "timeout", "duration": 60}, {"action": "timeout", "duration": 600}, {"action": "ban"}]'), COALESCE(sqlc.narg('decay')::integer, 86400))
+ON CONFLICT (user_id)
+    DO UPDATE SET
+        enabled = COALESCE(sqlc.narg('enabled')::bool, strike_ladders.enabled),
+        steps = COALESCE(sqlc.arg('steps')::jsonb, strike_ladders.steps),
+        decay = COALESCE(sqlc.narg('decay')::integer, strike_ladders.decay),
+        updated_at = CURRENT_TIMESTAMP
+    RETURNING
+        *;
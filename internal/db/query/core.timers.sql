@@ -0,0 +1,76 @@
+-- name: CoreTimerClaimDue :many
+-- CoreTimerClaimDue locks the timers that are due, skipping the ones another
+-- transaction already holds. It only makes sense inside a transaction.
+SELECT
+    *
+FROM
+    core.timers
+WHERE
+    enabled
+    AND next_run_at <= CURRENT_TIMESTAMP
+ORDER BY
+    next_run_at
+LIMIT $1
+FOR UPDATE
+    SKIP LOCKED;
+
+-- name: CoreTimerCreate :one
+INSERT INTO core.timers (user_id, platform, name, text, interval, min_lines, online_only, enabled, next_run_at)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('platform'), sqlc.arg('name'), sqlc.arg('text'), sqlc.arg('interval')::integer, sqlc.arg('min_lines'), sqlc.arg('online_only'), sqlc.arg('enabled'), CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg('interval')::integer))
+RETURNING
+    *;
+
+-- name: CoreTimerDelete :one
+DELETE FROM core.timers
+WHERE user_id = sqlc.arg('user_id')
+    AND platform = sqlc.arg('platform')
+    AND name = sqlc.arg('name')
+RETURNING
+    *;
+
+-- name: CoreTimerGetByUserID :many
+SELECT
+    *
+FROM
+    core.timers
+WHERE
+    user_id = $1
+ORDER BY
+    platform,
+    name;
+
+-- name: CoreTimerMarkRun :exec
+UPDATE
+    core.timers
+SET
+    last_run_at = CASE WHEN sqlc.arg('posted')::bool THEN CURRENT_TIMESTAMP ELSE last_run_at END,
+    lines_at_last_run = CASE WHEN sqlc.arg('posted')::bool THEN sqlc.arg('lines') ELSE lines_at_last_run END,
+    next_run_at = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg('next_run_in')::integer)
+WHERE
+    id = sqlc.arg('id');
+
+-- name: CoreTimerUpdate :one
+-- CoreTimerUpdate starts the interval over when it changes or when the timer
+-- is enabled again, as the next run of a disabled timer is long past.
+UPDATE
+    core.timers
+SET
+    name = COALESCE(sqlc.narg('new_name')::varchar(50), name),
+    text = COALESCE(sqlc.narg('text')::text, text),
+    interval = COALESCE(sqlc.narg('interval')::integer, interval),
+    min_lines = COALESCE(sqlc.narg('min_lines')::integer, min_lines),
+    online_only = COALESCE(sqlc.narg('online_only')::bool, online_only),
+    enabled = COALESCE(sqlc.narg('enabled')::bool, enabled),
+    next_run_at = CASE WHEN sqlc.narg('interval')::integer IS NOT NULL
+        OR (sqlc.narg('enabled')::bool AND NOT enabled) THEN
+        CURRENT_TIMESTAMP + make_interval(secs => COALESCE(sqlc.narg('interval')::integer, interval))
+    ELSE
+        next_run_at
+    END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND platform = sqlc.arg('platform')
+    AND name = sqlc.arg('name')
+RETURNING
+    *;
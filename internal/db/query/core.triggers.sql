@@ -0,0 +1,56 @@
+-- name: CoreTriggerCount :one
+SELECT
+    count(*)
+FROM
+    core.triggers
+WHERE
+    user_id = $1;
+
+-- name: CoreTriggerCreate :one
+INSERT INTO core.triggers (user_id, name, kind, pattern, text, cooldown, user_level, enabled)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('name'), sqlc.arg('kind'), sqlc.arg('pattern'), sqlc.arg('text'), sqlc.arg('cooldown'), sqlc.arg('user_level'), sqlc.arg('enabled'))
+RETURNING
+    *;
+
+-- name: CoreTriggerDelete :one
+DELETE FROM core.triggers
+WHERE user_id = sqlc.arg('user_id')
+    AND name = sqlc.arg('name')
+RETURNING
+    *;
+
+-- name: CoreTriggerGetByUserID :many
+SELECT
+    *
+FROM
+    core.triggers
+WHERE
+    user_id = $1
+ORDER BY
+    id;
+
+-- name: CoreTriggerLock :exec
+-- CoreTriggerLock holds a lock on the triggers of the user until the
+-- transaction ends, so concurrent creates count them one after the other. It
+-- only makes sense inside a transaction, and before the count, which must see
+-- what the previous holder committed.
+SELECT
+    pg_advisory_xact_lock(hashtextextended('core.triggers:' || sqlc.arg('user_id')::uuid::text, 0));
+
+-- name: CoreTriggerUpdate :one
+UPDATE
+    core.triggers
+SET
+    name = COALESCE(sqlc.narg('new_name')::varchar(50), name),
+    kind = COALESCE(sqlc.narg('kind')::varchar(10), kind),
+    pattern = COALESCE(sqlc.narg('pattern')::text, pattern),
+    text = COALESCE(sqlc.narg('text')::text, text),
+    cooldown = COALESCE(sqlc.narg('cooldown')::integer, cooldown),
+    user_level = COALESCE(sqlc.narg('user_level')::varchar(20), user_level),
+    enabled = COALESCE(sqlc.narg('enabled')::bool, enabled),
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND name = sqlc.arg('name')
+RETURNING
+    *;
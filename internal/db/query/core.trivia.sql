@@ -0,0 +1,174 @@
+-- name: CoreTriviaQuestionCount :one
+SELECT
+    count(*)
+FROM
+    core.trivia_questions
+WHERE
+    user_id = $1;
+
+-- name: CoreTriviaQuestionDeleteByCategory :execrows
+-- CoreTriviaQuestionDeleteByCategory returns how many questions were
+-- deleted.
+DELETE FROM core.trivia_questions
+WHERE user_id = sqlc.arg('user_id')
+    AND category = sqlc.arg('category');
+
+-- name: CoreTriviaQuestionGetByUserID :many
+SELECT
+    *
+FROM
+    core.trivia_questions
+WHERE
+    user_id = $1
+ORDER BY
+    id;
+
+-- name: CoreTriviaQuestionUpsert :one
+-- CoreTriviaQuestionUpsert replaces the category and answers of a question
+-- the channel has already, so importing a pack again updates it.
+INSERT INTO core.trivia_questions (user_id, category, question, answers)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('category'), sqlc.arg('question'), sqlc.arg('answers'))
+ON CONFLICT (user_id, question)
+    DO UPDATE SET
+        category = EXCLUDED.category, answers = EXCLUDED.answers
+    RETURNING
+        *;
+
+-- name: CoreTriviaScoreAdd :one
+INSERT INTO core.trivia_scores (user_id, platform, chatter_id, chatter_login, wins)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('platform'), sqlc.arg('chatter_id'), sqlc.arg('chatter_login'), 1)
+ON CONFLICT (user_id, platform, chatter_id)
+    DO UPDATE SET
+        wins = trivia_scores.wins + 1, chatter_login = EXCLUDED.chatter_login, updated_at = CURRENT_TIMESTAMP
+    RETURNING
+        user_id, platform, chatter_id, chatter_login, wins, updated_at;
+
+-- name: CoreTriviaScoreTop :many
+SELECT
+    user_id,
+    platform,
+    chatter_id,
+    chatter_login,
+    wins,
+    updated_at
+FROM
+    core.trivia_scores
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND platform = sqlc.arg('platform')
+ORDER BY
+    wins DESC,
+    updated_at
+LIMIT sqlc.arg('limit');
+
+-- name: CoreTriviaSessionAnswer :one
+-- CoreTriviaSessionAnswer ends the round for the first correct answer. It
+-- returns no rows for the answers that come after, or too late.
+UPDATE
+    core.trivia_sessions
+SET
+    round_ends_at = NULL,
+    next_round_at = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg('next_round_in')::integer)
+WHERE
+    id = sqlc.arg('id')
+    AND round = sqlc.arg('round')
+    AND round_ends_at > CURRENT_TIMESTAMP
+    AND closed_at IS NULL
+RETURNING
+    *;
+
+-- name: CoreTriviaSessionAsk :one
+UPDATE
+    core.trivia_sessions
+SET
+    round = sqlc.arg('round'),
+    round_ends_at = CURRENT_TIMESTAMP + make_interval(secs => answer_time)
+WHERE
+    id = sqlc.arg('id')
+RETURNING
+    *;
+
+-- name: CoreTriviaSessionClaimDue :many
+-- CoreTriviaSessionClaimDue locks the open sessions whose round ran out of
+-- time or that are due their next round, skipping the ones another
+-- transaction already holds. It only makes sense inside a transaction.
+SELECT
+    *
+FROM
+    core.trivia_sessions
+WHERE
+    closed_at IS NULL
+    AND (round_ends_at <= CURRENT_TIMESTAMP
+        OR (round_ends_at IS NULL
+            AND next_round_at <= CURRENT_TIMESTAMP))
+ORDER BY
+    next_round_at
+LIMIT $1
+FOR UPDATE
+    SKIP LOCKED;
+
+-- name: CoreTriviaSessionClose :one
+-- CoreTriviaSessionClose returns no rows when the session was closed before.
+UPDATE
+    core.trivia_sessions
+SET
+    closed_at = CURRENT_TIMESTAMP,
+    round_ends_at = NULL
+WHERE
+    id = $1
+    AND closed_at IS NULL
+RETURNING
+    *;
+
+-- name: CoreTriviaSessionCreate :one
+INSERT INTO core.trivia_sessions (user_id, platform, category, questions, answer_time, reward, next_round_at)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('platform'), sqlc.arg('category'), sqlc.arg('questions'), sqlc.arg('answer_time'), sqlc.arg('reward'), CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg('start_in')::integer))
+RETURNING
+    *;
+
+-- name: CoreTriviaSessionEndRound :one
+UPDATE
+    core.trivia_sessions
+SET
+    round_ends_at = NULL,
+    next_round_at = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg('next_round_in')::integer)
+WHERE
+    id = sqlc.arg('id')
+RETURNING
+    *;
+
+-- name: CoreTriviaSessionGetLatest :one
+SELECT
+    *
+FROM
+    core.trivia_sessions
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND platform = sqlc.arg('platform')
+ORDER BY
+    id DESC
+LIMIT 1;
+
+-- name: CoreTriviaSessionScoreAdd :one
+INSERT INTO core.trivia_session_scores (session_id, chatter_id, chatter_login, wins)
+    VALUES (sqlc.arg('session_id'), sqlc.arg('chatter_id'), sqlc.arg('chatter_login'), 1)
+ON CONFLICT (session_id, chatter_id)
+    DO UPDATE SET
+        wins = trivia_session_scores.wins + 1, chatter_login = EXCLUDED.chatter_login
+    RETURNING
+        session_id, chatter_id, chatter_login, wins;
+
+-- name: CoreTriviaSessionScoreTop :many
+SELECT
+    session_id,
+    chatter_id,
+    chatter_login,
+    wins
+FROM
+    core.trivia_session_scores
+WHERE
+    session_id = sqlc.arg('session_id')
+ORDER BY
+    wins DESC,
+    chatter_login
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,50 @@
+-- name: CoreUserCommandResponseCreate :one
+INSERT INTO core.user_command_responses (user_id, command_name, text, weight, variables)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('command_name'), sqlc.arg('text'), sqlc.arg('weight'), sqlc.arg('variables'))
+RETURNING
+    *;
+
+-- name: CoreUserCommandResponseDelete :one
+DELETE FROM core.user_command_responses
+WHERE user_id = sqlc.arg('user_id')
+    AND command_name = sqlc.arg('command_name')
+    AND id = sqlc.arg('id')
+RETURNING
+    *;
+
+-- name: CoreUserCommandResponseGetByCommand :many
+SELECT
+    *
+FROM
+    core.user_command_responses
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND command_name = sqlc.arg('command_name')
+ORDER BY
+    id;
+
+-- name: CoreUserCommandResponseGetByUserID :many
+SELECT
+    *
+FROM
+    core.user_command_responses
+WHERE
+    user_id = $1
+ORDER BY
+    command_name, id;
+
+-- name: CoreUserCommandResponseGetByCommands :many
+SELECT
+    *
+FROM
+    core.user_command_responses
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND command_name = ANY (sqlc.arg('command_names')::varchar(50)[])
+ORDER BY
+    command_name, id;
+
+-- name: CoreUserCommandResponseDeleteByCommand :execrows
+DELETE FROM core.user_command_responses
+WHERE user_id = sqlc.arg('user_id')
+    AND command_name = sqlc.arg('command_name');
@@ -0,0 +1,32 @@
+-- name: CoreUserCommandRevisionCreate :one
+INSERT INTO core.user_command_revisions (user_id, command_name, action, before, after, actor)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('command_name'), sqlc.arg('action'), sqlc.arg('before'), sqlc.arg('after'), sqlc.arg('actor'))
+RETURNING
+    *;
+
+-- name: CoreUserCommandRevisionGetLast :one
+SELECT
+    *
+FROM
+    core.user_command_revisions
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND command_name = sqlc.arg('command_name')
+ORDER BY
+    id DESC
+LIMIT 1;
+
+-- name: CoreUserCommandRevisionList :many
+SELECT
+    *
+FROM
+    core.user_command_revisions
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND (sqlc.narg('command_name')::varchar(50) IS NULL
+        OR command_name = sqlc.narg('command_name'))
+    AND (sqlc.narg('before_id')::bigint IS NULL
+        OR id < sqlc.narg('before_id'))
+ORDER BY
+    id DESC
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,150 @@
+-- name: CoreUserCommandCreate :one
+INSERT INTO core.user_commands (user_id, name, text, reply, enabled, response_mode, cooldown, user_level)
+    VALUES (sqlc.arg('user_id'), sqlc.arg('name'), sqlc.arg('text'), sqlc.arg('reply'), sqlc.arg('enabled'), sqlc.arg('response_mode'), sqlc.arg('cooldown'), sqlc.arg('user_level'))
+RETURNING
+    *;
+
+-- name: CoreUserCommandDelete :one
+UPDATE
+    core.user_commands
+SET
+    deleted_at = CURRENT_TIMESTAMP
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND name = sqlc.arg('name')
+    AND deleted_at IS NULL
+RETURNING
+    *;
+
+-- name: CoreUserCommandGetByUserID :many
+SELECT
+    *
+FROM
+    core.user_commands
+WHERE
+    user_id = $1
+    AND deleted_at IS NULL
+ORDER BY
+    updated_at DESC;
+
+-- name: CoreUserCommandGetNamesByUserID :many
+SELECT
+    name
+FROM
+    core.user_commands
+WHERE
+    user_id = $1
+    AND deleted_at IS NULL;
+
+-- name: CoreUserCommandCount :one
+SELECT
+    count(*)
+FROM
+    core.user_commands
+WHERE
+    user_id = $1
+    AND deleted_at IS NULL;
+
+-- name: CoreUserCommandList :many
+-- CoreUserCommandList pages through the commands ordered by sort_column, one
+-- of name, created_at and updated_at, then by name. after_name and
+-- after_value form the keyset of the last row of the previous page;
+-- after_value is ignored when sorting by name.
+SELECT
+    *
+FROM
+    core.user_commands
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND deleted_at IS NULL
+    AND (sqlc.narg('search')::text IS NULL
+        OR name ILIKE sqlc.narg('search')
+        OR text ILIKE sqlc.narg('search'))
+    AND (sqlc.narg('after_name')::varchar(50) IS NULL
+        OR CASE sqlc.arg('sort_column')::text
+        WHEN 'created_at' THEN
+            CASE WHEN sqlc.arg('descending')::bool THEN
+                (created_at, name) < (sqlc.arg('after_value')::timestamp, sqlc.narg('after_name'))
+            ELSE
+                (created_at, name) > (sqlc.arg('after_value')::timestamp, sqlc.narg('after_name'))
+            END
+        WHEN 'updated_at' THEN
+            CASE WHEN sqlc.arg('descending')::bool THEN
+                (updated_at, name) < (sqlc.arg('after_value')::timestamp, sqlc.narg('after_name'))
+            ELSE
+                (updated_at, name) > (sqlc.arg('after_value')::timestamp, sqlc.narg('after_name'))
+            END
+        ELSE
+            CASE WHEN sqlc.arg('descending')::bool THEN
+                name < sqlc.narg('after_name')
+            ELSE
+                name > sqlc.narg('after_name')
+            END
+        END)
+ORDER BY
+    CASE WHEN sqlc.arg('sort_column')::text = 'created_at' AND NOT sqlc.arg('descending')::bool THEN created_at END ASC,
+    CASE WHEN sqlc.arg('sort_column')::text = 'created_at' AND sqlc.arg('descending')::bool THEN created_at END DESC,
+    CASE WHEN sqlc.arg('sort_column')::text = 'updated_at' AND NOT sqlc.arg('descending')::bool THEN updated_at END ASC,
+    CASE WHEN sqlc.arg('sort_column')::text = 'updated_at' AND sqlc.arg('descending')::bool THEN updated_at END DESC,
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN name END ASC,
+    CASE WHEN sqlc.arg('descending')::bool THEN name END DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CoreUserCommandGetOne :one
+SELECT
+    *
+FROM
+    core.user_commands
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND name = sqlc.arg('name')
+    AND deleted_at IS NULL;
+
+-- name: CoreUserCommandUpdate :one
+-- CoreUserCommandUpdate renders variables in the text from the first time it
+-- is changed, unless variables says otherwise.
+UPDATE
+    core.user_commands
+SET
+    name = COALESCE(sqlc.narg('new_name')::varchar(50), name),
+    text = COALESCE(sqlc.narg('text')::text, text),
+    reply = COALESCE(sqlc.narg('reply')::bool, reply),
+    enabled = COALESCE(sqlc.narg('enabled')::bool, enabled),
+    response_mode = COALESCE(sqlc.narg('response_mode')::varchar(20), response_mode),
+    cooldown = COALESCE(sqlc.narg('cooldown')::integer, cooldown),
+    user_level = COALESCE(sqlc.narg('user_level')::varchar(20), user_level),
+    variables = COALESCE(sqlc.narg('variables')::bool, variables OR sqlc.narg('text')::text IS NOT NULL),
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND name = sqlc.arg('name')
+    AND deleted_at IS NULL
+RETURNING
+    *;
+
+-- name: CoreUserCommandRestore :one
+UPDATE
+    core.user_commands
+SET
+    deleted_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND name = sqlc.arg('name')
+    AND deleted_at IS NOT NULL
+RETURNING
+    *;
+
+-- name: CoreUserCommandArchiveDeleted :execrows
+-- CoreUserCommandArchiveDeleted frees the name of a soft deleted command, so
+-- it can be taken by a new or renamed one, by suffixing it with "~" and the
+-- time of the delete in milliseconds. Chat names cannot hold a "~", and the
+-- column is wider than chat names, so the row and its responses are kept.
+UPDATE
+    core.user_commands
+SET
+    name = name || '~' || floor(extract(epoch FROM deleted_at) * 1000)::bigint
+WHERE
+    user_id = sqlc.arg('user_id')
+    AND name = sqlc.arg('name')
+    AND deleted_at IS NOT NULL;
@@ -0,0 +1,8 @@
+-- name: UserPlatformAccountGet :one
+SELECT
+    *
+FROM
+    public.user_platform_accounts
+WHERE
+    platform = $1
+    AND platform_user_id = $2;
@@ -0,0 +1,53 @@
+-- The objects of arnobot-shared's schema that core's migrations and queries
+-- build on, copied from its db/schemas at the version in go.mod. It is only
+-- read by sqlc: shared owns and migrates these, so update this copy when
+-- bumping arnobot-shared instead of writing a migration for it.
+--
+-- core.user_commands is the exception. Shared creates it as copied below,
+-- but core owns every change since: its later columns come from
+-- internal/db/migrations, and shared must not migrate it again.
+
+CREATE SCHEMA IF NOT EXISTS public;
+
+CREATE TYPE public.user_status AS ENUM (
+    'active',
+    'banned',
+    'deactivated',
+    'deleted'
+);
+
+CREATE TYPE public.platform AS ENUM (
+    'twitch',
+    'kick'
+);
+
+CREATE TABLE public.users (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    username varchar(50) NOT NULL DEFAULT '',
+    status public.user_status NOT NULL DEFAULT 'active',
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE public.user_platform_accounts (
+    platform public.platform NOT NULL,
+    platform_user_id varchar(100) NOT NULL,
+    platform_user_name varchar(100) NOT NULL,
+    platform_user_login varchar(100) NOT NULL,
+    user_id uuid NOT NULL,
+    PRIMARY KEY (platform, platform_user_id),
+    FOREIGN KEY (user_id) REFERENCES public.users (id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE SCHEMA IF NOT EXISTS core;
+
+CREATE TABLE core.user_commands (
+    user_id uuid NOT NULL,
+    name varchar(50) NOT NULL,
+    text text NOT NULL,
+    reply boolean NOT NULL DEFAULT FALSE,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, name),
+    FOREIGN KEY (user_id) REFERENCES public.users (id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
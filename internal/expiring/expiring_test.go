@@ -0,0 +1,65 @@
+package expiring
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestCache(ttl time.Duration) (*Cache[string, int], *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New[string, int](ttl)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCacheExpires(t *testing.T) {
+	c, now := newTestCache(time.Minute)
+
+	c.Set("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+
+	*now = now.Add(time.Minute - time.Nanosecond)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Get(a) expired before its ttl")
+	}
+
+	*now = now.Add(time.Nanosecond)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get(a) did not expire after its ttl")
+	}
+}
+
+func TestCacheEvicts(t *testing.T) {
+	c, now := newTestCache(time.Minute)
+
+	for i, key := range []string{"a", "b", "c"} {
+		c.Set(key, i)
+	}
+	*now = now.Add(2 * time.Minute)
+	c.Set("d", 3)
+
+	if n := c.Len(); n != 1 {
+		t.Fatalf("Len() = %d after the others expired, want 1", n)
+	}
+}
+
+func TestCacheDeleteAndClear(t *testing.T) {
+	c, _ := newTestCache(time.Minute)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get(a) found a deleted entry")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Fatal("Delete(a) dropped b")
+	}
+
+	c.Clear()
+	if n := c.Len(); n != 0 {
+		t.Fatalf("Len() = %d after Clear, want 0", n)
+	}
+}
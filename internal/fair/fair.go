@@ -0,0 +1,79 @@
+// Package fair makes provably fair random numbers.
+//
+// A roll is drawn from a server seed, kept secret until it is revealed, a
+// client seed the server does not choose, and a nonce counting the rolls of
+// the server seed. Publishing the SHA-256 hash of the server seed before the
+// rolls commits to it, so once it is revealed anyone can check the hash and
+// draw the same numbers again:
+//
+//	HMAC-SHA256(key = server seed, message = "<client seed>:<nonce>:<cursor>")
+//
+// where the cursor counts the numbers drawn by the roll from 0. The first 8
+// bytes of the MAC, read as a big-endian unsigned integer x, give x mod n
+// for a number in [0, n); x is drawn again with the next cursor when it falls
+// in the incomplete range at the top that would make small numbers likelier.
+package fair
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"strconv"
+)
+
+const serverSeedBytes = 32
+
+// NewServerSeed returns a random server seed.
+func NewServerSeed() string {
+	seed := make([]byte, serverSeedBytes)
+	rand.Read(seed)
+	return hex.EncodeToString(seed)
+}
+
+// Hash returns the hash of a server seed published before its rolls.
+func Hash(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// Roller draws the numbers of one roll.
+type Roller struct {
+	serverSeed string
+	clientSeed string
+	nonce      int64
+	cursor     int
+}
+
+func NewRoller(serverSeed string, clientSeed string, nonce int64) *Roller {
+	return &Roller{
+		serverSeed: serverSeed,
+		clientSeed: clientSeed,
+		nonce:      nonce,
+	}
+}
+
+// IntN returns a number in [0, n). It panics if n <= 0, like math/rand.
+func (r *Roller) IntN(n int) int {
+	if n <= 0 {
+		panic("fair: invalid argument to IntN")
+	}
+
+	limit := math.MaxUint64 - math.MaxUint64%uint64(n)
+	for {
+		x := r.next()
+		if x < limit {
+			return int(x % uint64(n))
+		}
+	}
+}
+
+func (r *Roller) next() uint64 {
+	mac := hmac.New(sha256.New, []byte(r.serverSeed))
+	mac.Write([]byte(r.clientSeed + ":" + strconv.FormatInt(r.nonce, 10) + ":" + strconv.Itoa(r.cursor)))
+	r.cursor++
+
+	return binary.BigEndian.Uint64(mac.Sum(nil))
+}
@@ -0,0 +1,90 @@
+package fair
+
+import (
+	"math"
+	"testing"
+)
+
+// The expected numbers were computed apart from this package, from the
+// HMAC-SHA256 described in its doc comment.
+
+func TestHash(t *testing.T) {
+	want := "91024ec49c5bec0b689e42892526320fce08337205c91de94c7a588c20d08eeb"
+	if got := Hash("server-seed"); got != want {
+		t.Errorf("Hash = %s, want %s", got, want)
+	}
+}
+
+func TestNewServerSeed(t *testing.T) {
+	seed := NewServerSeed()
+	if len(seed) != 2*serverSeedBytes {
+		t.Errorf("len(NewServerSeed()) = %d, want %d", len(seed), 2*serverSeedBytes)
+	}
+	if seed == NewServerSeed() {
+		t.Error("NewServerSeed returned the same seed twice")
+	}
+}
+
+func TestRollerIntN(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{6, []int{2, 2, 0, 5, 4}},
+		{100, []int{18, 30, 72, 13, 82}},
+		{2, []int{0, 0, 0, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		r := NewRoller("server-seed", "client-seed", 7)
+		for i, want := range tt.want {
+			if got := r.IntN(tt.n); got != want {
+				t.Errorf("IntN(%d) #%d = %d, want %d", tt.n, i, got, want)
+			}
+		}
+	}
+}
+
+// TestRollerIntNRejects draws with n = 2^62+1, which rejects every x from
+// 0xc000000000000003 up. With nonce 6 the first two cursors fall there, so
+// the first number comes from cursor 2 and the next from cursor 3.
+func TestRollerIntNRejects(t *testing.T) {
+	n := 1<<62 + 1
+	if limit := uint64(math.MaxUint64) - math.MaxUint64%uint64(n); limit != 0xc000000000000003 {
+		t.Fatalf("limit = %#x, want 0xc000000000000003", limit)
+	}
+
+	r := NewRoller("server-seed", "client-seed", 6)
+	for i, want := range []int{1516039738304194901, 1644769274459211048} {
+		if got := r.IntN(n); got != want {
+			t.Errorf("IntN(2^62+1) #%d = %d, want %d", i, got, want)
+		}
+	}
+	if r.cursor != 4 {
+		t.Errorf("cursor = %d after two numbers and two rejections, want 4", r.cursor)
+	}
+}
+
+func TestRollerIntNRange(t *testing.T) {
+	r := NewRoller("server-seed", "client-seed", 0)
+	for _, n := range []int{1, 2, 3, 7, 1000, math.MaxInt} {
+		for range 100 {
+			if got := r.IntN(n); got < 0 || got >= n {
+				t.Fatalf("IntN(%d) = %d, out of [0, %d)", n, got, n)
+			}
+		}
+	}
+}
+
+func TestRollerIntNPanics(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("IntN(%d) did not panic", n)
+				}
+			}()
+			NewRoller("server-seed", "client-seed", 0).IntN(n)
+		}()
+	}
+}
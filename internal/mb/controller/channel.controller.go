@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type ChannelController struct {
+	channelService *service.ChannelService
+	logger         applog.Logger
+}
+
+func NewChannelController(
+	channelService *service.ChannelService,
+) *ChannelController {
+	logger := applog.NewServiceLogger("channel-controller")
+
+	return &ChannelController{
+		channelService: channelService,
+		logger:         logger,
+	}
+}
+
+func (c *ChannelController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CoreStreamStatus: c.StreamStatus,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *ChannelController) StreamStatus(msg *nats.Msg) {
+	handlePublish(msg, c.channelService.SetStreamStatus)
+}
@@ -2,20 +2,50 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/arnokay/arnobot-shared/apperror"
 	"github.com/arnokay/arnobot-shared/apptype"
 	"github.com/arnokay/arnobot-shared/trace"
 	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/appctx"
+	"github.com/arnokay/arnobot-core/internal/data"
 )
 
 type Controllers struct {
-	MessageController *MessageController
+	MessageController     *MessageController
+	UserCommandController *UserCommandController
+	ChannelController     *ChannelController
+	TimerController       *TimerController
+	TriggerController     *TriggerController
+	ModerationController  *ModerationController
+	PointsController      *PointsController
+	SlotController        *SlotController
+	FairController        *FairController
+	GiveawayController    *GiveawayController
+	PollController        *PollController
+	QuoteController       *QuoteController
+	TriviaController      *TriviaController
 }
 
 func (c *Controllers) Connect(conn *nats.Conn) {
 	c.MessageController.Connect(conn)
+	c.UserCommandController.Connect(conn)
+	c.ChannelController.Connect(conn)
+	c.TimerController.Connect(conn)
+	c.TriggerController.Connect(conn)
+	c.ModerationController.Connect(conn)
+	c.PointsController.Connect(conn)
+	c.SlotController.Connect(conn)
+	c.FairController.Connect(conn)
+	c.GiveawayController.Connect(conn)
+	c.PollController.Connect(conn)
+	c.QuoteController.Connect(conn)
+	c.TriviaController.Connect(conn)
 }
 
 func newControllerContext(traceID string) (context.Context, context.CancelFunc) {
@@ -55,6 +85,68 @@ func handleRequest[TReq, TResp any](
 	msg.Respond(b)
 }
 
+// authorizedResponse is apptype.Response extended with the invalid fields of
+// the request, if that is why it failed.
+type authorizedResponse[T any] struct {
+	apptype.Response[T]
+	Fields data.FieldErrors `json:"fields,omitempty"`
+}
+
+func (r *authorizedResponse[T]) ToFailErr(err error) {
+	r.Response.ToFailErr(err)
+	errors.As(err, &r.Fields)
+}
+
+func (r authorizedResponse[T]) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// handleAuthorizedRequest serves a request only to the owner of the requested
+// data. The caller is taken from the session token in the Authorization header
+// of the message, never from the payload, which any client can write.
+func handleAuthorizedRequest[TReq data.Owned, TResp any](
+	msg *nats.Msg,
+	authorizationService *service.AuthorizationService,
+	handler func(context.Context, TReq) (TResp, error),
+) {
+	var payload apptype.Request[TReq]
+	var response authorizedResponse[TResp]
+
+	err := json.Unmarshal(msg.Data, &payload)
+	if err != nil {
+		response.ToFailErr(apperror.New(apperror.CodeInvalidInput, "cannot decode payload", err))
+		b, _ := response.Encode()
+		msg.Respond(b)
+		return
+	}
+	response.TraceID = payload.TraceID
+
+	ctx, cancel := newControllerContext(payload.TraceID)
+	defer cancel()
+
+	actor, err := authorizationService.Authenticate(ctx, msg.Header.Get("Authorization"))
+	if err == nil {
+		err = authorizationService.Authorize(ctx, actor, payload.Data.OwnerID())
+	}
+	if err != nil {
+		response.ToFailErr(err)
+		b, _ := response.Encode()
+		msg.Respond(b)
+		return
+	}
+	ctx = appctx.SetActor(ctx, actor)
+
+	result, err := handler(ctx, payload.Data)
+	if err != nil {
+		response.ToFailErr(err)
+	} else {
+		response.ToSuccess(result)
+	}
+
+	b, _ := response.Encode()
+	msg.Respond(b)
+}
+
 func handlePublish[TReq any](
 	msg *nats.Msg,
 	handler func(context.Context, TReq) error,
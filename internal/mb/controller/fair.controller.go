@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type FairController struct {
+	fairService          *service.FairService
+	authorizationService *service.AuthorizationService
+	logger               applog.Logger
+}
+
+func NewFairController(
+	fairService *service.FairService,
+	authorizationService *service.AuthorizationService,
+) *FairController {
+	logger := applog.NewServiceLogger("fair-controller")
+
+	return &FairController{
+		fairService:          fairService,
+		authorizationService: authorizationService,
+		logger:               logger,
+	}
+}
+
+func (c *FairController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CoreFairSettingsGet:    c.GetSettings,
+		coreTopics.CoreFairSettingsUpdate: c.UpdateSettings,
+		coreTopics.CoreFairRollList:       c.Rolls,
+		coreTopics.CoreFairRollVerify:     c.Verify,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *FairController) GetSettings(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.fairService.GetSettings)
+}
+
+func (c *FairController) UpdateSettings(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.fairService.UpdateSettings)
+}
+
+func (c *FairController) Rolls(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.fairService.Rolls)
+}
+
+func (c *FairController) Verify(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.fairService.Verify)
+}
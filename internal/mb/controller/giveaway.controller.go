@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type GiveawayController struct {
+	giveawayService      *service.GiveawayService
+	authorizationService *service.AuthorizationService
+	logger               applog.Logger
+}
+
+func NewGiveawayController(
+	giveawayService *service.GiveawayService,
+	authorizationService *service.AuthorizationService,
+) *GiveawayController {
+	logger := applog.NewServiceLogger("giveaway-controller")
+
+	return &GiveawayController{
+		giveawayService:      giveawayService,
+		authorizationService: authorizationService,
+		logger:               logger,
+	}
+}
+
+func (c *GiveawayController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CoreGiveawayStart: c.Start,
+		coreTopics.CoreGiveawayGet:   c.Get,
+		coreTopics.CoreGiveawayClose: c.Close,
+		coreTopics.CoreGiveawayDraw:  c.Draw,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *GiveawayController) Start(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.giveawayService.Start)
+}
+
+func (c *GiveawayController) Get(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.giveawayService.Get)
+}
+
+func (c *GiveawayController) Close(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.giveawayService.Close)
+}
+
+func (c *GiveawayController) Draw(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.giveawayService.Draw)
+}
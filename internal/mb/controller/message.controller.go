@@ -9,18 +9,24 @@ import (
 	"github.com/nats-io/nats.go"
 
 	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
 )
 
 type MessageController struct {
 	messageService *service.MessageService
+	whisperService *service.WhisperService
 	logger         applog.Logger
 }
 
-func NewMessageController(messageService *service.MessageService) *MessageController {
+func NewMessageController(
+	messageService *service.MessageService,
+	whisperService *service.WhisperService,
+) *MessageController {
 	logger := applog.NewServiceLogger("message-controller")
 
 	return &MessageController{
 		messageService: messageService,
+		whisperService: whisperService,
 		logger:         logger,
 	}
 }
@@ -32,8 +38,18 @@ func (c *MessageController) Connect(conn *nats.Conn) {
 		Build()
 	_, err := conn.QueueSubscribe(topic, topic, c.NewChatMessage)
 	assert.NoError(err, "cannot start: "+topic)
+
+	topic = topics.TopicBuilder(coreTopics.PlatformWhisperNotify).
+		Platform(topics.Any).
+		Build()
+	_, err = conn.QueueSubscribe(topic, topic, c.NewWhisper)
+	assert.NoError(err, "cannot start: "+topic)
 }
 
 func (c *MessageController) NewChatMessage(msg *nats.Msg) {
 	handlePublish(msg, c.messageService.HandleNewMessage)
 }
+
+func (c *MessageController) NewWhisper(msg *nats.Msg) {
+	handlePublish(msg, c.whisperService.HandleNewWhisper)
+}
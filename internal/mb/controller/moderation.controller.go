@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type ModerationController struct {
+	moderationService    *service.ModerationService
+	bannedPhraseService  *service.BannedPhraseService
+	authorizationService *service.AuthorizationService
+	logger               applog.Logger
+}
+
+func NewModerationController(
+	moderationService *service.ModerationService,
+	bannedPhraseService *service.BannedPhraseService,
+	authorizationService *service.AuthorizationService,
+) *ModerationController {
+	logger := applog.NewServiceLogger("moderation-controller")
+
+	return &ModerationController{
+		moderationService:    moderationService,
+		bannedPhraseService:  bannedPhraseService,
+		authorizationService: authorizationService,
+		logger:               logger,
+	}
+}
+
+func (c *ModerationController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CoreModerationFilterList:   c.ListFilters,
+		coreTopics.CoreModerationFilterUpdate: c.UpdateFilter,
+		coreTopics.CoreBannedPhraseCreate:     c.CreateBannedPhrase,
+		coreTopics.CoreBannedPhraseDelete:     c.DeleteBannedPhrase,
+		coreTopics.CoreBannedPhraseList:       c.ListBannedPhrases,
+		coreTopics.CoreStrikeLadderGet:        c.GetStrikeLadder,
+		coreTopics.CoreStrikeLadderUpdate:     c.UpdateStrikeLadder,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *ModerationController) ListFilters(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.moderationService.List)
+}
+
+func (c *ModerationController) UpdateFilter(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.moderationService.Update)
+}
+
+func (c *ModerationController) CreateBannedPhrase(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.bannedPhraseService.Create)
+}
+
+func (c *ModerationController) DeleteBannedPhrase(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.bannedPhraseService.Delete)
+}
+
+func (c *ModerationController) ListBannedPhrases(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.bannedPhraseService.List)
+}
+
+func (c *ModerationController) GetStrikeLadder(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.moderationService.GetLadder)
+}
+
+func (c *ModerationController) UpdateStrikeLadder(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.moderationService.UpdateLadder)
+}
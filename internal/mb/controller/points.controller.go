@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type PointsController struct {
+	pointsService        *service.PointsService
+	authorizationService *service.AuthorizationService
+	logger               applog.Logger
+}
+
+func NewPointsController(
+	pointsService *service.PointsService,
+	authorizationService *service.AuthorizationService,
+) *PointsController {
+	logger := applog.NewServiceLogger("points-controller")
+
+	return &PointsController{
+		pointsService:        pointsService,
+		authorizationService: authorizationService,
+		logger:               logger,
+	}
+}
+
+func (c *PointsController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CorePointsSettingsGet:    c.GetSettings,
+		coreTopics.CorePointsSettingsUpdate: c.UpdateSettings,
+		coreTopics.CorePointsBalance:        c.Balance,
+		coreTopics.CorePointsAdjust:         c.Adjust,
+		coreTopics.CorePointsTransfer:       c.Transfer,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *PointsController) GetSettings(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.pointsService.GetSettings)
+}
+
+func (c *PointsController) UpdateSettings(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.pointsService.UpdateSettings)
+}
+
+func (c *PointsController) Balance(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.pointsService.Balance)
+}
+
+func (c *PointsController) Adjust(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.pointsService.Adjust)
+}
+
+func (c *PointsController) Transfer(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.pointsService.Transfer)
+}
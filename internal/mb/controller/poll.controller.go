@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type PollController struct {
+	pollService          *service.PollService
+	authorizationService *service.AuthorizationService
+	logger               applog.Logger
+}
+
+func NewPollController(
+	pollService *service.PollService,
+	authorizationService *service.AuthorizationService,
+) *PollController {
+	logger := applog.NewServiceLogger("poll-controller")
+
+	return &PollController{
+		pollService:          pollService,
+		authorizationService: authorizationService,
+		logger:               logger,
+	}
+}
+
+func (c *PollController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CorePollStart: c.Start,
+		coreTopics.CorePollGet:   c.Get,
+		coreTopics.CorePollEnd:   c.End,
+		coreTopics.CorePollList:  c.List,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *PollController) Start(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.pollService.Start)
+}
+
+func (c *PollController) Get(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.pollService.Get)
+}
+
+func (c *PollController) End(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.pollService.End)
+}
+
+func (c *PollController) List(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.pollService.List)
+}
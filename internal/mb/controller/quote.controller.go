@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type QuoteController struct {
+	quoteService         *service.QuoteService
+	authorizationService *service.AuthorizationService
+	logger               applog.Logger
+}
+
+func NewQuoteController(
+	quoteService *service.QuoteService,
+	authorizationService *service.AuthorizationService,
+) *QuoteController {
+	logger := applog.NewServiceLogger("quote-controller")
+
+	return &QuoteController{
+		quoteService:         quoteService,
+		authorizationService: authorizationService,
+		logger:               logger,
+	}
+}
+
+func (c *QuoteController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CoreQuoteList:   c.List,
+		coreTopics.CoreQuoteGet:    c.Get,
+		coreTopics.CoreQuoteCreate: c.Create,
+		coreTopics.CoreQuoteUpdate: c.Update,
+		coreTopics.CoreQuoteDelete: c.Delete,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *QuoteController) List(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.quoteService.List)
+}
+
+func (c *QuoteController) Get(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.quoteService.Get)
+}
+
+func (c *QuoteController) Create(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.quoteService.Create)
+}
+
+func (c *QuoteController) Update(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.quoteService.Update)
+}
+
+func (c *QuoteController) Delete(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.quoteService.Delete)
+}
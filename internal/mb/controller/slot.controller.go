@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type SlotController struct {
+	slotService          *service.SlotService
+	authorizationService *service.AuthorizationService
+	logger               applog.Logger
+}
+
+func NewSlotController(
+	slotService *service.SlotService,
+	authorizationService *service.AuthorizationService,
+) *SlotController {
+	logger := applog.NewServiceLogger("slot-controller")
+
+	return &SlotController{
+		slotService:          slotService,
+		authorizationService: authorizationService,
+		logger:               logger,
+	}
+}
+
+func (c *SlotController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CoreSlotMachineGet:    c.Get,
+		coreTopics.CoreSlotMachineUpdate: c.Update,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *SlotController) Get(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.slotService.Get)
+}
+
+func (c *SlotController) Update(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.slotService.Update)
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type TimerController struct {
+	timerService         *service.TimerService
+	authorizationService *service.AuthorizationService
+	logger               applog.Logger
+}
+
+func NewTimerController(
+	timerService *service.TimerService,
+	authorizationService *service.AuthorizationService,
+) *TimerController {
+	logger := applog.NewServiceLogger("timer-controller")
+
+	return &TimerController{
+		timerService:         timerService,
+		authorizationService: authorizationService,
+		logger:               logger,
+	}
+}
+
+func (c *TimerController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CoreTimerCreate: c.Create,
+		coreTopics.CoreTimerUpdate: c.Update,
+		coreTopics.CoreTimerDelete: c.Delete,
+		coreTopics.CoreTimerList:   c.List,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *TimerController) Create(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.timerService.Create)
+}
+
+func (c *TimerController) Update(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.timerService.Update)
+}
+
+func (c *TimerController) Delete(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.timerService.Delete)
+}
+
+func (c *TimerController) List(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.timerService.List)
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type TriggerController struct {
+	triggerService       *service.TriggerService
+	authorizationService *service.AuthorizationService
+	logger               applog.Logger
+}
+
+func NewTriggerController(
+	triggerService *service.TriggerService,
+	authorizationService *service.AuthorizationService,
+) *TriggerController {
+	logger := applog.NewServiceLogger("trigger-controller")
+
+	return &TriggerController{
+		triggerService:       triggerService,
+		authorizationService: authorizationService,
+		logger:               logger,
+	}
+}
+
+func (c *TriggerController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CoreTriggerCreate: c.Create,
+		coreTopics.CoreTriggerUpdate: c.Update,
+		coreTopics.CoreTriggerDelete: c.Delete,
+		coreTopics.CoreTriggerList:   c.List,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *TriggerController) Create(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triggerService.Create)
+}
+
+func (c *TriggerController) Update(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triggerService.Update)
+}
+
+func (c *TriggerController) Delete(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triggerService.Delete)
+}
+
+func (c *TriggerController) List(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triggerService.List)
+}
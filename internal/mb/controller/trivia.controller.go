@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
+	"github.com/nats-io/nats.go"
+
+	"github.com/arnokay/arnobot-core/internal/app/service"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
+)
+
+type TriviaController struct {
+	triviaService        *service.TriviaService
+	authorizationService *service.AuthorizationService
+	logger               applog.Logger
+}
+
+func NewTriviaController(
+	triviaService *service.TriviaService,
+	authorizationService *service.AuthorizationService,
+) *TriviaController {
+	logger := applog.NewServiceLogger("trivia-controller")
+
+	return &TriviaController{
+		triviaService:        triviaService,
+		authorizationService: authorizationService,
+		logger:               logger,
+	}
+}
+
+func (c *TriviaController) Connect(conn *nats.Conn) {
+	subscriptions := map[string]nats.MsgHandler{
+		coreTopics.CoreTriviaStart:          c.Start,
+		coreTopics.CoreTriviaGet:            c.Get,
+		coreTopics.CoreTriviaStop:           c.Stop,
+		coreTopics.CoreTriviaTop:            c.Top,
+		coreTopics.CoreTriviaImport:         c.Import,
+		coreTopics.CoreTriviaCategories:     c.Categories,
+		coreTopics.CoreTriviaCategoryDelete: c.DeleteCategory,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+func (c *TriviaController) Start(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triviaService.Start)
+}
+
+func (c *TriviaController) Get(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triviaService.Get)
+}
+
+func (c *TriviaController) Stop(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triviaService.Stop)
+}
+
+func (c *TriviaController) Top(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triviaService.Top)
+}
+
+func (c *TriviaController) Import(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triviaService.Import)
+}
+
+func (c *TriviaController) Categories(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triviaService.Categories)
+}
+
+func (c *TriviaController) DeleteCategory(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.triviaService.DeleteCategory)
+}
@@ -1,55 +1,152 @@
 package controller
 
 import (
-	
+	"context"
 
 	"github.com/arnokay/arnobot-shared/applog"
+	"github.com/arnokay/arnobot-shared/pkg/assert"
 	"github.com/arnokay/arnobot-shared/topics"
 	"github.com/nats-io/nats.go"
 
 	"github.com/arnokay/arnobot-core/internal/app/service"
+	"github.com/arnokay/arnobot-core/internal/data"
+	coreTopics "github.com/arnokay/arnobot-core/internal/topics"
 )
 
 type UserCommandController struct {
-	userCommandService *service.UserCommandService
-	logger             applog.Logger
+	userCommandService         *service.UserCommandService
+	userCommandTransferService *service.UserCommandTransferService
+	authorizationService       *service.AuthorizationService
+	logger                     applog.Logger
 }
 
 func NewUserCommandController(
 	userCommandService *service.UserCommandService,
+	userCommandTransferService *service.UserCommandTransferService,
+	authorizationService *service.AuthorizationService,
 ) *UserCommandController {
 	logger := applog.NewServiceLogger("user-command-controller")
 
 	return &UserCommandController{
-		userCommandService: userCommandService,
-		logger:             logger,
+		userCommandService:         userCommandService,
+		userCommandTransferService: userCommandTransferService,
+		authorizationService:       authorizationService,
+		logger:                     logger,
 	}
 }
 
 func (c *UserCommandController) Connect(conn *nats.Conn) {
-	conn.QueueSubscribe(topics.CoreUserCommandCreate, topics.CoreUserCommandCreate, c.Create)
-	conn.QueueSubscribe(topics.CoreUserCommandUpdate, topics.CoreUserCommandUpdate, c.Update)
-	conn.QueueSubscribe(topics.CoreUserCommandDelete, topics.CoreUserCommandDelete, c.Delete)
-	conn.QueueSubscribe(topics.CoreUserCommandGetOne, topics.CoreUserCommandGetOne, c.GetOne)
-	conn.QueueSubscribe(topics.CoreUserCommandGetByUserID, topics.CoreUserCommandGetByUserID, c.GetByUserID)
+	subscriptions := map[string]nats.MsgHandler{
+		topics.CoreUserCommandCreate:             c.InternalCreate,
+		topics.CoreUserCommandUpdate:             c.InternalUpdate,
+		topics.CoreUserCommandDelete:             c.InternalDelete,
+		topics.CoreUserCommandGetOne:             c.InternalGetOne,
+		topics.CoreUserCommandGetByUserID:        c.InternalGetByUserID,
+		coreTopics.CoreUserCommandCreate:         c.Create,
+		coreTopics.CoreUserCommandUpdate:         c.Update,
+		coreTopics.CoreUserCommandDelete:         c.Delete,
+		coreTopics.CoreUserCommandGetOne:         c.GetOne,
+		coreTopics.CoreUserCommandGetByUserID:    c.GetByUserID,
+		coreTopics.CoreUserCommandList:           c.List,
+		coreTopics.CoreUserCommandResponseAdd:    c.AddResponse,
+		coreTopics.CoreUserCommandResponseDelete: c.DeleteResponse,
+		coreTopics.CoreUserCommandImport:         c.Import,
+		coreTopics.CoreUserCommandExport:         c.Export,
+		coreTopics.CoreUserCommandRestore:        c.Restore,
+		coreTopics.CoreUserCommandUndo:           c.Undo,
+		coreTopics.CoreUserCommandRevisions:      c.Revisions,
+	}
+
+	for topic, handler := range subscriptions {
+		_, err := conn.QueueSubscribe(topic, topic, handler)
+		assert.NoError(err, "cannot start: "+topic)
+	}
+}
+
+// The Internal handlers serve the arnobot-shared topics, whose
+// UserCommandModule sends no session token. They trust the user id of the
+// payload, as every topic did before sessions were required, and go away once
+// the module sends the session to the authorized topics.
+
+func (c *UserCommandController) InternalGetByUserID(msg *nats.Msg) {
+	handleRequest(
+		msg,
+		func(ctx context.Context, arg data.UserCommandGetByUserID) ([]data.UserCommand, error) {
+			return c.userCommandService.GetByUserID(ctx, arg.OwnerID())
+		},
+	)
+}
+
+func (c *UserCommandController) InternalGetOne(msg *nats.Msg) {
+	handleRequest(msg, c.userCommandService.GetOne)
+}
+
+func (c *UserCommandController) InternalCreate(msg *nats.Msg) {
+	handleRequest(msg, c.userCommandService.Create)
+}
+
+func (c *UserCommandController) InternalUpdate(msg *nats.Msg) {
+	handleRequest(msg, c.userCommandService.Update)
+}
+
+func (c *UserCommandController) InternalDelete(msg *nats.Msg) {
+	handleRequest(msg, c.userCommandService.Delete)
 }
 
 func (c *UserCommandController) GetByUserID(msg *nats.Msg) {
-	handleRequest(msg, c.userCommandService.GetByUserID)
+	handleAuthorizedRequest(
+		msg,
+		c.authorizationService,
+		func(ctx context.Context, arg data.UserCommandGetByUserID) ([]data.UserCommand, error) {
+			return c.userCommandService.GetByUserID(ctx, arg.OwnerID())
+		},
+	)
+}
+
+func (c *UserCommandController) List(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandService.List)
 }
 
 func (c *UserCommandController) GetOne(msg *nats.Msg) {
-	handleRequest(msg, c.userCommandService.GetOne)
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandService.GetOne)
 }
 
 func (c *UserCommandController) Create(msg *nats.Msg) {
-	handleRequest(msg, c.userCommandService.Create)
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandService.Create)
 }
 
 func (c *UserCommandController) Update(msg *nats.Msg) {
-	handleRequest(msg, c.userCommandService.Update)
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandService.Update)
 }
 
 func (c *UserCommandController) Delete(msg *nats.Msg) {
-	handleRequest(msg, c.userCommandService.Delete)
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandService.Delete)
+}
+
+func (c *UserCommandController) AddResponse(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandService.AddResponse)
+}
+
+func (c *UserCommandController) DeleteResponse(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandService.DeleteResponse)
+}
+
+func (c *UserCommandController) Import(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandTransferService.Import)
+}
+
+func (c *UserCommandController) Export(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandTransferService.Export)
+}
+
+func (c *UserCommandController) Restore(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandService.Restore)
+}
+
+func (c *UserCommandController) Undo(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandService.Undo)
+}
+
+func (c *UserCommandController) Revisions(msg *nats.Msg) {
+	handleAuthorizedRequest(msg, c.authorizationService, c.userCommandService.Revisions)
 }
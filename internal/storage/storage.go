@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+
+	sharedDB "github.com/arnokay/arnobot-shared/db"
+	"github.com/arnokay/arnobot-shared/service"
+	"github.com/arnokay/arnobot-shared/storage"
+
+	"github.com/arnokay/arnobot-core/internal/db"
+)
+
+// Storager exposes the core queries alongside the shared storage so services
+// can mix both inside one transaction.
+type Storager interface {
+	Query(ctx context.Context) db.Querier
+	Shared() storage.Storager
+	Database(ctx context.Context) sharedDB.DBTX
+	HandleErr(ctx context.Context, err error) error
+}
+
+type Storage struct {
+	shared *storage.Storage
+	query  *db.Queries
+}
+
+func NewStorage(database sharedDB.DBTX) *Storage {
+	return &Storage{
+		shared: storage.NewStorage(database),
+		query:  db.New(database),
+	}
+}
+
+func (s *Storage) Query(ctx context.Context) db.Querier {
+	if tx := service.ExtractTx(ctx); tx != nil {
+		return s.query.WithTx(tx)
+	}
+	return s.query
+}
+
+func (s *Storage) Shared() storage.Storager {
+	return s.shared
+}
+
+func (s *Storage) Database(ctx context.Context) sharedDB.DBTX {
+	return s.shared.Database(ctx)
+}
+
+func (s *Storage) HandleErr(ctx context.Context, err error) error {
+	return s.shared.HandleErr(ctx, err)
+}
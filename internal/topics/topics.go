@@ -0,0 +1,94 @@
+// topics served by core that are not part of arnobot-shared/topics
+package topics
+
+// Core topics
+const (
+	// The user command topics of arnobot-shared stay open to internal callers
+	// that send no session, as its UserCommandModule does, until that moves
+	// to these.
+	CoreUserCommandCreate      = "core.user-command.authorized.create"
+	CoreUserCommandUpdate      = "core.user-command.authorized.update"
+	CoreUserCommandDelete      = "core.user-command.authorized.delete"
+	CoreUserCommandGetOne      = "core.user-command.authorized.get-one"
+	CoreUserCommandGetByUserID = "core.user-command.authorized.get-by-user-id"
+
+	CoreUserCommandList           = "core.user-command.list"
+	CoreUserCommandResponseAdd    = "core.user-command.response.add"
+	CoreUserCommandResponseDelete = "core.user-command.response.delete"
+	CoreUserCommandImport         = "core.user-command.import"
+	CoreUserCommandExport         = "core.user-command.export"
+	CoreUserCommandRestore        = "core.user-command.restore"
+	CoreUserCommandUndo           = "core.user-command.undo"
+	CoreUserCommandRevisions      = "core.user-command.revisions"
+
+	CoreTimerCreate = "core.timer.create"
+	CoreTimerUpdate = "core.timer.update"
+	CoreTimerDelete = "core.timer.delete"
+	CoreTimerList   = "core.timer.list"
+
+	CoreTriggerCreate = "core.trigger.create"
+	CoreTriggerUpdate = "core.trigger.update"
+	CoreTriggerDelete = "core.trigger.delete"
+	CoreTriggerList   = "core.trigger.list"
+
+	CoreModerationFilterList   = "core.moderation.filter.list"
+	CoreModerationFilterUpdate = "core.moderation.filter.update"
+
+	CoreBannedPhraseCreate = "core.moderation.banned-phrase.create"
+	CoreBannedPhraseDelete = "core.moderation.banned-phrase.delete"
+	CoreBannedPhraseList   = "core.moderation.banned-phrase.list"
+
+	CoreStrikeLadderGet    = "core.moderation.strike-ladder.get"
+	CoreStrikeLadderUpdate = "core.moderation.strike-ladder.update"
+
+	CorePointsSettingsGet    = "core.points.settings.get"
+	CorePointsSettingsUpdate = "core.points.settings.update"
+	CorePointsBalance        = "core.points.balance"
+	CorePointsAdjust         = "core.points.adjust"
+	CorePointsTransfer       = "core.points.transfer"
+
+	CoreSlotMachineGet    = "core.slots.get"
+	CoreSlotMachineUpdate = "core.slots.update"
+
+	CoreFairSettingsGet    = "core.fair.settings.get"
+	CoreFairSettingsUpdate = "core.fair.settings.update"
+	CoreFairRollList       = "core.fair.roll.list"
+	CoreFairRollVerify     = "core.fair.roll.verify"
+
+	CoreGiveawayStart = "core.giveaway.start"
+	CoreGiveawayGet   = "core.giveaway.get"
+	CoreGiveawayClose = "core.giveaway.close"
+	CoreGiveawayDraw  = "core.giveaway.draw"
+
+	CorePollStart = "core.poll.start"
+	CorePollGet   = "core.poll.get"
+	CorePollEnd   = "core.poll.end"
+	CorePollList  = "core.poll.list"
+
+	CoreQuoteList   = "core.quote.list"
+	CoreQuoteGet    = "core.quote.get"
+	CoreQuoteCreate = "core.quote.create"
+	CoreQuoteUpdate = "core.quote.update"
+	CoreQuoteDelete = "core.quote.delete"
+
+	CoreTriviaStart          = "core.trivia.start"
+	CoreTriviaGet            = "core.trivia.get"
+	CoreTriviaStop           = "core.trivia.stop"
+	CoreTriviaTop            = "core.trivia.top"
+	CoreTriviaImport         = "core.trivia.import"
+	CoreTriviaCategories     = "core.trivia.categories"
+	CoreTriviaCategoryDelete = "core.trivia.category.delete"
+
+	// CoreStreamStatus is published by the platform modules when a stream
+	// goes online or offline.
+	CoreStreamStatus = "core.stream.status"
+)
+
+// Platform topics core relies on that are not part of arnobot-shared/topics,
+// to be built with topics.TopicBuilder.
+const (
+	PlatformWhisperNotify = "chat.whisper.notify.{platform}"
+	PlatformWhisperSend   = "chat.whisper.send.{platform}"
+
+	PlatformBroadcasterChatModerate = "chat.moderate.{platform}.{broadcasterID}"
+)
@@ -0,0 +1,126 @@
+package trivia
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultCategory is given to questions of a pack that have none.
+const DefaultCategory = "general"
+
+//go:embed default.json
+var defaultPack []byte
+
+var parseDefault = sync.OnceValue(func() []Question {
+	questions, err := ParseJSON(strings.NewReader(string(defaultPack)))
+	if err != nil {
+		panic("trivia: bad default pack: " + err.Error())
+	}
+	return questions
+})
+
+// Default returns the pack every channel can play without importing one.
+func Default() []Question {
+	return slices.Clone(parseDefault())
+}
+
+// jsonQuestion takes a single answer too, the way packs are often written.
+type jsonQuestion struct {
+	Category string   `json:"category"`
+	Question string   `json:"question"`
+	Answer   string   `json:"answer"`
+	Answers  []string `json:"answers"`
+}
+
+// ParseJSON reads a pack written as a list of questions, like
+//
+//	[{"category": "science", "question": "H2O is?", "answers": ["water"]}]
+//
+// where "answer" can stand for a single answer.
+func ParseJSON(r io.Reader) ([]Question, error) {
+	var fromJSON []jsonQuestion
+	err := json.NewDecoder(r).Decode(&fromJSON)
+	if err != nil {
+		return nil, errors.New("not a list of questions: " + err.Error())
+	}
+
+	questions := make([]Question, 0, len(fromJSON))
+	for i, q := range fromJSON {
+		answers := q.Answers
+		if q.Answer != "" {
+			answers = append([]string{q.Answer}, answers...)
+		}
+		question, err := newQuestion(q.Category, q.Question, answers)
+		if err != nil {
+			return nil, errors.New("question " + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+		questions = append(questions, question)
+	}
+
+	return questions, nil
+}
+
+// ParseCSV reads a pack with a question per row, as category, question and
+// then one or more answers. A first row starting with "category,question" is
+// taken for a header.
+func ParseCSV(r io.Reader) ([]Question, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var questions []Question
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && len(record) >= 2 && strings.EqualFold(record[0], "category") && strings.EqualFold(record[1], "question") {
+			continue
+		}
+		if len(record) < 3 {
+			return nil, errors.New("line " + strconv.Itoa(line) + ": needs a category, a question and an answer")
+		}
+
+		question, err := newQuestion(record[0], record[1], record[2:])
+		if err != nil {
+			return nil, errors.New("line " + strconv.Itoa(line) + ": " + err.Error())
+		}
+		questions = append(questions, question)
+	}
+
+	return questions, nil
+}
+
+func newQuestion(category string, question string, answers []string) (Question, error) {
+	q := Question{
+		Category: strings.ToLower(strings.TrimSpace(category)),
+		Question: strings.TrimSpace(question),
+	}
+	for _, answer := range answers {
+		if answer = strings.TrimSpace(answer); answer != "" {
+			q.Answers = append(q.Answers, answer)
+		}
+	}
+
+	if q.Category == "" {
+		q.Category = DefaultCategory
+	}
+
+	switch {
+	case q.Question == "":
+		return Question{}, errors.New("no question")
+	case len(q.Answers) == 0:
+		return Question{}, errors.New("no answer")
+	}
+	return q, nil
+}
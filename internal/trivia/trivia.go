@@ -0,0 +1,110 @@
+// Package trivia matches answers to trivia questions and reads question
+// packs.
+//
+// Answers are compared loosely, as chat types them: case, accents,
+// punctuation and a leading "the", "a" or "an" do not count, and longer
+// answers forgive a typo or two. Answers with digits must be exact, so 1945
+// is not 1946.
+package trivia
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var articles = []string{"the ", "a ", "an "}
+
+// Question is asked in chat and answered by any of Answers, the first of
+// which is revealed when no one gets it.
+type Question struct {
+	Category string   `json:"category"`
+	Question string   `json:"question"`
+	Answers  []string `json:"answers"`
+}
+
+// Match reports whether the guess answers the question.
+func (q Question) Match(guess string) bool {
+	guess = Normalize(guess)
+	if guess == "" {
+		return false
+	}
+
+	for _, answer := range q.Answers {
+		answer = Normalize(answer)
+		if answer != "" && distance(answer, guess) <= tolerance(answer) {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize folds case and accents, drops punctuation and a leading article,
+// and turns runs of spaces into one.
+func Normalize(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+
+	space := true
+	for _, r := range norm.NFKD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+			space = false
+		case unicode.IsSpace(r) && !space:
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+
+	normalized := strings.TrimSuffix(b.String(), " ")
+	for _, article := range articles {
+		if rest, ok := strings.CutPrefix(normalized, article); ok {
+			return rest
+		}
+	}
+	return normalized
+}
+
+// tolerance is how many typos an answer forgives.
+func tolerance(answer string) int {
+	if strings.ContainsFunc(answer, unicode.IsDigit) {
+		return 0
+	}
+
+	switch n := len([]rune(answer)); {
+	case n < 5:
+		return 0
+	case n < 9:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// distance is the Levenshtein distance between a and b, in runes.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,100 @@
+package trivia
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Water", "water"},
+		{"  The   Beatles! ", "beatles"},
+		{"a cat", "cat"},
+		{"An apple", "apple"},
+		{"theatre", "theatre"},
+		{"Pokémon", "pokemon"},
+		{"rock-n-roll", "rocknroll"},
+		{"1,945", "1945"},
+		{"?!", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.text); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTolerance(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   int
+	}{
+		{"cat", 0},
+		{"lion", 0},
+		{"water", 1},
+		{"elephant", 1},
+		{"jellyfish", 2},
+		{"1945", 0},
+		{"area 51 and beyond", 0},
+		{"éclair", 1},
+	}
+
+	for _, tt := range tests {
+		if got := tolerance(tt.answer); got != tt.want {
+			t.Errorf("tolerance(%q) = %d, want %d", tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"water", "water", 0},
+		{"water", "watr", 1},
+		{"water", "waters", 1},
+		{"water", "wafer", 1},
+		{"kitten", "sitting", 3},
+		{"héllo", "hello", 1},
+	}
+
+	for _, tt := range tests {
+		if got := distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := distance(tt.b, tt.a); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestQuestionMatch(t *testing.T) {
+	q := Question{Answers: []string{"The Beatles", "1964", "Fab Four"}}
+
+	tests := []struct {
+		guess string
+		want  bool
+	}{
+		{"beatles", true},
+		{"the beetles", true},
+		{"beatle", true},
+		{"btles", false},
+		{"1964", true},
+		{"1965", false},
+		{"fab four!", true},
+		{"fab", false},
+		{"", false},
+		{"the", false},
+	}
+
+	for _, tt := range tests {
+		if got := q.Match(tt.guess); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.guess, got, tt.want)
+		}
+	}
+}